@@ -1,3 +1,8 @@
+// Package account wraps the Bybit v5 account endpoints.
+//
+// Balances, rates and other amounts are kept as the decimal strings Bybit sends. Each such
+// field has an accessor that decodes it into an exact client.Decimal on request, such as
+// CoinDetails.WalletBalanceDecimal for CoinDetails.WalletBalance.
 package account
 
 import "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -11,7 +12,7 @@ type Borrow struct {
 	client *client.Client
 }
 
-func (b *Borrow) GetHistory(currency string, startTime, endTime, limit int, cursor string) (*BorrowRes, error) {
+func (b *Borrow) GetHistory(ctx context.Context, currency string, startTime, endTime, limit int, cursor string) (*BorrowRes, error) {
 	params := client.Params{}
 
 	if currency != "" {
@@ -30,7 +31,7 @@ func (b *Borrow) GetHistory(currency string, startTime, endTime, limit int, curs
 		params["cursor"] = cursor
 	}
 
-	response, err := b.client.Get(Endpoints.Borrow, params)
+	response, err := b.client.GetContext(ctx, Endpoints.Borrow, params)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +47,19 @@ func (b *Borrow) GetHistory(currency string, startTime, endTime, limit int, curs
 
 	return &borrowRes, nil
 }
+
+// IterateHistory returns an iterator over the borrow history, following nextPageCursor
+// across pages. Zero times and limit are omitted.
+func (b *Borrow) IterateHistory(currency string, startTime, endTime, limit int) *client.Iterator[BorrowItem] {
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[BorrowItem], error) {
+		res, err := b.GetHistory(ctx, currency, startTime, endTime, limit, cursor)
+		if err != nil {
+			return client.Page[BorrowItem]{}, err
+		}
+		return client.Page[BorrowItem]{Items: res.Result.List, NextCursor: res.Result.NextPageCursor}, nil
+	})
+}
+
 func NewBorrow(client_ *client.Client) *Borrow {
 	if client_ == nil {
 		panic("client should not be nil")
@@ -1,6 +1,10 @@
 package account
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+import (
+	"context"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
 
 const twoHundred = 200
 
@@ -12,7 +16,7 @@ func NewCoinGreeks(client_ *client.Client) *CoinGreeks {
 	return &CoinGreeks{client: client_}
 }
 
-func (cg *CoinGreeks) Get(coin string) (*CoinGreekRes, error) {
+func (cg *CoinGreeks) Get(ctx context.Context, coin string) (*CoinGreekRes, error) {
 	params := client.Params{}
 
 	// Only add baseCoin to parameters if provided.
@@ -20,7 +24,7 @@ func (cg *CoinGreeks) Get(coin string) (*CoinGreekRes, error) {
 		params["baseCoin"] = coin
 	}
 
-	response, err := cg.client.Get(Endpoints.CoinGreek, params)
+	response, err := cg.client.GetContext(ctx, Endpoints.CoinGreek, params)
 	if err != nil {
 		return nil, err
 	}
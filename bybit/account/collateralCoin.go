@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -15,7 +16,7 @@ func NewSetCollateralCoin(c *client.Client) *CollateralCoin {
 	return &CollateralCoin{client: c}
 }
 
-func (s *CollateralCoin) Set(coin string, collateralSwitch CollateralSwitch) (*CollateralInfoResponse, error) {
+func (s *CollateralCoin) Set(ctx context.Context, coin string, collateralSwitch CollateralSwitch) (*CollateralInfoResponse, error) {
 	if coin == "USDT" || coin == "USDC" {
 		return nil, errors.New("USDT and USDC cannot be switched off")
 	}
@@ -29,7 +30,7 @@ func (s *CollateralCoin) Set(coin string, collateralSwitch CollateralSwitch) (*C
 		params["collateralSwitch"] = "OFF"
 	}
 
-	response, err := s.client.Post(Endpoints.Collateral, params)
+	response, err := s.client.PostContext(ctx, Endpoints.Collateral, params)
 
 	if err != nil {
 		return nil, err
@@ -47,13 +48,13 @@ func (s *CollateralCoin) Set(coin string, collateralSwitch CollateralSwitch) (*C
 	return &resp, nil
 }
 
-func (s *CollateralCoin) GetInfo(currency string) (*CollateralInfoResponse, error) {
+func (s *CollateralCoin) GetInfo(ctx context.Context, currency string) (*CollateralInfoResponse, error) {
 	params := client.Params{}
 	if currency != "" {
 		params["currency"] = currency
 	}
 
-	response, err := s.client.Get("/v5/account/collateral-info", params)
+	response, err := s.client.GetContext(ctx, "/v5/account/collateral-info", params)
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,281 @@
+package account
+
+import "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+
+// The account responses keep Bybit's decimal strings. The accessors below decode them into
+// client.Decimal for callers that need exact arithmetic, such as summing balances.
+
+// decimal parses an amount. The empty string Bybit sends for unset amounts, and any
+// malformed value, read as zero.
+func decimal(s string) client.Decimal {
+	d, err := client.ParseDecimal(s)
+	if err != nil {
+		return client.Decimal{}
+	}
+	return d
+}
+
+// AvailableToBorrowDecimal returns AvailableToBorrow as a client.Decimal.
+func (c CoinDetails) AvailableToBorrowDecimal() client.Decimal {
+	return decimal(c.AvailableToBorrow)
+}
+
+// BonusDecimal returns Bonus as a client.Decimal.
+func (c CoinDetails) BonusDecimal() client.Decimal {
+	return decimal(c.Bonus)
+}
+
+// AccruedInterestDecimal returns AccruedInterest as a client.Decimal.
+func (c CoinDetails) AccruedInterestDecimal() client.Decimal {
+	return decimal(c.AccruedInterest)
+}
+
+// AvailableToWithdrawDecimal returns AvailableToWithdraw as a client.Decimal.
+func (c CoinDetails) AvailableToWithdrawDecimal() client.Decimal {
+	return decimal(c.AvailableToWithdraw)
+}
+
+// TotalOrderIMDecimal returns TotalOrderIM as a client.Decimal.
+func (c CoinDetails) TotalOrderIMDecimal() client.Decimal {
+	return decimal(c.TotalOrderIM)
+}
+
+// EquityDecimal returns Equity as a client.Decimal.
+func (c CoinDetails) EquityDecimal() client.Decimal {
+	return decimal(c.Equity)
+}
+
+// TotalPositionMMDecimal returns TotalPositionMM as a client.Decimal.
+func (c CoinDetails) TotalPositionMMDecimal() client.Decimal {
+	return decimal(c.TotalPositionMM)
+}
+
+// UsdValueDecimal returns UsdValue as a client.Decimal.
+func (c CoinDetails) UsdValueDecimal() client.Decimal {
+	return decimal(c.UsdValue)
+}
+
+// UnrealisedPnlDecimal returns UnrealisedPnl as a client.Decimal.
+func (c CoinDetails) UnrealisedPnlDecimal() client.Decimal {
+	return decimal(c.UnrealisedPnl)
+}
+
+// BorrowAmountDecimal returns BorrowAmount as a client.Decimal.
+func (c CoinDetails) BorrowAmountDecimal() client.Decimal {
+	return decimal(c.BorrowAmount)
+}
+
+// TotalPositionIMDecimal returns TotalPositionIM as a client.Decimal.
+func (c CoinDetails) TotalPositionIMDecimal() client.Decimal {
+	return decimal(c.TotalPositionIM)
+}
+
+// WalletBalanceDecimal returns WalletBalance as a client.Decimal.
+func (c CoinDetails) WalletBalanceDecimal() client.Decimal {
+	return decimal(c.WalletBalance)
+}
+
+// CumRealisedPnlDecimal returns CumRealisedPnl as a client.Decimal.
+func (c CoinDetails) CumRealisedPnlDecimal() client.Decimal {
+	return decimal(c.CumRealisedPnl)
+}
+
+// LockedDecimal returns Locked as a client.Decimal.
+func (c CoinDetails) LockedDecimal() client.Decimal {
+	return decimal(c.Locked)
+}
+
+// TotalEquityDecimal returns TotalEquity as a client.Decimal.
+func (a AccDetails) TotalEquityDecimal() client.Decimal {
+	return decimal(a.TotalEquity)
+}
+
+// AccountIMRateDecimal returns AccountIMRate as a client.Decimal.
+func (a AccDetails) AccountIMRateDecimal() client.Decimal {
+	return decimal(a.AccountIMRate)
+}
+
+// TotalMarginBalanceDecimal returns TotalMarginBalance as a client.Decimal.
+func (a AccDetails) TotalMarginBalanceDecimal() client.Decimal {
+	return decimal(a.TotalMarginBalance)
+}
+
+// TotalInitialMarginDecimal returns TotalInitialMargin as a client.Decimal.
+func (a AccDetails) TotalInitialMarginDecimal() client.Decimal {
+	return decimal(a.TotalInitialMargin)
+}
+
+// TotalAvailableBalanceDecimal returns TotalAvailableBalance as a client.Decimal.
+func (a AccDetails) TotalAvailableBalanceDecimal() client.Decimal {
+	return decimal(a.TotalAvailableBalance)
+}
+
+// AccountMMRateDecimal returns AccountMMRate as a client.Decimal.
+func (a AccDetails) AccountMMRateDecimal() client.Decimal {
+	return decimal(a.AccountMMRate)
+}
+
+// TotalPerpUPLDecimal returns TotalPerpUPL as a client.Decimal.
+func (a AccDetails) TotalPerpUPLDecimal() client.Decimal {
+	return decimal(a.TotalPerpUPL)
+}
+
+// TotalWalletBalanceDecimal returns TotalWalletBalance as a client.Decimal.
+func (a AccDetails) TotalWalletBalanceDecimal() client.Decimal {
+	return decimal(a.TotalWalletBalance)
+}
+
+// AccountLTVDecimal returns AccountLTV as a client.Decimal.
+func (a AccDetails) AccountLTVDecimal() client.Decimal {
+	return decimal(a.AccountLTV)
+}
+
+// TotalMaintenanceMarginDecimal returns TotalMaintenanceMargin as a client.Decimal.
+func (a AccDetails) TotalMaintenanceMarginDecimal() client.Decimal {
+	return decimal(a.TotalMaintenanceMargin)
+}
+
+// CostExemptionDecimal returns CostExemption as a client.Decimal.
+func (b BorrowItem) CostExemptionDecimal() client.Decimal {
+	return decimal(b.CostExemption)
+}
+
+// InterestBearingBorrowSizeDecimal returns InterestBearingBorrowSize as a client.Decimal.
+func (b BorrowItem) InterestBearingBorrowSizeDecimal() client.Decimal {
+	return decimal(b.InterestBearingBorrowSize)
+}
+
+// HourlyBorrowRateDecimal returns HourlyBorrowRate as a client.Decimal.
+func (b BorrowItem) HourlyBorrowRateDecimal() client.Decimal {
+	return decimal(b.HourlyBorrowRate)
+}
+
+// BorrowCostDecimal returns BorrowCost as a client.Decimal.
+func (b BorrowItem) BorrowCostDecimal() client.Decimal {
+	return decimal(b.BorrowCost)
+}
+
+// TotalDeltaDecimal returns TotalDelta as a client.Decimal.
+func (g CoinGreekItem) TotalDeltaDecimal() client.Decimal {
+	return decimal(g.TotalDelta)
+}
+
+// TotalGammaDecimal returns TotalGamma as a client.Decimal.
+func (g CoinGreekItem) TotalGammaDecimal() client.Decimal {
+	return decimal(g.TotalGamma)
+}
+
+// TotalVegaDecimal returns TotalVega as a client.Decimal.
+func (g CoinGreekItem) TotalVegaDecimal() client.Decimal {
+	return decimal(g.TotalVega)
+}
+
+// TotalThetaDecimal returns TotalTheta as a client.Decimal.
+func (g CoinGreekItem) TotalThetaDecimal() client.Decimal {
+	return decimal(g.TotalTheta)
+}
+
+// BorrowAmountDecimal returns BorrowAmount as a client.Decimal.
+func (c CollateralData) BorrowAmountDecimal() client.Decimal {
+	return decimal(c.BorrowAmount)
+}
+
+// AvailableToBorrowDecimal returns AvailableToBorrow as a client.Decimal.
+func (c CollateralData) AvailableToBorrowDecimal() client.Decimal {
+	return decimal(c.AvailableToBorrow)
+}
+
+// FreeBorrowingAmountDecimal returns FreeBorrowingAmount as a client.Decimal.
+func (c CollateralData) FreeBorrowingAmountDecimal() client.Decimal {
+	return decimal(c.FreeBorrowingAmount)
+}
+
+// MaxBorrowingAmountDecimal returns MaxBorrowingAmount as a client.Decimal.
+func (c CollateralData) MaxBorrowingAmountDecimal() client.Decimal {
+	return decimal(c.MaxBorrowingAmount)
+}
+
+// HourlyBorrowRateDecimal returns HourlyBorrowRate as a client.Decimal.
+func (c CollateralData) HourlyBorrowRateDecimal() client.Decimal {
+	return decimal(c.HourlyBorrowRate)
+}
+
+// BorrowUsageRateDecimal returns BorrowUsageRate as a client.Decimal.
+func (c CollateralData) BorrowUsageRateDecimal() client.Decimal {
+	return decimal(c.BorrowUsageRate)
+}
+
+// CollateralRatioDecimal returns CollateralRatio as a client.Decimal.
+func (c CollateralData) CollateralRatioDecimal() client.Decimal {
+	return decimal(c.CollateralRatio)
+}
+
+// TakerFeeRateDecimal returns TakerFeeRate as a client.Decimal.
+func (f FeeRate) TakerFeeRateDecimal() client.Decimal {
+	return decimal(f.TakerFeeRate)
+}
+
+// MakerFeeRateDecimal returns MakerFeeRate as a client.Decimal.
+func (f FeeRate) MakerFeeRateDecimal() client.Decimal {
+	return decimal(f.MakerFeeRate)
+}
+
+// QtyLimitDecimal returns QtyLimit as a client.Decimal.
+func (m MMPStateItem) QtyLimitDecimal() client.Decimal {
+	return decimal(m.QtyLimit)
+}
+
+// DeltaLimitDecimal returns DeltaLimit as a client.Decimal.
+func (m MMPStateItem) DeltaLimitDecimal() client.Decimal {
+	return decimal(m.DeltaLimit)
+}
+
+// QtyDecimal returns Qty as a client.Decimal.
+func (e LogEntry) QtyDecimal() client.Decimal {
+	return decimal(e.Qty)
+}
+
+// SizeDecimal returns Size as a client.Decimal.
+func (e LogEntry) SizeDecimal() client.Decimal {
+	return decimal(e.Size)
+}
+
+// TradePriceDecimal returns TradePrice as a client.Decimal.
+func (e LogEntry) TradePriceDecimal() client.Decimal {
+	return decimal(e.TradePrice)
+}
+
+// FundingDecimal returns Funding as a client.Decimal.
+func (e LogEntry) FundingDecimal() client.Decimal {
+	return decimal(e.Funding)
+}
+
+// FeeDecimal returns Fee as a client.Decimal.
+func (e LogEntry) FeeDecimal() client.Decimal {
+	return decimal(e.Fee)
+}
+
+// CashFlowDecimal returns CashFlow as a client.Decimal.
+func (e LogEntry) CashFlowDecimal() client.Decimal {
+	return decimal(e.CashFlow)
+}
+
+// ChangeDecimal returns Change as a client.Decimal.
+func (e LogEntry) ChangeDecimal() client.Decimal {
+	return decimal(e.Change)
+}
+
+// CashBalanceDecimal returns CashBalance as a client.Decimal.
+func (e LogEntry) CashBalanceDecimal() client.Decimal {
+	return decimal(e.CashBalance)
+}
+
+// FeeRateDecimal returns FeeRate as a client.Decimal.
+func (e LogEntry) FeeRateDecimal() client.Decimal {
+	return decimal(e.FeeRate)
+}
+
+// BonusChangeDecimal returns BonusChange as a client.Decimal.
+func (e LogEntry) BonusChangeDecimal() client.Decimal {
+	return decimal(e.BonusChange)
+}
@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -17,7 +18,7 @@ func NewFeeRates(client_ *client.Client) *FeeRates {
 	return &FeeRates{client: client_}
 }
 
-func (fr *FeeRates) GetFeeRate(category string, symbol, baseCoin string) (*FeeRatesResponse, error) {
+func (fr *FeeRates) GetFeeRate(ctx context.Context, category string, symbol, baseCoin string) (*FeeRatesResponse, error) {
 	// Construct parameters
 	params := client.Params{
 		"category": category,
@@ -31,7 +32,7 @@ func (fr *FeeRates) GetFeeRate(category string, symbol, baseCoin string) (*FeeRa
 		params["baseCoin"] = baseCoin
 	}
 
-	response, err := fr.client.Get(FeeRatesEndpoint, params)
+	response, err := fr.client.GetContext(ctx, FeeRatesEndpoint, params)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
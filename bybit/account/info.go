@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
@@ -16,9 +17,9 @@ func NewInfo(client *client.Client) *Info {
 }
 
 // Get queries the margin mode configuration of the account.
-func (info *Info) Get() (*AccInfo, error) {
+func (info *Info) Get(ctx context.Context) (*AccInfoResponse, error) {
 	path := "/v5/account/info"
-	resp, err := info.client.Get(path, nil) // Assuming the Get method is as per your client package.
+	resp, err := info.client.GetContext(ctx, path, nil) // Assuming the Get method is as per your client package.
 
 	if err != nil {
 		return nil, err
@@ -28,7 +29,7 @@ func (info *Info) Get() (*AccInfo, error) {
 		return nil, errors.New("failed to get account info: non-200 status code received")
 	}
 
-	var accountInfo AccInfo
+	var accountInfo AccInfoResponse
 	err = resp.Unmarshal(&accountInfo)
 	if err != nil {
 		return nil, err
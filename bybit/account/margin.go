@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -23,12 +24,12 @@ func NewMargin(client *client.Client) *Margin {
 	return &Margin{client: client}
 }
 
-func (m *Margin) SetMarginMode(mode string) (*SetMarginModeResponse, error) {
+func (m *Margin) SetMarginMode(ctx context.Context, mode string) (*SetMarginModeResponse, error) {
 	params := client.Params{
 		"setMarginMode": mode,
 	}
 
-	response, err := m.client.Post(setMarginModePath, params)
+	response, err := m.client.PostContext(ctx, setMarginModePath, params)
 	if err != nil {
 		return nil, err
 	}
@@ -50,8 +51,8 @@ func (m *Margin) SetMarginMode(mode string) (*SetMarginModeResponse, error) {
 }
 
 // SetMMP sets the Market Maker Protection for the client.
-func (m *Margin) SetMMP(params *MMPParams) (*MMPResponse, error) {
-	response, err := m.client.Post(setMMPPath, client.Params{
+func (m *Margin) SetMMP(ctx context.Context, params *MMPParams) (*MMPResponse, error) {
+	response, err := m.client.PostContext(ctx, setMMPPath, client.Params{
 		"baseCoin":     params.BaseCoin,
 		"window":       strconv.Itoa(params.Window),
 		"frozenPeriod": strconv.Itoa(params.FrozenPeriod),
@@ -79,12 +80,12 @@ func (m *Margin) SetMMP(params *MMPParams) (*MMPResponse, error) {
 	return &mmpResponse, nil
 }
 
-func (m *Margin) ResetMMP(baseCoin string) (*MMPResponse, error) {
+func (m *Margin) ResetMMP(ctx context.Context, baseCoin string) (*MMPResponse, error) {
 	params := client.Params{
 		"baseCoin": baseCoin,
 	}
 
-	response, err := m.client.Post(resetMMPPath, params)
+	response, err := m.client.PostContext(ctx, resetMMPPath, params)
 	if err != nil {
 		return nil, err
 	}
@@ -105,12 +106,12 @@ func (m *Margin) ResetMMP(baseCoin string) (*MMPResponse, error) {
 	return &mmpResponse, nil
 }
 
-func (m *Margin) GetMMPState(baseCoin string) (*MMPStateResponse, error) {
+func (m *Margin) GetMMPState(ctx context.Context, baseCoin string) (*MMPStateResponse, error) {
 	params := client.Params{
 		"baseCoin": baseCoin,
 	}
 
-	response, err := m.client.Get(getMMPStatePath, params)
+	response, err := m.client.GetContext(ctx, getMMPStatePath, params)
 	if err != nil {
 		return nil, err
 	}
@@ -53,11 +53,17 @@ type AccInfo struct {
 	IsMasterTrader      bool   `json:"isMasterTrader"`
 	UpdatedTime         string `json:"updatedTime"`
 }
+
+// AccInfoResponse is the response of the /v5/account/info endpoint.
+type AccInfoResponse struct {
+	BaseResponse
+	Result AccInfo `json:"result"`
+}
 type WalletBalance struct {
 	BaseResponse
 	Result struct {
 		List []AccDetails `json:"list"`
-	}
+	} `json:"result"`
 }
 
 type BorrowItem struct {
@@ -74,7 +80,7 @@ type BorrowRes struct {
 	Result struct {
 		NextPageCursor string       `json:"nextPageCursor"`
 		List           []BorrowItem `json:"list"`
-	}
+	} `json:"result"`
 }
 
 type CoinGreekItem struct {
@@ -89,7 +95,7 @@ type CoinGreekRes struct {
 	BaseResponse
 	Result struct {
 		List []CoinGreekItem `json:"list"`
-	}
+	} `json:"result"`
 }
 
 type UnifiedUpdateMsg struct {
@@ -101,7 +107,7 @@ type UpgradeToUnifiedResponse struct {
 	Result struct {
 		UnifiedUpdateStatus string           `json:"unifiedUpdateStatus"`
 		UnifiedUpdateMsg    UnifiedUpdateMsg `json:"unifiedUpdateMsg"`
-	}
+	} `json:"result"`
 }
 
 type CollateralData struct {
@@ -120,7 +126,7 @@ type CollateralData struct {
 
 type CollateralInfoResponse struct {
 	BaseResponse
-	Result CollateralResult
+	Result CollateralResult `json:"result"`
 }
 
 type CollateralResult struct {
@@ -129,6 +135,7 @@ type CollateralResult struct {
 
 type FeeRate struct {
 	Symbol       string `json:"symbol"`
+	BaseCoin     string `json:"baseCoin"`
 	TakerFeeRate string `json:"takerFeeRate"`
 	MakerFeeRate string `json:"makerFeeRate"`
 }
@@ -136,8 +143,9 @@ type FeeRate struct {
 type FeeRatesResponse struct {
 	BaseResponse
 	Result struct {
-		List []FeeRate
-	}
+		Category string    `json:"category"`
+		List     []FeeRate `json:"list"`
+	} `json:"result"`
 }
 
 type SetMarginModeResponse struct {
@@ -179,5 +187,5 @@ type MMPStateResponse struct {
 	BaseResponse
 	Result struct {
 		List []MMPStateItem `json:"result"`
-	}
+	} `json:"result"`
 }
@@ -0,0 +1,33 @@
+package account
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWalletBalanceDecoding(t *testing.T) {
+	body := `{"retCode":0,"retMsg":"OK","result":{"list":[{"totalEquity":"3.31216591","accountIMRate":"","accountType":"UNIFIED",
+		"coin":[{"coin":"BTC","equity":"0.00012","walletBalance":"0.00012","borrowAmount":"","collateralSwitch":true}]}]},"time":1690872862481}`
+
+	var res WalletBalance
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Result.List) != 1 || len(res.Result.List[0].Coin) != 1 {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+	acc := res.Result.List[0]
+	if acc.TotalEquity != "3.31216591" || acc.AccountIMRate != "" || acc.AccountType != "UNIFIED" {
+		t.Errorf("unexpected account %+v", acc)
+	}
+	if acc.TotalEquityDecimal().String() != "3.31216591" || !acc.AccountIMRateDecimal().IsZero() {
+		t.Errorf("unexpected decimals %s %s", acc.TotalEquityDecimal(), acc.AccountIMRateDecimal())
+	}
+	coin := acc.Coin[0]
+	if coin.Equity != "0.00012" || !coin.CollateralSwitch {
+		t.Errorf("unexpected coin %+v", coin)
+	}
+	if sum := coin.EquityDecimal().Add(coin.WalletBalanceDecimal()); sum.String() != "0.00024" || !coin.BorrowAmountDecimal().IsZero() {
+		t.Errorf("unexpected decimals %s %s", sum, coin.BorrowAmountDecimal())
+	}
+}
@@ -1,9 +1,9 @@
 package account
 
 import (
+	"context"
 	"errors"
 	"net/http"
-	"net/url"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
 )
@@ -47,26 +47,26 @@ type LogEntry struct {
 	OrderLinkID     string `json:"orderLinkId"`
 }
 
-// LogResponse represents the response from the /v5/account/transaction-log endpoint
+// LogResponse represents the result of the /v5/account/transaction-log endpoint
 type LogResponse struct {
 	List           []LogEntry `json:"list"`
 	NextPageCursor string     `json:"nextPageCursor"`
 }
 
-// Get sends a GET request to the /v5/account/transaction-log endpoint to retrieve transaction logs.
-func (tl *TransactionLog) Get(params map[string]string) (*LogResponse, error) {
-	endpoint := "/v5/account/transaction-log"
+// TransactionLogResponse represents the response from the /v5/account/transaction-log endpoint
+type TransactionLogResponse struct {
+	BaseResponse
+	Result LogResponse `json:"result"`
+}
 
-	// Add the optional query parameters if provided
-	if len(params) > 0 {
-		queryParams := url.Values{}
-		for key, value := range params {
-			queryParams.Add(key, value)
-		}
-		endpoint = endpoint + "?" + queryParams.Encode()
+// Get sends a GET request to the /v5/account/transaction-log endpoint to retrieve transaction logs.
+func (tl *TransactionLog) Get(ctx context.Context, params map[string]string) (*TransactionLogResponse, error) {
+	query := make(client.Params, len(params))
+	for key, value := range params {
+		query[key] = value
 	}
 
-	resp, err := tl.client.Get(endpoint, nil)
+	resp, err := tl.client.GetContext(ctx, "/v5/account/transaction-log", query)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +75,7 @@ func (tl *TransactionLog) Get(params map[string]string) (*LogResponse, error) {
 		return nil, errors.New("failed to get transaction logs: non-200 status code received")
 	}
 
-	var logResponse LogResponse
+	var logResponse TransactionLogResponse
 	err = resp.Unmarshal(&logResponse)
 	if err != nil {
 		return nil, err
@@ -83,3 +83,22 @@ func (tl *TransactionLog) Get(params map[string]string) (*LogResponse, error) {
 
 	return &logResponse, nil
 }
+
+// Iterate returns an iterator over the log entries matching params, following
+// nextPageCursor across pages. params is copied, so it can be reused.
+func (tl *TransactionLog) Iterate(params map[string]string) *client.Iterator[LogEntry] {
+	query := make(map[string]string, len(params)+1)
+	for key, value := range params {
+		query[key] = value
+	}
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[LogEntry], error) {
+		if cursor != "" {
+			query["cursor"] = cursor
+		}
+		res, err := tl.Get(ctx, query)
+		if err != nil {
+			return client.Page[LogEntry]{}, err
+		}
+		return client.Page[LogEntry]{Items: res.Result.List, NextCursor: res.Result.NextPageCursor}, nil
+	})
+}
@@ -0,0 +1,46 @@
+package account
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestTransactionLogIterate(t *testing.T) {
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/account/transaction-log", func(req bybittest.Request) string {
+		if req.Query.Get("cursor") == "page2" {
+			return `{"retCode":0,"retMsg":"OK","result":{"list":[{"id":"2","symbol":"BTCUSDT","cashFlow":"-1.5"}],"nextPageCursor":""},"time":1}`
+		}
+		return `{"retCode":0,"retMsg":"OK","result":{"list":[{"id":"1","symbol":"BTCUSDT","cashFlow":"2"}],"nextPageCursor":"page2"},"time":1}`
+	})
+
+	params := map[string]string{"accountType": "UNIFIED", "category": "linear"}
+	entries, err := New(mock.Client()).TransactionLog().Iterate(params).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].ID != "1" || entries[1].CashFlow != "-1.5" || !entries[1].CashFlowDecimal().Equal(client.MustParseDecimal("-1.5")) {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+	if _, ok := params["cursor"]; ok {
+		t.Error("expected the caller's params to be left unchanged")
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %+v", requests)
+	}
+	for i, cursor := range []string{"", "page2"} {
+		query := requests[i].Query
+		if query.Get("accountType") != "UNIFIED" || query.Get("category") != "linear" || query.Get("cursor") != cursor {
+			t.Errorf("unexpected query %v of request %d", query, i)
+		}
+		if requests[i].Header.Get("X-BAPI-SIGN") == "" {
+			t.Errorf("expected request %d to be signed", i)
+		}
+	}
+}
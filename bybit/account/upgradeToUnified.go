@@ -1,6 +1,10 @@
 package account
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+import (
+	"context"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
 
 type UpgradeToUnified struct {
 	client *client.Client
@@ -10,9 +14,9 @@ func NewUpgradeToUnifiedRequest(c *client.Client) *UpgradeToUnified {
 	return &UpgradeToUnified{c}
 }
 
-func (r *UpgradeToUnified) Upgrade() (*UpgradeToUnifiedResponse, error) {
+func (r *UpgradeToUnified) Upgrade(ctx context.Context) (*UpgradeToUnifiedResponse, error) {
 	var ret UpgradeToUnifiedResponse
-	res, err := r.client.Post(Endpoints.UpgradeToUnified, client.Params{})
+	res, err := r.client.PostContext(ctx, Endpoints.UpgradeToUnified, client.Params{})
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package account
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -20,7 +21,7 @@ func NewWallet(client_ *client.Client) *Wallet {
 	}
 }
 
-func (w Wallet) GetUnifiedWalletBalance(coins ...string) (*WalletBalance, error) {
+func (w Wallet) GetUnifiedWalletBalance(ctx context.Context, coins ...string) (*WalletBalance, error) {
 	params := client.Params{}
 	params["accountType"] = string(Unified)
 
@@ -30,7 +31,7 @@ func (w Wallet) GetUnifiedWalletBalance(coins ...string) (*WalletBalance, error)
 	}
 
 	// Make the GET request
-	resp, err := w.Get(Endpoints.Wallet, params)
+	resp, err := w.GetContext(ctx, Endpoints.Wallet, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch wallet balance: %w", err)
 	}
@@ -57,11 +58,11 @@ func joinCoins(coins []string) string {
 	return strings.TrimRight(coinStr, ",") // Remove trailing comma
 }
 
-func (w Wallet) GetAllUnifiedWalletBalance() (*WalletBalance, error) {
+func (w Wallet) GetAllUnifiedWalletBalance(ctx context.Context) (*WalletBalance, error) {
 	params := client.Params{}
 	params["accountType"] = string(Unified)
 
-	resp, err := w.Get(Endpoints.Wallet, params)
+	resp, err := w.GetContext(ctx, Endpoints.Wallet, params)
 	if err != nil {
 		return nil, err
 	}
@@ -78,11 +79,11 @@ func (w Wallet) GetAllUnifiedWalletBalance() (*WalletBalance, error) {
 	return &balanceResp, nil
 }
 
-func (w Wallet) GetAllSpotWalletBalance() (*WalletBalance, error) {
+func (w Wallet) GetAllSpotWalletBalance(ctx context.Context) (*WalletBalance, error) {
 	params := client.Params{}
 	params["accountType"] = string(Spot)
 
-	resp, err := w.Get(Endpoints.Wallet, params)
+	resp, err := w.GetContext(ctx, Endpoints.Wallet, params)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +100,7 @@ func (w Wallet) GetAllSpotWalletBalance() (*WalletBalance, error) {
 	return &balanceResp, nil
 }
 
-func (w Wallet) GetSpotWalletBalance(coins ...string) (*WalletBalance, error) {
+func (w Wallet) GetSpotWalletBalance(ctx context.Context, coins ...string) (*WalletBalance, error) {
 	params := client.Params{}
 	params["accountType"] = string(Spot)
 	coinStr := ""
@@ -110,7 +111,7 @@ func (w Wallet) GetSpotWalletBalance(coins ...string) (*WalletBalance, error) {
 		coinStr = coinStr[:len(coinStr)-1]
 		params["coin"] = coinStr
 	}
-	resp, err := w.Get(Endpoints.Wallet, params)
+	resp, err := w.GetContext(ctx, Endpoints.Wallet, params)
 	if err != nil {
 		return nil, err
 	}
@@ -127,11 +128,11 @@ func (w Wallet) GetSpotWalletBalance(coins ...string) (*WalletBalance, error) {
 	return &balanceResp, nil
 }
 
-func (w Wallet) GetAllContractWalletBalance() (*WalletBalance, error) {
+func (w Wallet) GetAllContractWalletBalance(ctx context.Context) (*WalletBalance, error) {
 	params := client.Params{}
 	params["accountType"] = string(Contract)
 
-	resp, err := w.Get(Endpoints.Wallet, params)
+	resp, err := w.GetContext(ctx, Endpoints.Wallet, params)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +149,7 @@ func (w Wallet) GetAllContractWalletBalance() (*WalletBalance, error) {
 	return &balanceResp, nil
 }
 
-func (w Wallet) GetContractWalletBalance(coins ...string) (*WalletBalance, error) {
+func (w Wallet) GetContractWalletBalance(ctx context.Context, coins ...string) (*WalletBalance, error) {
 	params := client.Params{}
 	params["accountType"] = string(Contract)
 	coinStr := ""
@@ -159,7 +160,7 @@ func (w Wallet) GetContractWalletBalance(coins ...string) (*WalletBalance, error
 		coinStr = coinStr[:len(coinStr)-1]
 		params["coin"] = coinStr
 	}
-	resp, err := w.Get(Endpoints.Wallet, params)
+	resp, err := w.GetContext(ctx, Endpoints.Wallet, params)
 	if err != nil {
 		return nil, err
 	}
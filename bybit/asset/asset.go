@@ -1,6 +1,7 @@
 package asset
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,35 +14,35 @@ var OK = "OK"
 
 type Asset interface {
 	// GetCoinExchangeRecords queries the coin exchange records.
-	GetCoinExchangeRecords(req *GetCoinExchangeRecordsRequest) (*GetCoinExchangeRecordsResponse, error)
+	GetCoinExchangeRecords(ctx context.Context, req *GetCoinExchangeRecordsRequest) (*GetCoinExchangeRecordsResponse, error)
 	// GetDeliveryRecords queries the delivery records of USDC futures and Options.
-	GetDeliveryRecords(req *GetDeliveryRecordRequest) (*GetDeliveryRecordResponse, error)
+	GetDeliveryRecords(ctx context.Context, req *GetDeliveryRecordRequest) (*GetDeliveryRecordResponse, error)
 	// GetSessionSettlementRecords queries the session settlement records of USDC perpetual and futures.
-	GetSessionSettlementRecords(req *GetSessionSettlementRecordRequest) (*GetSessionSettlementRecordResponse, error)
+	GetSessionSettlementRecords(ctx context.Context, req *GetSessionSettlementRecordRequest) (*GetSessionSettlementRecordResponse, error)
 	// GetAssetInfo queries the asset information for SPOT accounts.
-	GetAssetInfo(req *GetAssetInfoRequest) (*GetAssetInfoResponse, error)
+	GetAssetInfo(ctx context.Context, req *GetAssetInfoRequest) (*GetAssetInfoResponse, error)
 	// GetAllCoinsBalance retrieves all coin balances for specified account types.
-	GetAllCoinsBalance(req *GetAllCoinsBalanceRequest) (*GetAllCoinsBalanceResponse, error)
+	GetAllCoinsBalance(ctx context.Context, req *GetAllCoinsBalanceRequest) (*GetAllCoinsBalanceResponse, error)
 	// GetSingleCoinBalance queries the balance of a specific coin in a specific account type.
-	GetSingleCoinBalance(req *GetSingleCoinBalanceRequest) (*GetSingleCoinBalanceResponse, error)
+	GetSingleCoinBalance(ctx context.Context, req *GetSingleCoinBalanceRequest) (*GetSingleCoinBalanceResponse, error)
 	// GetTransferableCoin queries the list of transferable coins between account types.
-	GetTransferableCoin(req *GetTransferableCoinRequest) (*GetTransferableCoinResponse, error)
-	CreateInternalTransfer(req *CreateInternalTransferRequest) (*CreateInternalTransferResponse, error)
-	GetInternalTransferRecords(req *GetInternalTransferRecordsRequest) (*GetInternalTransferRecordsResponse, error)
-	GetSubUIDs() (*GetSubUIDsResponse, error)
-	CreateUniversalTransfer(req *CreateUniversalTransferRequest) (*CreateUniversalTransferResponse, error)
-	GetUniversalTransferRecords(req *GetUniversalTransferRecordsRequest) (*GetUniversalTransferRecordsResponse, error)
-	GetAllowedDepositCoinInfo(req *GetAllowedDepositCoinInfoRequest) (*GetAllowedDepositCoinInfoResponse, error)
-	GetDepositRecords(req *GetDepositRecordsRequest) (*GetDepositRecordsResponse, error)
-	GetSubDepositRecords(req *GetSubDepositRecordsRequest) (*GetSubDepositRecordsResponse, error)
-	GetInternalDepositRecords(req *GetInternalDepositRecordsRequest) (*GetInternalDepositRecordsResponse, error)
-	GetMasterDepositAddress(req *GetMasterDepositAddressRequest) (*GetMasterDepositAddressResponse, error)
-	GetSubDepositAddress(req *GetSubDepositAddressRequest) (*GetSubDepositAddressResponse, error)
-	GetCoinInfo(coin *string) (*GetCoinInfoResponse, error)
-	GetWithdrawalRecords(req *GetWithdrawalRecordsRequest) (*GetWithdrawalRecordsResponse, error)
-	GetWithdrawableAmount(req *GetWithdrawableAmountRequest) (*GetWithdrawableAmountResponse, error)
-	Withdraw(req *WithdrawRequest) (*WithdrawResponse, error)
-	CancelWithdrawal(req *CancelWithdrawalRequest) (*CancelWithdrawalResponse, error)
+	GetTransferableCoin(ctx context.Context, req *GetTransferableCoinRequest) (*GetTransferableCoinResponse, error)
+	CreateInternalTransfer(ctx context.Context, req *CreateInternalTransferRequest) (*CreateInternalTransferResponse, error)
+	GetInternalTransferRecords(ctx context.Context, req *GetInternalTransferRecordsRequest) (*GetInternalTransferRecordsResponse, error)
+	GetSubUIDs(ctx context.Context) (*GetSubUIDsResponse, error)
+	CreateUniversalTransfer(ctx context.Context, req *CreateUniversalTransferRequest) (*CreateUniversalTransferResponse, error)
+	GetUniversalTransferRecords(ctx context.Context, req *GetUniversalTransferRecordsRequest) (*GetUniversalTransferRecordsResponse, error)
+	GetAllowedDepositCoinInfo(ctx context.Context, req *GetAllowedDepositCoinInfoRequest) (*GetAllowedDepositCoinInfoResponse, error)
+	GetDepositRecords(ctx context.Context, req *GetDepositRecordsRequest) (*GetDepositRecordsResponse, error)
+	GetSubDepositRecords(ctx context.Context, req *GetSubDepositRecordsRequest) (*GetSubDepositRecordsResponse, error)
+	GetInternalDepositRecords(ctx context.Context, req *GetInternalDepositRecordsRequest) (*GetInternalDepositRecordsResponse, error)
+	GetMasterDepositAddress(ctx context.Context, req *GetMasterDepositAddressRequest) (*GetMasterDepositAddressResponse, error)
+	GetSubDepositAddress(ctx context.Context, req *GetSubDepositAddressRequest) (*GetSubDepositAddressResponse, error)
+	GetCoinInfo(ctx context.Context, coin *string) (*GetCoinInfoResponse, error)
+	GetWithdrawalRecords(ctx context.Context, req *GetWithdrawalRecordsRequest) (*GetWithdrawalRecordsResponse, error)
+	GetWithdrawableAmount(ctx context.Context, req *GetWithdrawableAmountRequest) (*GetWithdrawableAmountResponse, error)
+	Withdraw(ctx context.Context, req *WithdrawRequest) (*WithdrawResponse, error)
+	CancelWithdrawal(ctx context.Context, req *CancelWithdrawalRequest) (*CancelWithdrawalResponse, error)
 }
 
 type impl struct {
@@ -53,7 +54,7 @@ func New(client *client.Client) Asset {
 		client: client,
 	}
 }
-func (i *impl) GetCoinExchangeRecords(req *GetCoinExchangeRecordsRequest) (*GetCoinExchangeRecordsResponse, error) {
+func (i *impl) GetCoinExchangeRecords(ctx context.Context, req *GetCoinExchangeRecordsRequest) (*GetCoinExchangeRecordsResponse, error) {
 	var allRecords []CoinExchangeRecord
 	var finalResponse GetCoinExchangeRecordsResponse
 
@@ -74,7 +75,7 @@ func (i *impl) GetCoinExchangeRecords(req *GetCoinExchangeRecordsRequest) (*GetC
 		}
 
 		// Perform the GET request
-		response, err := i.client.Get("/v5/asset/exchange/order-record", queryParams)
+		response, err := i.client.GetContext(ctx, "/v5/asset/exchange/order-record", queryParams)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching coin exchange records: %w", err)
 		}
@@ -104,7 +105,7 @@ func (i *impl) GetCoinExchangeRecords(req *GetCoinExchangeRecordsRequest) (*GetC
 	finalResponse.Result.NextPageCursor = ""
 	return &finalResponse, nil
 }
-func (i *impl) GetDeliveryRecords(req *GetDeliveryRecordRequest) (*GetDeliveryRecordResponse, error) {
+func (i *impl) GetDeliveryRecords(ctx context.Context, req *GetDeliveryRecordRequest) (*GetDeliveryRecordResponse, error) {
 	var allRecords []DeliveryRecordEntry
 	var finalResponse GetDeliveryRecordResponse
 
@@ -132,7 +133,7 @@ func (i *impl) GetDeliveryRecords(req *GetDeliveryRecordRequest) (*GetDeliveryRe
 		}
 
 		// Perform the GET request
-		response, err := i.client.Get("/v5/asset/delivery-record", queryParams)
+		response, err := i.client.GetContext(ctx, "/v5/asset/delivery-record", queryParams)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching delivery records: %w", err)
 		}
@@ -163,7 +164,7 @@ func (i *impl) GetDeliveryRecords(req *GetDeliveryRecordRequest) (*GetDeliveryRe
 	finalResponse.Result.NextPageCursor = ""
 	return &finalResponse, nil
 }
-func (i *impl) GetSessionSettlementRecords(req *GetSessionSettlementRecordRequest) (*GetSessionSettlementRecordResponse, error) {
+func (i *impl) GetSessionSettlementRecords(ctx context.Context, req *GetSessionSettlementRecordRequest) (*GetSessionSettlementRecordResponse, error) {
 	queryParams := make(client.Params)
 	queryParams["category"] = req.Category
 	if req.Symbol != nil {
@@ -187,7 +188,7 @@ func (i *impl) GetSessionSettlementRecords(req *GetSessionSettlementRecordReques
 	var finalResponse GetSessionSettlementRecordResponse
 
 	for {
-		response, err := i.client.Get("/v5/asset/settlement-record", queryParams)
+		response, err := i.client.GetContext(ctx, "/v5/asset/settlement-record", queryParams)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching session settlement records: %w", err)
 		}
@@ -220,7 +221,7 @@ func (i *impl) GetSessionSettlementRecords(req *GetSessionSettlementRecordReques
 	return &finalResponse, nil
 }
 
-func (i *impl) GetAssetInfo(req *GetAssetInfoRequest) (*GetAssetInfoResponse, error) {
+func (i *impl) GetAssetInfo(ctx context.Context, req *GetAssetInfoRequest) (*GetAssetInfoResponse, error) {
 	queryParams := make(client.Params)
 	queryParams["accountType"] = req.AccountType
 	if req.Coin != nil {
@@ -228,7 +229,7 @@ func (i *impl) GetAssetInfo(req *GetAssetInfoRequest) (*GetAssetInfoResponse, er
 	}
 
 	// Perform the GET request
-	response, err := i.client.Get("/v5/asset/transfer/query-asset-info", queryParams)
+	response, err := i.client.GetContext(ctx, "/v5/asset/transfer/query-asset-info", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching asset information: %w", err)
 	}
@@ -244,7 +245,7 @@ func (i *impl) GetAssetInfo(req *GetAssetInfoRequest) (*GetAssetInfoResponse, er
 	return &assetInfoResponse, nil
 }
 
-func (i *impl) GetSingleCoinBalance(req *GetSingleCoinBalanceRequest) (*GetSingleCoinBalanceResponse, error) {
+func (i *impl) GetSingleCoinBalance(ctx context.Context, req *GetSingleCoinBalanceRequest) (*GetSingleCoinBalanceResponse, error) {
 	queryParams := make(client.Params)
 	if req.MemberID != nil {
 		queryParams["memberId"] = *req.MemberID
@@ -268,7 +269,7 @@ func (i *impl) GetSingleCoinBalance(req *GetSingleCoinBalanceRequest) (*GetSingl
 	}
 
 	// Perform the GET request
-	response, err := i.client.Get("/v5/asset/transfer/query-account-coin-balance", queryParams)
+	response, err := i.client.GetContext(ctx, "/v5/asset/transfer/query-account-coin-balance", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching single coin balance: %w", err)
 	}
@@ -283,14 +284,14 @@ func (i *impl) GetSingleCoinBalance(req *GetSingleCoinBalanceRequest) (*GetSingl
 
 	return &coinBalanceResponse, nil
 }
-func (i *impl) GetTransferableCoin(req *GetTransferableCoinRequest) (*GetTransferableCoinResponse, error) {
+func (i *impl) GetTransferableCoin(ctx context.Context, req *GetTransferableCoinRequest) (*GetTransferableCoinResponse, error) {
 	// Prepare query parameters
 	queryParams := make(client.Params)
 	queryParams["fromAccountType"] = req.FromAccountType
 	queryParams["toAccountType"] = req.ToAccountType
 
 	// Perform the GET request
-	response, err := i.client.Get("/v5/asset/transfer/query-transfer-coin-list", queryParams)
+	response, err := i.client.GetContext(ctx, "/v5/asset/transfer/query-transfer-coin-list", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching transferable coin list: %w", err)
 	}
@@ -306,7 +307,7 @@ func (i *impl) GetTransferableCoin(req *GetTransferableCoinRequest) (*GetTransfe
 	return &transferableCoinResponse, nil
 }
 
-func (i *impl) GetAllCoinsBalance(req *GetAllCoinsBalanceRequest) (*GetAllCoinsBalanceResponse, error) {
+func (i *impl) GetAllCoinsBalance(ctx context.Context, req *GetAllCoinsBalanceRequest) (*GetAllCoinsBalanceResponse, error) {
 	queryParams := make(client.Params)
 	if req.MemberID != nil {
 		queryParams["memberId"] = *req.MemberID
@@ -320,7 +321,7 @@ func (i *impl) GetAllCoinsBalance(req *GetAllCoinsBalanceRequest) (*GetAllCoinsB
 	}
 
 	// Perform the GET request
-	response, err := i.client.Get("/v5/asset/transfer/query-account-coins-balance", queryParams)
+	response, err := i.client.GetContext(ctx, "/v5/asset/transfer/query-account-coins-balance", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching all coins balance: %w", err)
 	}
@@ -332,7 +333,7 @@ func (i *impl) GetAllCoinsBalance(req *GetAllCoinsBalanceRequest) (*GetAllCoinsB
 
 	return &coinsBalanceResponse, nil
 }
-func (i *impl) CreateInternalTransfer(req *CreateInternalTransferRequest) (*CreateInternalTransferResponse, error) {
+func (i *impl) CreateInternalTransfer(ctx context.Context, req *CreateInternalTransferRequest) (*CreateInternalTransferResponse, error) {
 	// Initialize Params and populate with request data
 	params := client.Params{
 		"transferId":      req.TransferID,
@@ -348,7 +349,7 @@ func (i *impl) CreateInternalTransfer(req *CreateInternalTransferRequest) (*Crea
 	}
 
 	// Perform the POST request
-	response, err := i.client.Post("/v5/asset/transfer/inter-transfer", params)
+	response, err := i.client.PostContext(ctx, "/v5/asset/transfer/inter-transfer", params)
 	if err != nil {
 		return nil, fmt.Errorf("error creating internal transfer: %w", err)
 	}
@@ -366,7 +367,7 @@ func (i *impl) CreateInternalTransfer(req *CreateInternalTransferRequest) (*Crea
 	return &transferResponse, nil
 }
 
-func (i *impl) GetUniversalTransferRecords(req *GetUniversalTransferRecordsRequest) (*GetUniversalTransferRecordsResponse, error) {
+func (i *impl) GetUniversalTransferRecords(ctx context.Context, req *GetUniversalTransferRecordsRequest) (*GetUniversalTransferRecordsResponse, error) {
 	queryParams := client.Params{}
 	if req.TransferID != nil {
 		queryParams["transferId"] = *req.TransferID
@@ -391,7 +392,7 @@ func (i *impl) GetUniversalTransferRecords(req *GetUniversalTransferRecordsReque
 	}
 
 	// Perform the GET request
-	response, err := i.client.Get("/v5/asset/transfer/query-universal-transfer-list", queryParams)
+	response, err := i.client.GetContext(ctx, "/v5/asset/transfer/query-universal-transfer-list", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching universal transfer records: %w", err)
 	}
@@ -406,7 +407,7 @@ func (i *impl) GetUniversalTransferRecords(req *GetUniversalTransferRecordsReque
 
 	return &transferRecordsResponse, nil
 }
-func (i *impl) GetInternalTransferRecords(req *GetInternalTransferRecordsRequest) (*GetInternalTransferRecordsResponse, error) {
+func (i *impl) GetInternalTransferRecords(ctx context.Context, req *GetInternalTransferRecordsRequest) (*GetInternalTransferRecordsResponse, error) {
 	queryParams := make(client.Params)
 	if req.TransferID != nil {
 		queryParams["transferId"] = *req.TransferID
@@ -431,7 +432,7 @@ func (i *impl) GetInternalTransferRecords(req *GetInternalTransferRecordsRequest
 	}
 
 	// Perform the GET request
-	response, err := i.client.Get("/v5/asset/transfer/query-inter-transfer-list", queryParams)
+	response, err := i.client.GetContext(ctx, "/v5/asset/transfer/query-inter-transfer-list", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching internal transfer records: %w", err)
 	}
@@ -447,9 +448,9 @@ func (i *impl) GetInternalTransferRecords(req *GetInternalTransferRecordsRequest
 
 	return &transferRecordsResponse, nil
 }
-func (i *impl) GetSubUIDs() (*GetSubUIDsResponse, error) {
+func (i *impl) GetSubUIDs(ctx context.Context) (*GetSubUIDsResponse, error) {
 	// Perform the GET request
-	response, err := i.client.Get("/v5/asset/transfer/query-sub-member-list", nil)
+	response, err := i.client.GetContext(ctx, "/v5/asset/transfer/query-sub-member-list", nil)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching sub UIDs: %w", err)
 	}
@@ -465,7 +466,7 @@ func (i *impl) GetSubUIDs() (*GetSubUIDsResponse, error) {
 
 	return &subUIDsResponse, nil
 }
-func (i *impl) CreateUniversalTransfer(req *CreateUniversalTransferRequest) (*CreateUniversalTransferResponse, error) {
+func (i *impl) CreateUniversalTransfer(ctx context.Context, req *CreateUniversalTransferRequest) (*CreateUniversalTransferResponse, error) {
 	queryParams := make(client.Params)
 	queryParams["transferId"] = req.TransferID
 	queryParams["coin"] = req.Coin
@@ -481,7 +482,7 @@ func (i *impl) CreateUniversalTransfer(req *CreateUniversalTransferRequest) (*Cr
 	}
 
 	// Perform the POST request
-	response, err := i.client.Post("/v5/asset/transfer/universal-transfer", queryParams)
+	response, err := i.client.PostContext(ctx, "/v5/asset/transfer/universal-transfer", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error creating universal transfer: %w", err)
 	}
@@ -497,7 +498,7 @@ func (i *impl) CreateUniversalTransfer(req *CreateUniversalTransferRequest) (*Cr
 
 	return &transferResponse, nil
 }
-func (i *impl) GetAllowedDepositCoinInfo(req *GetAllowedDepositCoinInfoRequest) (*GetAllowedDepositCoinInfoResponse, error) {
+func (i *impl) GetAllowedDepositCoinInfo(ctx context.Context, req *GetAllowedDepositCoinInfoRequest) (*GetAllowedDepositCoinInfoResponse, error) {
 	queryParams := make(client.Params)
 	if req.Coin != nil {
 		queryParams["coin"] = *req.Coin
@@ -513,7 +514,7 @@ func (i *impl) GetAllowedDepositCoinInfo(req *GetAllowedDepositCoinInfoRequest)
 	}
 
 	// Perform the GET request
-	response, err := i.client.Get("/v5/asset/deposit/query-allowed-list", queryParams)
+	response, err := i.client.GetContext(ctx, "/v5/asset/deposit/query-allowed-list", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching allowed deposit coin information: %w", err)
 	}
@@ -530,13 +531,13 @@ func (i *impl) GetAllowedDepositCoinInfo(req *GetAllowedDepositCoinInfoRequest)
 
 	return &allowedDepositCoinInfoResponse, nil
 }
-func (i *impl) SetDepositAccount(req *SetDepositAccountRequest) (*SetDepositAccountResponse, error) {
+func (i *impl) SetDepositAccount(ctx context.Context, req *SetDepositAccountRequest) (*SetDepositAccountResponse, error) {
 	// Initialize Params and populate with request data
 	params := client.Params{
 		"accountType": req.AccountType, // Direct assignment since AccountType is required and assumed to be always provided
 	}
 
-	responseBytes, err := i.client.Post("/v5/asset/deposit/deposit-to-account", params)
+	responseBytes, err := i.client.PostContext(ctx, "/v5/asset/deposit/deposit-to-account", params)
 	if err != nil {
 		return nil, fmt.Errorf("error during POST request for setting deposit account: %w", err)
 	}
@@ -553,7 +554,7 @@ func (i *impl) SetDepositAccount(req *SetDepositAccountRequest) (*SetDepositAcco
 
 	return &response, nil
 }
-func (i *impl) GetDepositRecords(req *GetDepositRecordsRequest) (*GetDepositRecordsResponse, error) {
+func (i *impl) GetDepositRecords(ctx context.Context, req *GetDepositRecordsRequest) (*GetDepositRecordsResponse, error) {
 	allDepositRecords := []DepositRecordEntry{}
 	var finalResponse GetDepositRecordsResponse
 
@@ -574,7 +575,7 @@ func (i *impl) GetDepositRecords(req *GetDepositRecordsRequest) (*GetDepositReco
 
 	for {
 		// Perform the GET request
-		response, err := i.client.Get("/v5/asset/deposit/query-record", queryParams)
+		response, err := i.client.GetContext(ctx, "/v5/asset/deposit/query-record", queryParams)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching deposit records: %w", err)
 		}
@@ -611,7 +612,7 @@ func (i *impl) GetDepositRecords(req *GetDepositRecordsRequest) (*GetDepositReco
 
 	return &finalResponse, nil
 }
-func (i *impl) GetSubDepositRecords(req *GetSubDepositRecordsRequest) (*GetSubDepositRecordsResponse, error) {
+func (i *impl) GetSubDepositRecords(ctx context.Context, req *GetSubDepositRecordsRequest) (*GetSubDepositRecordsResponse, error) {
 	var allRows []DepositRecordEntry
 	var finalResponse GetSubDepositRecordsResponse
 
@@ -632,7 +633,7 @@ func (i *impl) GetSubDepositRecords(req *GetSubDepositRecordsRequest) (*GetSubDe
 	queryParams["cursor"] = req.Cursor // Start with nil or provided cursor
 
 	for {
-		response, err := i.client.Get("/v5/asset/deposit/query-sub-member-record", queryParams)
+		response, err := i.client.GetContext(ctx, "/v5/asset/deposit/query-sub-member-record", queryParams)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching sub deposit records: %w", err)
 		}
@@ -660,7 +661,7 @@ func (i *impl) GetSubDepositRecords(req *GetSubDepositRecordsRequest) (*GetSubDe
 	finalResponse.RetCode = 0
 	return &finalResponse, nil
 }
-func (i *impl) GetInternalDepositRecords(req *GetInternalDepositRecordsRequest) (*GetInternalDepositRecordsResponse, error) {
+func (i *impl) GetInternalDepositRecords(ctx context.Context, req *GetInternalDepositRecordsRequest) (*GetInternalDepositRecordsResponse, error) {
 	var allRows []InternalDepositRecordEntry
 	var finalResponse GetInternalDepositRecordsResponse
 
@@ -686,7 +687,7 @@ func (i *impl) GetInternalDepositRecords(req *GetInternalDepositRecordsRequest)
 	var currentPageResponse GetInternalDepositRecordsResponse
 	// Loop through pages to collect all records
 	for {
-		response, err := i.client.Get("/v5/asset/deposit/query-internal-record", queryParams)
+		response, err := i.client.GetContext(ctx, "/v5/asset/deposit/query-internal-record", queryParams)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching internal deposit records: %w", err)
 		}
@@ -719,7 +720,7 @@ func (i *impl) GetInternalDepositRecords(req *GetInternalDepositRecordsRequest)
 	return &finalResponse, nil
 }
 
-func (i *impl) GetMasterDepositAddress(req *GetMasterDepositAddressRequest) (*GetMasterDepositAddressResponse, error) {
+func (i *impl) GetMasterDepositAddress(ctx context.Context, req *GetMasterDepositAddressRequest) (*GetMasterDepositAddressResponse, error) {
 	queryParams := make(client.Params)
 	queryParams["coin"] = req.Coin
 	if req.ChainType != nil {
@@ -727,7 +728,7 @@ func (i *impl) GetMasterDepositAddress(req *GetMasterDepositAddressRequest) (*Ge
 	}
 
 	// Perform the GET request
-	responseBytes, err := i.client.Get("/v5/asset/deposit/query-address", queryParams)
+	responseBytes, err := i.client.GetContext(ctx, "/v5/asset/deposit/query-address", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error querying master deposit address: %w", err)
 	}
@@ -745,14 +746,14 @@ func (i *impl) GetMasterDepositAddress(req *GetMasterDepositAddressRequest) (*Ge
 
 	return &response, nil
 }
-func (i *impl) GetSubDepositAddress(req *GetSubDepositAddressRequest) (*GetSubDepositAddressResponse, error) {
+func (i *impl) GetSubDepositAddress(ctx context.Context, req *GetSubDepositAddressRequest) (*GetSubDepositAddressResponse, error) {
 	queryParams := make(client.Params)
 	queryParams["coin"] = req.Coin
 	queryParams["chainType"] = req.ChainType
 	queryParams["subMemberId"] = req.SubMemberID
 
 	// Perform the GET request
-	responseBytes, err := i.client.Get("/v5/asset/deposit/query-sub-member-address", queryParams)
+	responseBytes, err := i.client.GetContext(ctx, "/v5/asset/deposit/query-sub-member-address", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error querying sub deposit address: %w", err)
 	}
@@ -770,14 +771,14 @@ func (i *impl) GetSubDepositAddress(req *GetSubDepositAddressRequest) (*GetSubDe
 
 	return &response, nil
 }
-func (i *impl) GetCoinInfo(coin *string) (*GetCoinInfoResponse, error) {
+func (i *impl) GetCoinInfo(ctx context.Context, coin *string) (*GetCoinInfoResponse, error) {
 	queryParams := make(client.Params)
 	if coin != nil {
 		queryParams["coin"] = *coin
 	}
 
 	// Perform the GET request
-	responseBytes, err := i.client.Get("/v5/asset/coin/query-info", queryParams)
+	responseBytes, err := i.client.GetContext(ctx, "/v5/asset/coin/query-info", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error querying coin information: %w", err)
 	}
@@ -796,7 +797,7 @@ func (i *impl) GetCoinInfo(coin *string) (*GetCoinInfoResponse, error) {
 	return &response, nil
 }
 
-func (i *impl) GetWithdrawalRecords(req *GetWithdrawalRecordsRequest) (*GetWithdrawalRecordsResponse, error) {
+func (i *impl) GetWithdrawalRecords(ctx context.Context, req *GetWithdrawalRecordsRequest) (*GetWithdrawalRecordsResponse, error) {
 	allRecords := []WithdrawalRecord{}
 	var finalResponse GetWithdrawalRecordsResponse
 
@@ -826,7 +827,7 @@ func (i *impl) GetWithdrawalRecords(req *GetWithdrawalRecordsRequest) (*GetWithd
 	queryParams["cursor"] = req.Cursor // Initialize cursor for pagination
 	var currentPageResponse GetWithdrawalRecordsResponse
 	for {
-		responseBytes, err := i.client.Get("/v5/asset/withdraw/query-record", queryParams)
+		responseBytes, err := i.client.GetContext(ctx, "/v5/asset/withdraw/query-record", queryParams)
 		if err != nil {
 			return nil, fmt.Errorf("error querying withdrawal records: %w", err)
 		}
@@ -858,12 +859,12 @@ func (i *impl) GetWithdrawalRecords(req *GetWithdrawalRecordsRequest) (*GetWithd
 
 	return &finalResponse, nil
 }
-func (i *impl) GetWithdrawableAmount(req *GetWithdrawableAmountRequest) (*GetWithdrawableAmountResponse, error) {
+func (i *impl) GetWithdrawableAmount(ctx context.Context, req *GetWithdrawableAmountRequest) (*GetWithdrawableAmountResponse, error) {
 	queryParams := client.Params{
 		"coin": req.Coin,
 	}
 	// Perform the GET request
-	responseBytes, err := i.client.Get("/v5/asset/withdraw/withdrawable-amount", queryParams)
+	responseBytes, err := i.client.GetContext(ctx, "/v5/asset/withdraw/withdrawable-amount", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error querying withdrawable amount: %w", err)
 	}
@@ -879,7 +880,7 @@ func (i *impl) GetWithdrawableAmount(req *GetWithdrawableAmountRequest) (*GetWit
 	}
 	return &response, nil
 }
-func (i *impl) Withdraw(req *WithdrawRequest) (*WithdrawResponse, error) {
+func (i *impl) Withdraw(ctx context.Context, req *WithdrawRequest) (*WithdrawResponse, error) {
 	// Construct the queryParams from the WithdrawRequest struct
 	queryParams := make(client.Params)
 	queryParams["coin"] = req.Coin
@@ -906,7 +907,7 @@ func (i *impl) Withdraw(req *WithdrawRequest) (*WithdrawResponse, error) {
 	}
 
 	// Perform the POST request
-	responseBytes, err := i.client.Post("/v5/asset/withdraw/create", queryParams)
+	responseBytes, err := i.client.PostContext(ctx, "/v5/asset/withdraw/create", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error creating withdraw request: %w", err)
 	}
@@ -924,13 +925,13 @@ func (i *impl) Withdraw(req *WithdrawRequest) (*WithdrawResponse, error) {
 	return &response, nil
 }
 
-func (i *impl) CancelWithdrawal(req *CancelWithdrawalRequest) (*CancelWithdrawalResponse, error) {
+func (i *impl) CancelWithdrawal(ctx context.Context, req *CancelWithdrawalRequest) (*CancelWithdrawalResponse, error) {
 	// Construct the queryParams from the CancelWithdrawalRequest struct
 	queryParams := make(client.Params)
 	queryParams["id"] = req.ID
 
 	// Perform the POST request
-	responseBytes, err := i.client.Post("/v5/asset/withdraw/cancel", queryParams)
+	responseBytes, err := i.client.PostContext(ctx, "/v5/asset/withdraw/cancel", queryParams)
 	if err != nil {
 		return nil, fmt.Errorf("error cancelling withdrawal: %w", err)
 	}
@@ -1,12 +1,17 @@
 package bybit
 
 import (
+	"context"
+	"time"
+
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/account"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/asset"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/position"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/spread"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/trade"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/user"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws"
 	wsCli "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 )
@@ -18,6 +23,9 @@ type Bybit interface {
 	Trade() trade.Trade
 	Position() position.Position
 	Asset() asset.Asset
+	User() user.User
+	Spread() spread.Spread
+	EnableDCP(ctx context.Context, timeWindow time.Duration) error
 }
 
 type bybitImpl struct {
@@ -31,6 +39,8 @@ type bybitImpl struct {
 	trade      trade.Trade
 	position   position.Position
 	asset      asset.Asset
+	user       user.User
+	spread     spread.Spread
 	webSocket  ws.WebSocket
 }
 
@@ -51,6 +61,8 @@ func New(key, secretKey string, isTestNet bool, category string) Bybit {
 		trade:     trade.New(c),
 		position:  position.New(c),
 		asset:     asset.New(c),
+		user:      user.New(c),
+		spread:    spread.New(c),
 		client:    c,
 		isTestNet: isTestNet,
 		apiKey:    key,
@@ -107,3 +119,33 @@ func (b *bybitImpl) Position() position.Position {
 func (b *bybitImpl) Asset() asset.Asset {
 	return b.asset
 }
+
+// User returns the User interface for Bybit operations.
+//
+// No parameters.
+// Returns a user.User interface.
+func (b *bybitImpl) User() user.User {
+	return b.user
+}
+
+// Spread returns the Spread interface for Bybit's spread trading market data.
+//
+// No parameters.
+// Returns a spread.Spread interface.
+func (b *bybitImpl) Spread() spread.Spread {
+	return b.spread
+}
+
+// EnableDCP enables disconnect cancel protection: if the private WebSocket connection stays
+// down for longer than timeWindow, Bybit cancels the account's open orders.
+//
+// ctx bounds the REST call that sets the window; timeWindow must be between 10 and 300
+// seconds.
+// Returns an error if the window cannot be set or the DCP topic cannot be subscribed.
+func (b *bybitImpl) EnableDCP(ctx context.Context, timeWindow time.Duration) error {
+	private, err := b.webSocket.Private()
+	if err != nil {
+		return err
+	}
+	return private.Dcp("").Enable(ctx, b.trade, timeWindow, nil)
+}
@@ -0,0 +1,76 @@
+package bybittest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/trade"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMock_Fixtures(t *testing.T) {
+	mock := bybittest.NewMock()
+	ctx := context.Background()
+
+	res, err := market.New(mock.Client()).ServerTime(ctx, &client.Params{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1688639403", res.Result.TimeSecond)
+	}
+
+	order, err := trade.New(mock.Client()).PlaceOrder(ctx, &trade.PlaceOrderRequest{
+		Category: "spot", Symbol: "BTCUSDT", Side: "Buy", OrderType: "Limit", Qty: "0.1",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1321003749386327552", order.Result.OrderID)
+	}
+
+	requests := mock.Requests()
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, http.MethodPost, requests[1].Method)
+		assert.Equal(t, "/v5/order/create", requests[1].Path)
+		assert.Contains(t, string(requests[1].Body), `"symbol":"BTCUSDT"`)
+		assert.Equal(t, "test-key", requests[1].Header.Get("X-BAPI-API-KEY"))
+	}
+}
+
+func TestMock_HandleAndServer(t *testing.T) {
+	mock := bybittest.NewMock()
+	mock.HandleError(http.MethodPost, "/v5/order/create", client.RetCodeRateLimited, "Too many visits!")
+	srv := httptest.NewServer(mock)
+	defer srv.Close()
+	cli := client.NewClient("key", "secret", false, client.WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	_, err := trade.New(cli).PlaceOrder(ctx, &trade.PlaceOrderRequest{Category: "linear", Symbol: "BTCUSDT"})
+	var apiErr *client.APIError
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.True(t, apiErr.IsRateLimited())
+	}
+
+	_, err = cli.GetContext(ctx, "/v5/unknown", client.Params{})
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	}
+}
+
+func TestMock_HandleFunc(t *testing.T) {
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/market/instruments-info", func(req bybittest.Request) string {
+		return fmt.Sprintf(`{"retCode":0,"retMsg":"OK","result":{"category":%q,"list":[{"symbol":"BTCUSDT"}],"nextPageCursor":""},"time":1}`, req.Query.Get("category"))
+	})
+	m := market.New(mock.Client())
+	for _, category := range []string{"spot", "linear"} {
+		page, err := m.GetInstrumentsInfo(context.Background(), market.InstrumentsInfoRequest{Category: category})
+		if assert.NoError(t, err) {
+			assert.Equal(t, category, page.Category)
+		}
+	}
+	assert.Len(t, mock.Requests(), 2)
+}
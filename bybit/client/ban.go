@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultIPBanDuration is how long the client assumes an IP ban lasts when the response
+// carries no Retry-After header. Bybit bans an IP that exceeds its limit for 10 minutes.
+const DefaultIPBanDuration = 10 * time.Minute
+
+// ErrIPBanned matches, with errors.Is, the *IPBanError of requests rejected because Bybit
+// banned the IP address.
+var ErrIPBanned = errors.New("ip address banned by bybit")
+
+// IPBanError is returned for an HTTP 403 or 429 response, and for every request made before
+// the ban ends, which the client refuses to send so that the ban is not extended.
+type IPBanError struct {
+	// Until is when the ban is expected to end.
+	Until time.Time
+	// Err is the *APIError of the response that started the ban.
+	Err error
+}
+
+func (e *IPBanError) Error() string {
+	return fmt.Sprintf("ip address banned by bybit until %s", e.Until.Format(time.RFC3339))
+}
+
+func (e *IPBanError) Is(target error) bool {
+	return target == ErrIPBanned
+}
+
+func (e *IPBanError) Unwrap() error {
+	return e.Err
+}
+
+// WithBanPause makes requests made during an IP ban wait for the ban to end, or for their
+// context to be done, instead of failing with an *IPBanError.
+func WithBanPause(pause bool) Option {
+	return func(c *Client) {
+		c.pauseOnBan = pause
+	}
+}
+
+// BannedUntil returns when the current IP ban ends, or the zero time when there is none.
+func (c *Client) BannedUntil() time.Time {
+	until := c.bannedUntil.Load()
+	if until == 0 || time.Now().UnixNano() >= until {
+		return time.Time{}
+	}
+	return time.Unix(0, until)
+}
+
+// checkBan fails, or waits with WithBanPause, while the IP is banned.
+func (c *Client) checkBan(ctx context.Context) error {
+	until := c.BannedUntil()
+	if until.IsZero() {
+		return nil
+	}
+	if !c.pauseOnBan {
+		banErr := &IPBanError{Until: until}
+		if err := c.banErr.Load(); err != nil {
+			banErr.Err = *err
+		}
+		return banErr
+	}
+	timer := time.NewTimer(time.Until(until))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recordBan starts a ban when err is the *APIError of an HTTP 403 or 429 response, and
+// returns the *IPBanError to report instead of err.
+func (c *Client) recordBan(header http.Header, err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) ||
+		(apiErr.StatusCode != http.StatusForbidden && apiErr.StatusCode != http.StatusTooManyRequests) {
+		return err
+	}
+	until := time.Now().Add(retryAfter(header, DefaultIPBanDuration))
+	c.banErr.Store(&err)
+	c.bannedUntil.Store(until.UnixNano())
+	return &IPBanError{Until: until, Err: err}
+}
+
+// retryAfter parses the Retry-After header, given in seconds or as an HTTP date.
+func retryAfter(header http.Header, fallback time.Duration) time.Duration {
+	value := header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return fallback
+}
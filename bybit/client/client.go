@@ -3,50 +3,65 @@ package client
 import (
 	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
-	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
 	"strconv"
+	"sync/atomic"
 	"time"
-
-	"golang.org/x/time/rate"
 )
 
 const (
-	recvWindow            = "5000"
-	BaseURL               = "https://api.bybit.com"
-	TestnetBaseURL        = "https://api-testnet.bybit.com"
-	APIVersion            = "v5"
-	GET            Method = "GET"
-	POST           Method = "POST"
+	defaultRecvWindow        = 5 * time.Second
+	BaseURL                  = "https://api.bybit.com"
+	TestnetBaseURL           = "https://api-testnet.bybit.com"
+	APIVersion               = "v5"
+	GET               Method = "GET"
+	POST              Method = "POST"
 
 	timestampKey  = "X-BAPI-TIMESTAMP"
 	signatureKey  = "X-BAPI-SIGN"
 	apiRequestKey = "X-BAPI-API-KEY"
 	recvWindowKey = "X-BAPI-RECV-WINDOW"
 	signTypeKey   = "X-BAPI-SIGN-TYPE"
+	refererKey    = "Referer"
 )
 
 // Requester interface defines methods for making HTTP GET and POST requests
 type Requester interface {
 	Get(path string, params Params) (Response, error)
 	Post(path string, params Params) (Response, error)
+	GetContext(ctx context.Context, path string, params Params) (Response, error)
+	PostContext(ctx context.Context, path string, params Params) (Response, error)
 }
 
 // Client struct holds information needed for API interaction
 type Client struct {
-	key             string
-	secretKey       string
-	httpClient      *http.Client
-	IsTestNet       bool
-	params          []byte
-	QueryParams     url.Values
-	endpointLimiter *EndpointRateLimiter
+	key                string
+	signer             Signer
+	httpClient         *http.Client
+	IsTestNet          bool
+	environment        Environment
+	domain             string
+	baseURLOverride    string
+	disableCompression bool
+	timeout            time.Duration
+	recvWindow         string
+	retry              retryPolicy
+	pauseOnBan         bool
+	breaker            *CircuitBreaker
+	observer           RequestObserver
+	tracer             RequestTracer
+	endpointLimiter    *EndpointRateLimiter
+	logger             Logger
+	debug              bool
+	brokerID           string
+	// timeOffset is the server minus the local clock in nanoseconds, see TimeSync.
+	timeOffset atomic.Int64
+	// bannedUntil is the end of an IP ban in Unix nanoseconds, and banErr the error that
+	// started it.
+	bannedUntil atomic.Int64
+	banErr      atomic.Pointer[error]
 }
 
 // Define HTTP method types as strings
@@ -62,97 +77,141 @@ type Request struct {
 	params Params
 }
 
-func (c *Client) initializeEndpointLimiters() {
-	// Make sure the limiter map isn't nil
-	if c.endpointLimiter == nil {
-		c.endpointLimiter = NewEndpointRateLimiter()
-	}
+// NewClient creates a new client instance with API key, secret key, and testnet setting
+func NewClient(key, secretKey string, isTestnet bool, opts ...Option) *Client {
+	return NewClientWithSigner(key, NewHMACSigner(secretKey), isTestnet, opts...)
+}
 
-	// Set the limiters for each endpoint
-	for endpoint, limit := range endpointLimits {
-		// log.Printf("Setting rate limiter for endpoint: %s with limit %v requests/sec", endpoint, limit)
-		limiter := rate.NewLimiter(limit, 5) // Burst size 5
-		c.endpointLimiter.SetLimiter(endpoint, limiter)
+// NewClientWithSigner creates a client that signs requests with signer, such as an
+// RSASigner for RSA API keys.
+func NewClientWithSigner(key string, signer Signer, isTestnet bool, opts ...Option) *Client {
+	environment := Mainnet
+	if isTestnet {
+		environment = Testnet
 	}
+	return NewClientWithOptions(append([]Option{WithSigner(key, signer), WithEnvironment(environment)}, opts...)...)
 }
 
-// NewClient creates a new client instance with API key, secret key, and testnet setting
-func NewClient(key, secretKey string, isTestnet bool) *Client {
+// NewClientWithOptions creates a client configured entirely by options:
+//
+//	c := client.NewClientWithOptions(
+//		client.WithCredentials(key, secret),
+//		client.WithEnvironment(client.Testnet),
+//		client.WithRecvWindow(10*time.Second),
+//		client.WithRetries(3, 200*time.Millisecond),
+//	)
+//
+// A client without credentials sends unsigned requests, which is enough for the public
+// market endpoints.
+func NewClientWithOptions(opts ...Option) *Client {
 	client := &Client{
-		key:             key,
-		secretKey:       secretKey,
 		httpClient:      &http.Client{},
-		IsTestNet:       isTestnet,
+		recvWindow:      strconv.FormatInt(defaultRecvWindow.Milliseconds(), 10),
 		endpointLimiter: NewEndpointRateLimiter(),
 	}
-
-	// Initialize the rate limiters for all endpoints
-	client.initializeEndpointLimiters()
-	// fmt.Printf("Rate limiter initialized for endpoint: %+v", client.endpointLimiter)
+	for _, opt := range opts {
+		opt(client)
+	}
 	return client
 }
 
 // Get method performs a GET request to the specified API path with params
 func (c *Client) Get(path string, params Params) (Response, error) {
-	return c.doRequest(GET, path, params)
+	return c.GetContext(context.Background(), path, params)
 }
 
 // Post method performs a POST request to the specified API path with params
 func (c *Client) Post(path string, params Params) (Response, error) {
-	return c.doRequest(POST, path, params)
+	return c.PostContext(context.Background(), path, params)
+}
+
+// GetContext is like Get but cancels the rate limiter wait and the HTTP request when ctx
+// is done.
+func (c *Client) GetContext(ctx context.Context, path string, params Params) (Response, error) {
+	return c.doRequest(ctx, GET, path, params)
+}
+
+// PostContext is like Post but cancels the rate limiter wait and the HTTP request when ctx
+// is done.
+func (c *Client) PostContext(ctx context.Context, path string, params Params) (Response, error) {
+	return c.doRequest(ctx, POST, path, params)
 }
 
 // doRequest handles both GET and POST requests, applying rate limiting and signing
-func (c *Client) doRequest(method Method, path string, params Params) (Response, error) {
+func (c *Client) doRequest(ctx context.Context, method Method, path string, params Params) (Response, error) {
 	// Ensure the endpointLimiter is initialized
 	if c.endpointLimiter == nil {
 		return nil, fmt.Errorf("endpointLimiter is not initialized")
 	}
 
-	// Generate the endpoint key
-	endpointKey := fmt.Sprintf("%s %s", method, path)
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
 
-	// Get the rate limiter for this endpoint
-	limiter := c.endpointLimiter.GetLimiter(endpointKey)
-	if limiter == nil {
-		// log.Printf("No rate limiter found for endpoint: %s. Applying default rate limit.", endpointKey)
-		limiter = rate.NewLimiter(rate.Limit(30.0/60.0), 1) // Default to 30 requests per minute
-	}
-
-	// Wait for the rate limiter to allow the request
-	ctx := context.Background()
-	if err := limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limiter error: %w", err)
-	}
-
-	// Continue with request processing
 	req := &Request{
 		method: method,
 		path:   path,
 		params: params,
 	}
-	return c.do(req)
+	if c.tracer == nil {
+		return c.attempt(ctx, req)
+	}
+	start := time.Now()
+	ctx, end := c.tracer.StartRequest(ctx, method, path, params)
+	res, err := c.attempt(ctx, req)
+	end(newRequestInfo(req, res, time.Since(start), err))
+	return res, err
 }
 
-// do handles the actual execution of the HTTP request
-func (c *Client) do(req *Request) (Response, error) {
-	c.QueryParams = make(url.Values)
-	baseURL := BaseURL
-	if c.IsTestNet {
-		baseURL = TestnetBaseURL
+// attempt sends req until it succeeds or the retry policy gives up.
+func (c *Client) attempt(ctx context.Context, req *Request) (Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.checkBan(ctx); err != nil {
+			return nil, err
+		}
+		// Wait for the rate limiter of the endpoint to allow the request
+		if err := c.endpointLimiter.Wait(ctx, endpointKey(req.method, req.path)); err != nil {
+			return nil, fmt.Errorf("rate limiter error: %w", err)
+		}
+
+		if c.breaker != nil {
+			if err := c.breaker.Allow(); err != nil {
+				return nil, err
+			}
+		}
+		res, err := c.do(ctx, req)
+		if c.breaker != nil {
+			c.breaker.Record(err)
+		}
+		if attempt >= c.retry.maxRetries || !c.retry.retryable(req, err) {
+			return res, err
+		}
+		if err := c.retry.wait(ctx, attempt); err != nil {
+			return res, err
+		}
 	}
+}
+
+// endpointKey identifies the rate limiter of an endpoint.
+func endpointKey(method Method, path string) string {
+	return fmt.Sprintf("%s %s", method, path)
+}
+
+// do handles the actual execution of the HTTP request
+func (c *Client) do(ctx context.Context, req *Request) (Response, error) {
+	baseURL := c.baseURL()
 
 	var (
 		httpReq *http.Request
+		payload string
 		err     error
 	)
 
 	// Prepare the GET or POST request based on the method
 	switch req.method {
 	case GET:
-		httpReq, err = c.newGETRequest(baseURL, req)
+		httpReq, payload, err = c.newGETRequest(baseURL, req)
 	case POST:
-		httpReq, err = c.newPOSTRequest(baseURL, req)
+		httpReq, payload, err = c.newPOSTRequest(baseURL, req)
 	default:
 		return nil, errors.New("unsupported method")
 	}
@@ -162,66 +221,125 @@ func (c *Client) do(req *Request) (Response, error) {
 	}
 
 	// Set common headers for the request
-	c.setCommonHeaders(httpReq)
+	if err := c.setCommonHeaders(httpReq, payload); err != nil {
+		return nil, err
+	}
+	if !c.disableCompression {
+		// Setting the header turns off the transparent decompression of http.Transport, so
+		// NewResponse decompresses; this also covers custom transports.
+		httpReq.Header.Set("Accept-Encoding", "gzip")
+	}
 
 	// Execute the request
-	resp, err := c.httpClient.Do(httpReq)
+	start := time.Now()
+	resp, err := c.httpClient.Do(httpReq.WithContext(ctx))
+	elapsed := time.Since(start)
 	if err != nil {
+		if c.debug {
+			c.logRequest(req, httpReq, elapsed, nil, err)
+		}
+		c.observeRequest(req, nil, elapsed, err)
 		return nil, err
 	}
 	defer resp.Body.Close()
-
-	// Process and return the response
-	return NewResponse(resp), nil
-}
-func (c *Client) newGETRequest(baseURL string, req *Request) (*http.Request, error) {
-	c.QueryParams = url.Values{}
-	for k, v := range req.params {
-		c.QueryParams.Set(k, fmt.Sprintf("%v", v))
+	c.endpointLimiter.Update(endpointKey(req.method, req.path), resp.Header)
+
+	// Process and return the response. A non-zero retCode is returned as *APIError together
+	// with the response.
+	response := newResponse(resp, elapsed)
+	captureMetadata(ctx, response.Metadata())
+	if err := response.Error(); err != nil {
+		if c.debug {
+			c.logRequest(req, httpReq, elapsed, nil, err)
+		}
+		c.observeRequest(req, response, elapsed, err)
+		return nil, err
+	}
+	if c.debug {
+		c.logRequest(req, httpReq, elapsed, response, nil)
 	}
+	err = c.recordBan(resp.Header, checkResponse(req, response))
+	c.observeRequest(req, response, elapsed, err)
+	return response, err
+}
 
-	return http.NewRequest(string(GET), baseURL+req.path+"?"+c.QueryParams.Encode(), http.NoBody)
+// newGETRequest returns the request and its query string, which is signed.
+func (c *Client) newGETRequest(baseURL string, req *Request) (*http.Request, string, error) {
+	query, err := req.params.Query()
+	if err != nil {
+		return nil, "", err
+	}
+	httpReq, err := http.NewRequest(string(GET), baseURL+req.path+"?"+query, http.NoBody)
+	return httpReq, query, err
 }
 
-func (c *Client) newPOSTRequest(baseURL string, req *Request) (*http.Request, error) {
-	jsonData, err := json.Marshal(req.params)
+// newPOSTRequest returns the request and its body, which is signed.
+func (c *Client) newPOSTRequest(baseURL string, req *Request) (*http.Request, string, error) {
+	body, err := req.params.Body()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	c.params = jsonData
-	return http.NewRequest(string(POST), baseURL+req.path, bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequest(string(POST), baseURL+req.path, bytes.NewReader(body))
+	return httpReq, string(body), err
 }
-func (c *Client) setCommonHeaders(req *http.Request) {
-	timestamp := strconv.FormatInt(GetCurrentTime(), 10) // Get the current timestamp in milliseconds
-	req.Header.Set(signTypeKey, "2")
+
+// setCommonHeaders authenticates req, signing payload: the query string of a GET request or
+// the body of a POST request.
+func (c *Client) setCommonHeaders(req *http.Request, payload string) error {
+	if c.signer == nil {
+		// Public requests need no authentication
+		if req.Method == "POST" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if c.brokerID != "" {
+			req.Header.Set(refererKey, c.brokerID)
+		}
+		return nil
+	}
+
+	timestamp := strconv.FormatInt(c.Now().UnixMilli(), 10) // Get the server adjusted timestamp in milliseconds
+	if _, ok := c.signer.(*HMACSigner); ok {
+		req.Header.Set(signTypeKey, "2")
+	}
 	req.Header.Set(apiRequestKey, c.key)
 	req.Header.Set(timestampKey, timestamp)
-	req.Header.Set(recvWindowKey, "5000") // Match Bybit's recvWindow of 5000 ms
+	req.Header.Set(recvWindowKey, c.recvWindow)
+	if c.brokerID != "" {
+		req.Header.Set(refererKey, c.brokerID)
+	}
 
-	var signatureBase []byte
 	if req.Method == "POST" {
 		req.Header.Set("Content-Type", "application/json")
-		// Concatenate timestamp, API key, recvWindow, and the request body for POST requests
-		signatureBase = []byte(timestamp + c.key + "5000" + string(c.params))
-	} else {
-		// Alphabetically sort query parameters and concatenate them with other fields for GET requests
-		queryString := c.QueryParams.Encode() // Automatically sorts the parameters alphabetically
-		signatureBase = []byte(timestamp + c.key + "5000" + queryString)
 	}
+	// Concatenate timestamp, API key, recvWindow, and the query string or body
+	signatureBase := timestamp + c.key + c.recvWindow + payload
 
-	// Generate the HMAC-SHA256 signature
-	hmac256 := hmac.New(sha256.New, []byte(c.secretKey))
-	hmac256.Write(signatureBase)
-	signature := hex.EncodeToString(hmac256.Sum(nil))
+	// Sign with HMAC-SHA256 or RSA depending on the key type
+	signature, err := c.signer.Sign(signatureBase)
+	if err != nil {
+		return err
+	}
 
 	// Set the signature in the headers
 	req.Header.Set(signatureKey, signature)
+	return nil
+}
 
-	// Debug logging for troubleshooting
-	// 	log.Printf("Signature Base String: %s", string(signatureBase))
-	// 	log.Printf("Generated Signature: %s", signature)
-	// 	log.Printf("Headers: X-BAPI-API-KEY=%s, X-BAPI-TIMESTAMP=%s, X-BAPI-SIGN=%s", c.key, timestamp, signature)
+// SetTimeOffset sets the offset added to the local clock when timestamping signed requests.
+func (c *Client) SetTimeOffset(offset time.Duration) {
+	c.timeOffset.Store(int64(offset))
 }
+
+// TimeOffset returns the offset set by SetTimeOffset or TimeSync.
+func (c *Client) TimeOffset() time.Duration {
+	return time.Duration(c.timeOffset.Load())
+}
+
+// Now returns the local time corrected by the time offset, an estimate of Bybit's clock.
+func (c *Client) Now() time.Time {
+	return time.Now().Add(c.TimeOffset())
+}
+
 func GetCurrentTime() int64 {
 	return time.Now().UnixNano() / int64(time.Millisecond)
 }
@@ -0,0 +1,635 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestGetContextCanceled(t *testing.T) {
+	c := NewClient("key", "secret", true)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.GetContext(ctx, "/v5/market/time", Params{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestAPIError(t *testing.T) {
+	body := `{"retCode":10006,"retMsg":"Too many visits!","result":{},"time":1}`
+	c := NewClient("key", "secret", true)
+	c.httpClient.Transport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	_, err := c.Get("/v5/order/realtime", Params{"category": "linear"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if !apiErr.IsRateLimited() || apiErr.IsInvalidAPIKey() || apiErr.IsInsufficientBalance() {
+		t.Errorf("unexpected predicates for %v", apiErr)
+	}
+	if apiErr.Endpoint != "/v5/order/realtime" || string(apiErr.Body) != body {
+		t.Errorf("unexpected endpoint %q or body %q", apiErr.Endpoint, apiErr.Body)
+	}
+	for _, retCode := range []int{RetCodeOrderNotFound, 170213} {
+		if !(&APIError{RetCode: retCode}).IsOrderNotFound() {
+			t.Errorf("expected retCode %d to be an order not found", retCode)
+		}
+	}
+}
+
+func TestEndpointRateLimiter_Update(t *testing.T) {
+	limiter := NewEndpointRateLimiter()
+	reset := time.Now().Add(200 * time.Millisecond)
+	header := http.Header{}
+	header.Set("X-Bapi-Limit", "20")
+	header.Set("X-Bapi-Limit-Status", "0")
+	header.Set("X-Bapi-Limit-Reset-Timestamp", strconv.FormatInt(reset.UnixMilli(), 10))
+	limiter.Update("GET /v5/order/realtime", header)
+
+	if got := limiter.GetLimiter("GET /v5/order/realtime").Limit(); got != 20 {
+		t.Errorf("expected a limit of 20 requests per second, got %v", got)
+	}
+	if err := limiter.Wait(context.Background(), "GET /v5/order/realtime"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Now().Before(reset.Truncate(time.Millisecond)) {
+		t.Error("Wait returned before the limit reset")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	header.Set("X-Bapi-Limit-Reset-Timestamp", strconv.FormatInt(time.Now().Add(time.Minute).UnixMilli(), 10))
+	limiter.Update("GET /v5/order/realtime", header)
+	if err := limiter.Wait(ctx, "GET /v5/order/realtime"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to be canceled, got %v", err)
+	}
+}
+
+func TestEndpointRateLimiter_Shared(t *testing.T) {
+	limiter := NewEndpointRateLimiter()
+	transport := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		body := `{"retCode":0,"retMsg":"OK","result":{},"time":1}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	clients := []*Client{
+		NewClient("key", "secret", false, WithRateLimiter(limiter), WithTransport(transport)),
+		NewClient("key", "secret", false, WithRateLimiter(limiter), WithTransport(transport)),
+	}
+	if got := limiter.GetLimiter("POST /v5/order/create").Limit(); got != tenPerMinute {
+		t.Fatalf("expected the static order/create limit, got %v", got)
+	}
+
+	// A burst of 5 and 10 requests per second make the 7th request wait at least 200ms.
+	start := time.Now()
+	for i := 0; i < 7; i++ {
+		if _, err := clients[i%2].Post("/v5/order/create", Params{"category": "linear"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected the shared order/create limit to be enforced, took %v", elapsed)
+	}
+}
+
+func TestEndpointRateLimiter_Unconfigured(t *testing.T) {
+	limiter := NewEndpointRateLimiter()
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if err := limiter.Wait(context.Background(), "GET /v5/market/tickers"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("expected an endpoint without a limit not to be throttled, took %v", elapsed)
+	}
+
+	header := http.Header{}
+	header.Set("X-Bapi-Limit", "50")
+	limiter.Update("GET /v5/market/tickers", header)
+	if got := limiter.GetLimiter("GET /v5/market/tickers"); got.Limit() != 50 || got.Burst() != 50 || got.Tokens() > 50 {
+		t.Errorf("expected the reported limit of 50 requests per second, got %v with burst %d", got.Limit(), got.Burst())
+	}
+}
+
+func TestTimeSync(t *testing.T) {
+	serverNow := time.Now().Add(3 * time.Second)
+	body := fmt.Sprintf(`{"retCode":0,"retMsg":"OK","result":{"timeSecond":"%d","timeNano":"%d"},"time":%d}`,
+		serverNow.Unix(), serverNow.UnixNano(), serverNow.UnixMilli())
+	c := NewClient("key", "secret", true)
+	c.httpClient.Transport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	offset, err := NewTimeSync(c, time.Minute).Sync(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset < 2900*time.Millisecond || offset > 3*time.Second {
+		t.Errorf("expected an offset of about 3s, got %v", offset)
+	}
+	if c.TimeOffset() != offset {
+		t.Errorf("expected the client offset to be %v, got %v", offset, c.TimeOffset())
+	}
+
+	req, _ := http.NewRequest(string(GET), BaseURL, http.NoBody)
+	if err := c.setCommonHeaders(req, ""); err != nil {
+		t.Fatal(err)
+	}
+	timestamp, _ := strconv.ParseInt(req.Header.Get(timestampKey), 10, 64)
+	if skew := time.UnixMilli(timestamp).Sub(serverNow); skew < -time.Second || skew > time.Second {
+		t.Errorf("expected the signed timestamp to follow the server clock, skew %v", skew)
+	}
+}
+
+func TestParseServerTime(t *testing.T) {
+	now, err := ParseServerTime("1688639403", "1688639403423213947")
+	if err != nil || now.UnixNano() != 1688639403423213947 {
+		t.Errorf("expected the nanosecond time, got %v, %v", now, err)
+	}
+	now, err = ParseServerTime("1688639403", "")
+	if err != nil || now.Unix() != 1688639403 {
+		t.Errorf("expected the second time, got %v, %v", now, err)
+	}
+	if _, err := ParseServerTime("", ""); err == nil {
+		t.Error("expected an error for an empty server time")
+	}
+}
+
+func TestSleepUntil(t *testing.T) {
+	c := NewClient("key", "secret", true)
+	c.SetTimeOffset(time.Hour)
+	start := time.Now()
+	if err := SleepUntil(context.Background(), c, c.Now().Add(20*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond || elapsed > time.Second {
+		t.Errorf("expected to sleep for about 20ms on the server clock, slept %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := SleepUntil(ctx, c, c.Now().Add(time.Hour)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRSASigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewRSASignerFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: mustMarshalPKCS8(t, key)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var header http.Header
+	var query string
+	c := NewClientWithSigner("key", signer, true)
+	c.httpClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header, query = req.Header, req.URL.RawQuery
+		body := `{"retCode":0,"retMsg":"OK","result":{},"time":1}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	if _, err := c.Get("/v5/order/realtime", Params{"category": "linear"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Get(signTypeKey) != "" {
+		t.Errorf("expected no HMAC sign type header, got %q", header.Get(signTypeKey))
+	}
+	signature, err := base64.StdEncoding.DecodeString(header.Get(signatureKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashed := sha256.Sum256([]byte(header.Get(timestampKey) + "key" + "5000" + query))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hashed[:], signature); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+}
+
+func mustMarshalPKCS8(t *testing.T, key *rsa.PrivateKey) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestClientOptions(t *testing.T) {
+	var called bool
+	httpClient := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		called = true
+		body := `{"retCode":0,"retMsg":"OK","result":{},"time":1}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+	c := NewClient("key", "secret", true, WithHTTPClient(httpClient), WithTimeout(time.Second))
+	if c.HTTPClient() != httpClient {
+		t.Fatal("expected the injected HTTP client")
+	}
+	if _, err := c.Get("/v5/market/time", Params{}); err != nil || !called {
+		t.Fatalf("expected the request to go through the injected transport, got %v", err)
+	}
+
+	proxyURL, _ := url.Parse("http://proxy.internal:3128")
+	c = NewClient("key", "secret", true, WithProxy(proxyURL))
+	transport, ok := c.HTTPClient().Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", c.HTTPClient().Transport)
+	}
+	req, _ := http.NewRequest(string(GET), BaseURL, http.NoBody)
+	if got, err := transport.Proxy(req); err != nil || got.String() != proxyURL.String() {
+		t.Errorf("expected requests to use %v, got %v (%v)", proxyURL, got, err)
+	}
+	if http.RoundTripper(transport) == http.DefaultTransport {
+		t.Error("expected the default transport to be cloned")
+	}
+
+	c = NewClient("key", "secret", true, WithHTTPClient(http.DefaultClient), WithProxy(proxyURL), WithTransport(httpClient.Transport))
+	if c.HTTPClient() == http.DefaultClient || http.DefaultClient.Transport != nil {
+		t.Error("expected the shared HTTP client to be copied rather than changed")
+	}
+	if c.HTTPClient().Transport == nil {
+		t.Error("expected the copy to use the transport")
+	}
+}
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Debug(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+func (l *recordingLogger) Info(format string, v ...any)  {}
+func (l *recordingLogger) Warn(format string, v ...any)  {}
+func (l *recordingLogger) Error(format string, v ...any) {}
+
+func TestDebug(t *testing.T) {
+	body := `{"retCode":10001,"retMsg":"params error","result":{},"time":1}` + strings.Repeat(" ", 2*maxDebugBody)
+	logger := &recordingLogger{}
+	c := NewClient("my-api-key", "secret", true, WithDebug(true), WithLogger(logger), WithTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})))
+	_, _ = c.Post("/v5/order/create", Params{"symbol": "BTCUSDT", "sign": "secret-sign"})
+
+	if len(logger.lines) != 1 {
+		t.Fatalf("expected one debug line, got %d", len(logger.lines))
+	}
+	line := logger.lines[0]
+	for _, want := range []string{"POST /v5/order/create", `"symbol":"BTCUSDT"`, "X-BAPI-API-KEY=[REDACTED]", "X-BAPI-SIGN=[REDACTED]", "retCode=10001", "...(truncated)"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("expected %q in %s", want, line)
+		}
+	}
+	for _, secret := range []string{"my-api-key", "secret-sign"} {
+		if strings.Contains(line, secret) {
+			t.Errorf("expected %q to be redacted in %s", secret, line)
+		}
+	}
+}
+
+func TestWithMetadata(t *testing.T) {
+	reset := time.Now().Add(time.Second).Truncate(time.Millisecond)
+	c := NewClient("key", "secret", true, WithTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("Traceid", "trace-1")
+		header.Set("X-Bapi-Limit", "10")
+		header.Set("X-Bapi-Limit-Status", "9")
+		header.Set("X-Bapi-Limit-Reset-Timestamp", strconv.FormatInt(reset.UnixMilli(), 10))
+		body := `{"retCode":110001,"retMsg":"order not exists","result":{},"time":1}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: header, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})))
+
+	ctx, meta := WithMetadata(context.Background())
+	res, err := c.PostContext(ctx, "/v5/order/cancel", Params{"category": "linear"})
+	want := RateLimit{Limit: 10, Remaining: 9, Reset: reset}
+	if meta.TraceID != "trace-1" || meta.StatusCode != http.StatusOK || meta.RateLimit != want || meta.Elapsed <= 0 {
+		t.Errorf("unexpected metadata %+v", meta)
+	}
+	if res.Metadata().TraceID != "trace-1" {
+		t.Errorf("expected the response to carry the trace id, got %q", res.Metadata().TraceID)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.TraceID != "trace-1" {
+		t.Errorf("expected *APIError with the trace id, got %v", err)
+	}
+}
+
+func TestWithBrokerID(t *testing.T) {
+	var referer string
+	c := NewClient("key", "secret", true, WithBrokerID("broker-1"), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		referer = req.Header.Get("Referer")
+		body := `{"retCode":0,"retMsg":"OK","result":{},"time":1}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})))
+	if _, err := c.Post("/v5/order/create", Params{"category": "linear"}); err != nil {
+		t.Fatal(err)
+	}
+	if referer != "broker-1" {
+		t.Errorf("expected the broker id in the Referer header, got %q", referer)
+	}
+}
+
+func TestEnvironment(t *testing.T) {
+	for _, tc := range []struct {
+		client *Client
+		want   Environment
+		host   string
+	}{
+		{NewClient("key", "secret", false), Mainnet, "api.bybit.com"},
+		{NewClient("key", "secret", true), Testnet, "api-testnet.bybit.com"},
+		{NewClient("key", "secret", true, WithEnvironment(Demo)), Demo, "api-demo.bybit.com"},
+		{NewClient("key", "secret", false, WithBaseDomain(DomainNL)), Mainnet, "api.bybit.nl"},
+		{NewClient("key", "secret", true, WithBaseDomain(DomainBytick)), Testnet, "api-testnet.bytick.com"},
+	} {
+		var host string
+		tc.client.httpClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			host = req.URL.Host
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(`{"retCode":0}`))}, nil
+		})
+		if _, err := tc.client.Get("/v5/market/time", Params{}); err != nil {
+			t.Fatal(err)
+		}
+		if tc.client.Environment() != tc.want || host != tc.host {
+			t.Errorf("expected %s on %s, got %s on %s", tc.want, tc.host, tc.client.Environment(), host)
+		}
+	}
+}
+
+func TestCompression(t *testing.T) {
+	body := `{"retCode":0,"retMsg":"OK","result":{"list":[]},"time":1}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			_, _ = io.WriteString(w, body)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = io.WriteString(gz, body)
+		_ = gz.Close()
+	}))
+	defer srv.Close()
+
+	res, err := NewClient("key", "secret", false, WithBaseURL(srv.URL)).Get("/v5/market/instruments-info", Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res.Data()) != body || res.Metadata().Header.Get("Content-Encoding") != "gzip" {
+		t.Errorf("expected the gzipped body to be decompressed, got %q", res.Data())
+	}
+
+	var acceptEncoding string
+	c := NewClient("key", "secret", false, WithCompression(false), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		acceptEncoding = req.Header.Get("Accept-Encoding")
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})))
+	if _, err := c.Get("/v5/market/instruments-info", Params{}); err != nil {
+		t.Fatal(err)
+	}
+	if acceptEncoding != "" {
+		t.Errorf("expected no Accept-Encoding header with compression disabled, got %q", acceptEncoding)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	c := NewClient("key", "secret", false, WithTimeout(20*time.Millisecond), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-time.After(50 * time.Millisecond):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		body := `{"retCode":0,"retMsg":"OK","result":{},"time":1}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	})))
+
+	if _, err := c.Get("/v5/order/history", Params{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the client timeout to expire, got %v", err)
+	}
+	if _, err := c.GetContext(WithCallTimeout(context.Background(), time.Second), "/v5/order/history", Params{}); err != nil {
+		t.Errorf("expected the looser call timeout to apply, got %v", err)
+	}
+	if _, err := c.GetContext(WithCallTimeout(context.Background(), 0), "/v5/order/history", Params{}); err != nil {
+		t.Errorf("expected no timeout, got %v", err)
+	}
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	var (
+		attempts int
+		headers  []http.Header
+	)
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		headers = append(headers, req.Header)
+		if attempts < 3 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Header: http.Header{}, Body: io.NopCloser(strings.NewReader("unavailable"))}, nil
+		}
+		body := `{"retCode":0,"retMsg":"OK","result":{},"time":1}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	limiter := NewEndpointRateLimiter()
+	c := NewClientWithOptions(
+		WithCredentials("key", "secret"),
+		WithEnvironment(Testnet),
+		WithTransport(transport),
+		WithRecvWindow(10*time.Second),
+		WithRetries(2, time.Millisecond),
+		WithRateLimiter(limiter),
+	)
+	if !c.IsTestNet || c.endpointLimiter != limiter {
+		t.Fatal("expected the testnet environment and the shared rate limiter")
+	}
+
+	if _, err := c.Get("/v5/market/time", Params{}); err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if got := headers[0].Get(recvWindowKey); got != "10000" {
+		t.Errorf("expected a receive window of 10000, got %q", got)
+	}
+	if headers[0].Get(apiRequestKey) != "key" || headers[0].Get(signatureKey) == "" {
+		t.Error("expected signed requests")
+	}
+
+	attempts = 0
+	if _, err := c.Post("/v5/order/create", Params{}); err == nil {
+		t.Error("expected the unretried POST to fail")
+	}
+	if attempts != 1 {
+		t.Errorf("expected POST requests not to be retried, got %d attempts", attempts)
+	}
+
+	headers = nil
+	attempts = 2
+	public := NewClientWithOptions(WithTransport(transport), WithRateLimiter(limiter))
+	if _, err := public.Get("/v5/market/time", Params{}); err != nil {
+		t.Fatal(err)
+	}
+	if headers[0].Get(apiRequestKey) != "" || headers[0].Get(signatureKey) != "" {
+		t.Error("expected unsigned requests without credentials")
+	}
+}
+
+func TestIPBan(t *testing.T) {
+	var requests int
+	c := NewClient("key", "secret", false, WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		header := http.Header{"Retry-After": []string{"1"}}
+		return &http.Response{StatusCode: http.StatusForbidden, Status: "403 Forbidden", Header: header, Body: io.NopCloser(strings.NewReader("access too frequent"))}, nil
+	})))
+
+	_, err := c.Get("/v5/market/time", Params{})
+	var banErr *IPBanError
+	if !errors.Is(err, ErrIPBanned) || !errors.As(err, &banErr) {
+		t.Fatalf("expected an IP ban error, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected the ban to wrap the 403 API error, got %v", err)
+	}
+	if until := time.Until(banErr.Until); until <= 0 || until > time.Second {
+		t.Errorf("expected the ban to end after Retry-After, got %v", until)
+	}
+	if !c.BannedUntil().Equal(banErr.Until) {
+		t.Errorf("expected BannedUntil %v, got %v", banErr.Until, c.BannedUntil())
+	}
+
+	if _, err := c.Get("/v5/market/time", Params{}); !errors.Is(err, ErrIPBanned) || requests != 1 {
+		t.Errorf("expected requests during the ban to fail unsent, got %v after %d requests", err, requests)
+	}
+
+	WithBanPause(true)(c)
+	start := time.Now()
+	if _, err := c.Get("/v5/market/time", Params{}); !errors.Is(err, ErrIPBanned) || requests != 2 {
+		t.Errorf("expected the paused request to be sent after the ban, got %v after %d requests", err, requests)
+	}
+	if !start.Add(500 * time.Millisecond).Before(time.Now()) {
+		t.Error("expected the request to wait for the ban to end")
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		requests int
+		fail     = true
+	)
+	breaker := NewCircuitBreaker(2, 50*time.Millisecond)
+	c := NewClient("key", "secret", false, WithCircuitBreaker(breaker), WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		if fail {
+			return nil, errors.New("connection refused")
+		}
+		body := `{"retCode":0,"retMsg":"OK","result":{},"time":1}`
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})))
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Get("/v5/market/time", Params{}); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("expected request %d to fail, got %v", i, err)
+		}
+	}
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("expected the circuit to open, got %v", breaker.State())
+	}
+	if _, err := c.Get("/v5/market/time", Params{}); !errors.Is(err, ErrCircuitOpen) || requests != 2 {
+		t.Fatalf("expected the open circuit to reject the request unsent, got %v after %d requests", err, requests)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("expected the circuit to be half-open, got %v", breaker.State())
+	}
+	if _, err := c.Get("/v5/market/time", Params{}); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the failing probe to be sent, got %v", err)
+	}
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("expected the failed probe to reopen the circuit, got %v", breaker.State())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	fail = false
+	if _, err := c.Get("/v5/market/time", Params{}); err != nil {
+		t.Fatalf("expected the probe to succeed, got %v", err)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("expected the successful probe to close the circuit, got %v", breaker.State())
+	}
+}
+
+func TestEndpointRateLimiter_Groups(t *testing.T) {
+	limiter := NewEndpointRateLimiter()
+	limiter.SetGlobalLimiter(nil)
+	endpoints := []string{"POST /v5/order/create", "POST /v5/order/amend", "GET /v5/market/time"}
+	for _, key := range endpoints {
+		limiter.SetLimiter(key, rate.NewLimiter(rate.Inf, 1))
+	}
+	limiter.SetGroupLimiter(rate.NewLimiter(20, 2), endpoints[:2]...)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background(), key); err != nil {
+				t.Error(err)
+			}
+		}(endpoints[i%2])
+	}
+	wg.Wait()
+	// The burst of 2 covers two requests, the other four wait 50ms each
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("expected the group to pace both endpoints, took %v", elapsed)
+	}
+
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		if err := limiter.Wait(context.Background(), endpoints[2]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected endpoints outside the group to be unaffected, took %v", elapsed)
+	}
+
+	limiter.SetGlobalLimiter(rate.NewLimiter(rate.Every(time.Hour), 1))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_ = limiter.Wait(ctx, endpoints[2])
+	if err := limiter.Wait(ctx, endpoints[2]); err == nil {
+		t.Error("expected the global limiter to apply to every endpoint")
+	}
+}
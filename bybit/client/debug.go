@@ -0,0 +1,135 @@
+package client
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxDebugBody is the number of body bytes logged in debug mode.
+const maxDebugBody = 1024
+
+// redacted replaces credentials in debug output.
+const redacted = "[REDACTED]"
+
+// Logger receives the client's debug output. It has the shape of the WebSocket client's
+// Logger, so the same implementation, such as the logger package's *logger.Logger, serves
+// both.
+type Logger interface {
+	Debug(format string, v ...any)
+	Info(format string, v ...any)
+	Warn(format string, v ...any)
+	Error(format string, v ...any)
+}
+
+// defaultLogger is used in debug mode when no logger is configured.
+func defaultLogger() Logger {
+	return stdLogger{log.New(os.Stdout, "[RestClient] ", log.LstdFlags)}
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+func (s stdLogger) Debug(format string, v ...any) { s.l.Printf("DEBUG "+format, v...) }
+func (s stdLogger) Info(format string, v ...any)  { s.l.Printf("INFO "+format, v...) }
+func (s stdLogger) Warn(format string, v ...any)  { s.l.Printf("WARN "+format, v...) }
+func (s stdLogger) Error(format string, v ...any) { s.l.Printf("ERROR "+format, v...) }
+
+// WithLogger sets the logger debug output is written to.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithDebug logs every request with its method, path, parameters, headers, latency,
+// status, retCode and a truncated body. The API key and signature are redacted. Output
+// goes to the logger set by WithLogger, or to stdout.
+func WithDebug(debug bool) Option {
+	return func(c *Client) {
+		c.debug = debug
+	}
+}
+
+// sensitiveParams are parameter names whose values are redacted in debug output.
+var sensitiveParams = map[string]bool{
+	"apikey":    true,
+	"api_key":   true,
+	"secret":    true,
+	"sign":      true,
+	"signature": true,
+}
+
+// sensitiveHeaders are the headers whose values are redacted in debug output.
+var sensitiveHeaders = map[string]bool{
+	apiRequestKey: true,
+	signatureKey:  true,
+}
+
+// logRequest writes the debug dump of one request. res is nil when the request failed
+// before a response was read.
+func (c *Client) logRequest(req *Request, httpReq *http.Request, latency time.Duration, res Response, err error) {
+	logger := c.logger
+	if logger == nil {
+		logger = defaultLogger()
+	}
+	params := sanitizeParams(req.params)
+	headers := sanitizeHeaders(httpReq.Header)
+	if res == nil {
+		logger.Debug("%s %s params=%s headers=%s latency=%s error=%v", req.method, req.path, params, headers, latency, err)
+		return
+	}
+
+	var body struct {
+		RetCode int `json:"retCode"`
+	}
+	_ = json.Unmarshal(res.Data(), &body)
+	logger.Debug("%s %s params=%s headers=%s latency=%s status=%d retCode=%d body=%s",
+		req.method, req.path, params, headers, latency, res.StatusCode(), body.RetCode, truncate(res.Data(), maxDebugBody))
+}
+
+// sanitizeParams encodes params as JSON with sensitive values redacted.
+func sanitizeParams(params Params) string {
+	sanitized := make(Params, len(params))
+	for k, v := range params {
+		if sensitiveParams[strings.ToLower(k)] {
+			v = redacted
+		}
+		sanitized[k] = v
+	}
+	data, err := json.Marshal(sanitized)
+	if err != nil {
+		return "<unencodable params>"
+	}
+	return string(data)
+}
+
+// sanitizeHeaders formats the X-BAPI headers with the API key and signature redacted.
+func sanitizeHeaders(header http.Header) string {
+	var parts []string
+	for k := range header {
+		if !strings.HasPrefix(strings.ToUpper(k), "X-BAPI-") {
+			continue
+		}
+		v := header.Get(k)
+		if sensitiveHeaders[strings.ToUpper(k)] {
+			v = redacted
+		}
+		parts = append(parts, strings.ToUpper(k)+"="+v)
+	}
+	sort.Strings(parts)
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// truncate returns at most n bytes of data, marking the cut.
+func truncate(data []byte, n int) string {
+	if len(data) <= n {
+		return string(data)
+	}
+	return string(data[:n]) + "...(truncated)"
+}
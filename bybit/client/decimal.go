@@ -0,0 +1,245 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact fixed-point number. Bybit encodes prices, quantities and balances as
+// decimal strings, and Decimal keeps them without the rounding errors of float64, which
+// matters when amounts are summed or compared in accounting code. The zero value is 0.
+type Decimal struct {
+	// unscaled is nil for zero and never modified after construction, so copies can share it.
+	unscaled *big.Int
+	scale    int32
+}
+
+// NewDecimal returns unscaled * 10^-scale, so NewDecimal(125, 4) is 0.0125.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	return Decimal{unscaled: big.NewInt(unscaled), scale: scale}.normalize()
+}
+
+// maxScale bounds the scale of a parsed decimal in both directions. Bybit's amounts need a
+// few dozen digits at most, while an exponent such as 1e999999 would expand into a number
+// of a million digits, and one beyond the int32 range would wrap the scale.
+const maxScale = 1 << 12
+
+// ParseDecimal parses a decimal string such as "-12.5" or "1e-8". It rejects numbers whose
+// scale, the count of fractional digits minus the exponent, lies outside -4096 to 4096.
+func ParseDecimal(s string) (Decimal, error) {
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		mantissa = s[:i]
+		if exponent, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+	}
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '+' || mantissa[0] == '-') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	unscaled, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	scale := int64(len(fracPart)) - exponent
+	if scale < -maxScale || scale > maxScale {
+		return Decimal{}, fmt.Errorf("decimal %q is out of range", s)
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}.normalize(), nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on invalid input. It is meant for
+// constants.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Add returns d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{unscaled: new(big.Int).Add(a, b), scale: scale}.normalize()
+}
+
+// Sub returns d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{unscaled: new(big.Int).Sub(a, b), scale: scale}.normalize()
+}
+
+// Mul returns d * other.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), other.int()), scale: d.scale + other.scale}.normalize()
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}.normalize()
+}
+
+// Cmp returns -1, 0 or +1 depending on whether d is less than, equal to or greater than
+// other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+// Equal reports whether d and other are the same number.
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero reports whether d is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Floor returns the largest multiple of step that is not greater than d. It returns d when
+// step is not positive.
+func (d Decimal) Floor(step Decimal) Decimal {
+	return d.toStep(step, func(quo, mod, _ *big.Int) {})
+}
+
+// Ceil returns the smallest multiple of step that is not less than d. It returns d when
+// step is not positive.
+func (d Decimal) Ceil(step Decimal) Decimal {
+	return d.toStep(step, func(quo, mod, _ *big.Int) {
+		if mod.Sign() != 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	})
+}
+
+// Round returns the multiple of step nearest to d, rounding halves up. It returns d when
+// step is not positive.
+func (d Decimal) Round(step Decimal) Decimal {
+	return d.toStep(step, func(quo, mod, step *big.Int) {
+		if new(big.Int).Lsh(mod, 1).Cmp(step) >= 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	})
+}
+
+// IsMultipleOf reports whether d is a multiple of step. Every number is a multiple of a
+// step that is not positive.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	return d.Floor(step).Equal(d)
+}
+
+// toStep divides d by step, lets adjust change the floored quotient given the non-negative
+// remainder, and returns the quotient times step.
+func (d Decimal) toStep(step Decimal, adjust func(quo, mod, step *big.Int)) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	a, b, scale := align(d, step)
+	quo, mod := new(big.Int).DivMod(a, b, new(big.Int))
+	adjust(quo, mod, b)
+	return Decimal{unscaled: quo.Mul(quo, b), scale: scale}.normalize()
+}
+
+// Float64 returns the float64 nearest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats d without an exponent, such as "0.0125".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits + strings.Repeat("0", int(-d.scale))
+	}
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// UnmarshalJSON decodes a quoted or bare number. An empty string decodes as 0.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(bytes.Trim(data, `"`))
+	if s == "" || s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON encodes d as a string, the way Bybit does.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// normalize strips trailing fractional zeros so that equal numbers have equal scales.
+func (d Decimal) normalize() Decimal {
+	if d.unscaled == nil || d.unscaled.Sign() == 0 {
+		return Decimal{}
+	}
+	ten := big.NewInt(10)
+	quo, rem := new(big.Int), new(big.Int)
+	for d.scale > 0 {
+		quo.QuoRem(d.unscaled, ten, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		d.unscaled, quo = quo, new(big.Int)
+		d.scale--
+	}
+	return d
+}
+
+// align returns the unscaled values of a and b at their common scale.
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	switch {
+	case a.scale == b.scale:
+		return a.int(), b.int(), a.scale
+	case a.scale > b.scale:
+		return a.int(), new(big.Int).Mul(b.int(), pow10(int64(a.scale-b.scale))), a.scale
+	default:
+		return new(big.Int).Mul(a.int(), pow10(int64(b.scale-a.scale))), b.int(), b.scale
+	}
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
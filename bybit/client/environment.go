@@ -0,0 +1,112 @@
+package client
+
+import "strings"
+
+// DemoBaseURL is the REST endpoint of demo trading, which serves real market data and trades
+// simulated funds.
+const DemoBaseURL = "https://api-demo.bybit.com"
+
+// Domains of the regional Bybit sites, for WithBaseDomain. Users of a regulated region must
+// use its domain; DomainBytick is an alternative to DomainBybit.
+const (
+	DomainBybit  = "bybit.com"
+	DomainBytick = "bytick.com"
+	DomainNL     = "bybit.nl"
+	DomainTR     = "bybit-tr.com"
+	DomainHK     = "bybit.com.hk"
+)
+
+// Parts of the REST endpoints, which are <scheme><prefix>.<domain>.
+const (
+	defaultDomain  = DomainBybit
+	endpointScheme = "https://"
+	mainnetPrefix  = "api"
+	testnetPrefix  = "api-testnet"
+	demoPrefix     = "api-demo"
+)
+
+// Environment selects the Bybit deployment a client talks to.
+type Environment int
+
+const (
+	Mainnet Environment = iota
+	Testnet
+	// Demo is demo trading on the mainnet site, with API keys created in demo mode.
+	Demo
+)
+
+// BaseURL returns the REST endpoint of e on bybit.com.
+func (e Environment) BaseURL() string {
+	return e.BaseURLFor(defaultDomain)
+}
+
+// BaseURLFor returns the REST endpoint of e on domain, such as DomainNL.
+func (e Environment) BaseURLFor(domain string) string {
+	switch e {
+	case Testnet:
+		return endpointScheme + testnetPrefix + "." + domain
+	case Demo:
+		return endpointScheme + demoPrefix + "." + domain
+	default:
+		return endpointScheme + mainnetPrefix + "." + domain
+	}
+}
+
+func (e Environment) String() string {
+	switch e {
+	case Testnet:
+		return "testnet"
+	case Demo:
+		return "demo"
+	default:
+		return "mainnet"
+	}
+}
+
+// WithEnvironment selects the deployment requests are sent to, overriding the testnet
+// argument of NewClient.
+func WithEnvironment(env Environment) Option {
+	return func(c *Client) {
+		c.environment = env
+		c.IsTestNet = env == Testnet
+	}
+}
+
+// WithBaseDomain sends requests to the regional site on domain, such as DomainTR, instead
+// of bybit.com.
+func WithBaseDomain(domain string) Option {
+	return func(c *Client) {
+		c.domain = domain
+	}
+}
+
+// Environment returns the deployment requests are sent to. IsTestNet is honored for
+// clients that set it after construction.
+func (c *Client) Environment() Environment {
+	if c.environment != Demo && c.IsTestNet {
+		return Testnet
+	}
+	if c.environment == Testnet && !c.IsTestNet {
+		return Mainnet
+	}
+	return c.environment
+}
+
+// WithBaseURL sends requests to baseURL, such as the URL of an httptest.Server, overriding
+// the environment and domain.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURLOverride = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// baseURL returns the endpoint of the client's deployment and domain.
+func (c *Client) baseURL() string {
+	if c.baseURLOverride != "" {
+		return c.baseURLOverride
+	}
+	if c.domain == "" {
+		return c.Environment().BaseURL()
+	}
+	return c.Environment().BaseURLFor(c.domain)
+}
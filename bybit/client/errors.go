@@ -0,0 +1,112 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Bybit return codes checked by the APIError predicates.
+const (
+	RetCodeInvalidAPIKey    = 10003
+	RetCodeInvalidSignature = 10004
+	RetCodePermissionDenied = 10005
+	RetCodeRateLimited      = 10006
+	RetCodeIPNotAllowed     = 10010
+	RetCodeIPRateLimited    = 10018
+	RetCodeOrderNotFound    = 110001
+)
+
+// orderNotFoundCodes are the return codes Bybit uses for an order that does not exist or
+// is already filled or canceled: 110001 for contracts and options, 170213 for classic spot.
+var orderNotFoundCodes = map[int]bool{
+	RetCodeOrderNotFound: true,
+	170213:               true,
+}
+
+// insufficientBalanceCodes are the return codes Bybit uses for insufficient wallet or
+// available balance across products.
+var insufficientBalanceCodes = map[int]bool{
+	110004: true,
+	110007: true,
+	110012: true,
+	110045: true,
+	170131: true,
+}
+
+// APIError is returned when Bybit answers a request with a non-zero retCode or an HTTP
+// error status.
+type APIError struct {
+	RetCode    int
+	RetMsg     string
+	Method     Method
+	Endpoint   string
+	StatusCode int
+	// TraceID identifies the request to Bybit support.
+	TraceID string
+	// Body is the raw response body.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	if e.RetCode == 0 {
+		return fmt.Sprintf("%s %s failed with HTTP status %d", e.Method, e.Endpoint, e.StatusCode)
+	}
+	return fmt.Sprintf("%s %s failed with retCode %d: %s", e.Method, e.Endpoint, e.RetCode, e.RetMsg)
+}
+
+// IsRateLimited reports whether the request exceeded a rate limit.
+func (e *APIError) IsRateLimited() bool {
+	return e.RetCode == RetCodeRateLimited || e.RetCode == RetCodeIPRateLimited ||
+		e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsInvalidAPIKey reports whether the API key is invalid or expired.
+func (e *APIError) IsInvalidAPIKey() bool {
+	return e.RetCode == RetCodeInvalidAPIKey
+}
+
+// IsInvalidSignature reports whether the request signature was rejected, usually because
+// of a wrong secret or a clock skew beyond the receive window.
+func (e *APIError) IsInvalidSignature() bool {
+	return e.RetCode == RetCodeInvalidSignature
+}
+
+// IsPermissionDenied reports whether the API key lacks the permission or is not allowed
+// from this IP address.
+func (e *APIError) IsPermissionDenied() bool {
+	return e.RetCode == RetCodePermissionDenied || e.RetCode == RetCodeIPNotAllowed
+}
+
+// IsInsufficientBalance reports whether the account balance does not cover the request.
+func (e *APIError) IsInsufficientBalance() bool {
+	return insufficientBalanceCodes[e.RetCode]
+}
+
+// IsOrderNotFound reports whether the order does not exist or can no longer be modified.
+func (e *APIError) IsOrderNotFound() bool {
+	return orderNotFoundCodes[e.RetCode]
+}
+
+// checkResponse returns an *APIError when res carries a non-zero retCode or an HTTP error
+// status.
+func checkResponse(req *Request, res Response) error {
+	var body struct {
+		RetCode int    `json:"retCode"`
+		RetMsg  string `json:"retMsg"`
+	}
+	// A body that is not JSON leaves RetCode zero; decoding errors are left to the caller.
+	_ = json.Unmarshal(res.Data(), &body)
+	if body.RetCode == 0 && res.StatusCode() < http.StatusBadRequest {
+		return nil
+	}
+	return &APIError{
+		RetCode:    body.RetCode,
+		RetMsg:     body.RetMsg,
+		Method:     req.method,
+		Endpoint:   req.path,
+		StatusCode: res.StatusCode(),
+		TraceID:    res.Metadata().TraceID,
+		Body:       res.Data(),
+	}
+}
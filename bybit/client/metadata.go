@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// traceIDHeader carries the id Bybit support asks for when investigating a request.
+const traceIDHeader = "Traceid"
+
+// RateLimit is the rate limit state of an endpoint reported by the X-Bapi-Limit headers.
+// It is zero when the endpoint reports no limit.
+type RateLimit struct {
+	// Limit is the number of requests allowed per second.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is when the window resets.
+	Reset time.Time
+}
+
+// Metadata describes the HTTP exchange behind a response.
+type Metadata struct {
+	StatusCode int
+	TraceID    string
+	RateLimit  RateLimit
+	// Elapsed is the time from sending the request to receiving the response headers.
+	Elapsed time.Duration
+	Header  http.Header
+}
+
+// newMetadata extracts the metadata of resp.
+func newMetadata(resp *http.Response, elapsed time.Duration) Metadata {
+	meta := Metadata{
+		StatusCode: resp.StatusCode,
+		TraceID:    resp.Header.Get(traceIDHeader),
+		Elapsed:    elapsed,
+		Header:     resp.Header,
+	}
+	meta.RateLimit.Limit, _ = strconv.Atoi(resp.Header.Get(limitHeader))
+	meta.RateLimit.Remaining, _ = strconv.Atoi(resp.Header.Get(limitStatusHeader))
+	if reset, err := strconv.ParseInt(resp.Header.Get(limitResetHeader), 10, 64); err == nil {
+		meta.RateLimit.Reset = time.UnixMilli(reset)
+	}
+	return meta
+}
+
+type metadataKey struct{}
+
+// WithMetadata returns a context that captures the metadata of the request it is passed
+// to, so that it is available next to the typed result of any endpoint method:
+//
+//	ctx, meta := client.WithMetadata(ctx)
+//	res, err := m.ServerTime(ctx, &client.Params{})
+//	log.Printf("trace id %s took %s", meta.TraceID, meta.Elapsed)
+//
+// The metadata is filled for rejected requests too. A context reused across requests
+// holds the metadata of the last one.
+func WithMetadata(ctx context.Context) (context.Context, *Metadata) {
+	meta := &Metadata{}
+	return context.WithValue(ctx, metadataKey{}, meta), meta
+}
+
+// captureMetadata stores meta in the Metadata of ctx, if any.
+func captureMetadata(ctx context.Context, meta Metadata) {
+	if target, ok := ctx.Value(metadataKey{}).(*Metadata); ok {
+		*target = meta
+	}
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Int is an integer, typically a millisecond timestamp, that Bybit encodes as a JSON
+// string. An empty string decodes as zero and bare JSON numbers are accepted too.
+type Int int64
+
+// UnmarshalJSON decodes a quoted or bare integer.
+func (i *Int) UnmarshalJSON(data []byte) error {
+	s := string(bytes.Trim(data, `"`))
+	if s == "" || s == "null" {
+		*i = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+	*i = Int(v)
+	return nil
+}
+
+// MarshalJSON encodes i as a string, the way Bybit does.
+func (i Int) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(i.String())), nil
+}
+
+// Int64 returns i as an int64.
+func (i Int) Int64() int64 {
+	return int64(i)
+}
+
+// String formats i in base 10.
+func (i Int) String() string {
+	return strconv.FormatInt(int64(i), 10)
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNumbers(t *testing.T) {
+	var v struct {
+		Rate    Decimal `json:"rate"`
+		Empty   Decimal `json:"empty"`
+		Bare    Decimal `json:"bare"`
+		Updated Int     `json:"updated"`
+	}
+	if err := json.Unmarshal([]byte(`{"rate":"0.01250","empty":"","bare":2.5,"updated":"1700000000000"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Rate.String() != "0.0125" || !v.Empty.IsZero() || v.Bare.Float64() != 2.5 || v.Updated != 1700000000000 {
+		t.Errorf("unexpected values %+v", v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"rate":"0.0125","empty":"0","bare":"2.5","updated":"1700000000000"}`; string(data) != want {
+		t.Errorf("expected %s, got %s", want, data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"rate":"abc"}`), &v); err == nil {
+		t.Error("expected an error for an invalid decimal")
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"0.1", "0.1"},
+		{"-12.500", "-12.5"},
+		{"+3", "3"},
+		{"1e-8", "0.00000001"},
+		{"1.5E3", "1500"},
+		{"-0.000", "0"},
+		{".5", "0.5"},
+		{"1e4096", "1" + strings.Repeat("0", 4096)},
+		{"1e-4096", "0." + strings.Repeat("0", 4095) + "1"},
+	} {
+		d, err := ParseDecimal(tc.in)
+		if err != nil || d.String() != tc.want {
+			t.Errorf("ParseDecimal(%q) = %v, %v; want %s", tc.in, d, err, tc.want)
+		}
+	}
+	for _, in := range []string{"", ".", "-", "1.2.3", "1-2", "1e", "abc", "1e999999", "1e-999999", "0.1e-2147483647", "1e99999999999"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("expected ParseDecimal(%q) to fail", in)
+		}
+	}
+
+	// 0.1 + 0.2 is exact, unlike with float64.
+	sum := MustParseDecimal("0.1").Add(MustParseDecimal("0.2"))
+	if !sum.Equal(MustParseDecimal("0.3")) || sum.String() != "0.3" {
+		t.Errorf("expected 0.3, got %s", sum)
+	}
+	if got := MustParseDecimal("1.5").Sub(MustParseDecimal("2.25")); got.String() != "-0.75" {
+		t.Errorf("expected -0.75, got %s", got)
+	}
+	if got := MustParseDecimal("0.0125").Mul(NewDecimal(400, 0)); got.String() != "5" || got.Cmp(NewDecimal(5, 0)) != 0 {
+		t.Errorf("expected 5, got %s", got)
+	}
+	var zero Decimal
+	if !zero.IsZero() || zero.Add(NewDecimal(1, 0)).String() != "1" || zero.Neg().Sign() != 0 {
+		t.Error("expected the zero value to be usable as 0")
+	}
+}
+
+func TestDecimalStep(t *testing.T) {
+	tick := MustParseDecimal("0.5")
+	for _, tc := range []struct {
+		in, floor, ceil, round string
+	}{
+		{"10.74", "10.5", "11", "10.5"},
+		{"10.75", "10.5", "11", "11"},
+		{"10.5", "10.5", "10.5", "10.5"},
+		{"-10.74", "-11", "-10.5", "-10.5"},
+		{"-10.75", "-11", "-10.5", "-10.5"},
+		{"0.1", "0", "0.5", "0"},
+	} {
+		d := MustParseDecimal(tc.in)
+		if got := d.Floor(tick).String(); got != tc.floor {
+			t.Errorf("%s.Floor(0.5) = %s, want %s", tc.in, got, tc.floor)
+		}
+		if got := d.Ceil(tick).String(); got != tc.ceil {
+			t.Errorf("%s.Ceil(0.5) = %s, want %s", tc.in, got, tc.ceil)
+		}
+		if got := d.Round(tick).String(); got != tc.round {
+			t.Errorf("%s.Round(0.5) = %s, want %s", tc.in, got, tc.round)
+		}
+	}
+	if got := MustParseDecimal("0.123456789").Floor(MustParseDecimal("0.001")).String(); got != "0.123" {
+		t.Errorf("expected 0.123, got %s", got)
+	}
+	if !MustParseDecimal("16638.2").IsMultipleOf(MustParseDecimal("0.1")) || MustParseDecimal("16638.25").IsMultipleOf(MustParseDecimal("0.1")) {
+		t.Error("expected 16638.2 only to be a multiple of 0.1")
+	}
+	if got := MustParseDecimal("1.234").Round(Decimal{}); got.String() != "1.234" {
+		t.Errorf("expected a zero step to keep the value, got %s", got)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RequestInfo describes a completed request.
+type RequestInfo struct {
+	Method Method
+	// Path is the endpoint, such as /v5/order/create, without the query string.
+	Path string
+	// StatusCode is zero when no response was received.
+	StatusCode int
+	// RetCode is the Bybit return code, zero on success and for requests that received no
+	// response.
+	RetCode int
+	Elapsed time.Duration
+	Err     error
+}
+
+// RequestObserver is notified of every request the client sends, for example to export
+// metrics. ObserveRequest is called on the goroutine of the request and should return
+// quickly.
+type RequestObserver interface {
+	ObserveRequest(info RequestInfo)
+}
+
+// WithRequestObserver notifies observer of every request, see the metrics package.
+func WithRequestObserver(observer RequestObserver) Option {
+	return func(c *Client) {
+		c.observer = observer
+	}
+}
+
+// RequestTracer starts a span around every call, including its rate limiter wait and
+// retries, for example an OpenTelemetry span, see the tracing package. The returned
+// context is used for the call, and end is called with the outcome of its last attempt.
+type RequestTracer interface {
+	StartRequest(ctx context.Context, method Method, path string, params Params) (_ context.Context, end func(RequestInfo))
+}
+
+// WithRequestTracer traces every call with tracer.
+func WithRequestTracer(tracer RequestTracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// observeRequest reports a completed request to the observer, if one is set.
+func (c *Client) observeRequest(req *Request, res Response, elapsed time.Duration, err error) {
+	if c.observer == nil {
+		return
+	}
+	c.observer.ObserveRequest(newRequestInfo(req, res, elapsed, err))
+}
+
+// newRequestInfo describes the outcome of req.
+func newRequestInfo(req *Request, res Response, elapsed time.Duration, err error) RequestInfo {
+	info := RequestInfo{Method: req.method, Path: req.path, Elapsed: elapsed, Err: err}
+	if res != nil {
+		info.StatusCode = res.StatusCode()
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		info.StatusCode = apiErr.StatusCode
+		info.RetCode = apiErr.RetCode
+	}
+	return info
+}
@@ -0,0 +1,148 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Option configures a Client created with NewClient, NewClientWithSigner or
+// NewClientWithOptions.
+type Option func(*Client)
+
+// WithCredentials signs requests with the API key and its HMAC secret.
+func WithCredentials(key, secretKey string) Option {
+	return WithSigner(key, NewHMACSigner(secretKey))
+}
+
+// WithSigner signs requests for the API key with signer, such as an RSASigner for RSA API
+// keys.
+func WithSigner(key string, signer Signer) Option {
+	return func(c *Client) {
+		c.key = key
+		c.signer = signer
+	}
+}
+
+// WithRecvWindow sets how long after its timestamp Bybit accepts a signed request. It
+// defaults to 5 seconds; a larger window tolerates more clock skew and network latency.
+func WithRecvWindow(window time.Duration) Option {
+	return func(c *Client) {
+		if window > 0 {
+			c.recvWindow = strconv.FormatInt(window.Milliseconds(), 10)
+		}
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter instead of its own limiters, for example
+// to share the request budget between several clients of the same account.
+func WithRateLimiter(limiter *EndpointRateLimiter) Option {
+	return func(c *Client) {
+		if limiter != nil {
+			c.endpointLimiter = limiter
+		}
+	}
+}
+
+// WithHTTPClient makes the client send its requests with httpClient, for example one with
+// a tuned connection pool or an instrumented transport. Options that change the transport
+// must follow this option; they apply to a copy of httpClient, which is left unchanged for
+// its other users, such as http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithTransport sets the RoundTripper of the client's HTTP client.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.setTransport(transport)
+	}
+}
+
+// setTransport replaces the transport on a copy of the HTTP client, which may be shared.
+func (c *Client) setTransport(transport http.RoundTripper) {
+	httpClient := *c.httpClient
+	httpClient.Transport = transport
+	c.httpClient = &httpClient
+}
+
+// WithTimeout bounds every call, including the rate limiter wait and reading the response
+// body. WithCallTimeout overrides it for single calls, in either direction.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+type callTimeoutKey struct{}
+
+// WithCallTimeout returns a context that bounds the call it is passed to by timeout instead
+// of the client's WithTimeout, for example a tight timeout for placing an order or a loose
+// one for downloading history:
+//
+//	res, err := t.PlaceOrder(client.WithCallTimeout(ctx, 2*time.Second), req)
+//
+// Unlike context.WithTimeout, the clock starts when the call starts, and a timeout longer
+// than the client's applies. A non-positive timeout removes the client's timeout.
+func WithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, callTimeoutKey{}, timeout)
+}
+
+// callContext applies the call or client timeout to ctx.
+func (c *Client) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.timeout
+	if override, ok := ctx.Value(callTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// WithProxy routes requests through the HTTP or SOCKS5 proxy at proxyURL. It clones the
+// client's *http.Transport, or http.DefaultTransport when none is set, so that other
+// clients are unaffected. Custom RoundTrippers are left as they are.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Client) {
+		var transport *http.Transport
+		switch t := c.httpClient.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = t.Clone()
+		default:
+			return
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+		c.setTransport(transport)
+	}
+}
+
+// WithCompression controls whether responses are requested gzip compressed, which cuts the
+// transfer size of large responses such as instruments info and kline history. It is
+// enabled by default.
+func WithCompression(enabled bool) Option {
+	return func(c *Client) {
+		c.disableCompression = !enabled
+	}
+}
+
+// WithBrokerID sends the broker id in the Referer header of every request, so that Bybit
+// attributes the commission of the orders to the broker.
+func WithBrokerID(id string) Option {
+	return func(c *Client) {
+		c.brokerID = id
+	}
+}
+
+// HTTPClient returns the HTTP client requests are sent with.
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
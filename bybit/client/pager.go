@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrIteratorDone is returned by Iterator.Next when every item has been returned.
+var ErrIteratorDone = errors.New("no more items in iterator")
+
+// Page is one page of a cursor paginated list.
+type Page[T any] struct {
+	Items []T
+	// NextCursor is the nextPageCursor of the response; empty on the last page.
+	NextCursor string
+}
+
+// PageFetcher fetches the page starting at cursor, which is empty for the first page.
+type PageFetcher[T any] func(ctx context.Context, cursor string) (Page[T], error)
+
+// Iterator walks a cursor paginated list item by item, fetching the next page when the
+// current one is exhausted. Every page goes through the client's endpoint rate limiter,
+// so a long walk slows down instead of being rejected. An Iterator is not safe for
+// concurrent use.
+type Iterator[T any] struct {
+	fetch  PageFetcher[T]
+	items  []T
+	cursor string
+	seen   map[string]bool
+	done   bool
+	err    error
+}
+
+// NewIterator creates an Iterator over the pages returned by fetch.
+func NewIterator[T any](fetch PageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, seen: make(map[string]bool)}
+}
+
+// Next returns the next item. It returns ErrIteratorDone after the last item and the
+// fetch error when a page cannot be fetched; a failed fetch is retried by the next call.
+// A cursor returned twice ends the walk with an error after the items of its page.
+func (it *Iterator[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+	for len(it.items) == 0 {
+		if it.done {
+			if it.err != nil {
+				return zero, it.err
+			}
+			return zero, ErrIteratorDone
+		}
+		page, err := it.fetch(ctx, it.cursor)
+		if err != nil {
+			return zero, err
+		}
+		it.items = page.Items
+		if page.NextCursor == "" {
+			it.done = true
+		} else if it.seen[page.NextCursor] {
+			// Stop instead of looping forever on a server returning the same cursor.
+			it.done = true
+			it.err = fmt.Errorf("cursor %q was returned twice", page.NextCursor)
+		}
+		it.seen[page.NextCursor] = true
+		it.cursor = page.NextCursor
+	}
+	item := it.items[0]
+	it.items = it.items[1:]
+	return item, nil
+}
+
+// All returns the remaining items of every page.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for {
+		item, err := it.Next(ctx)
+		if errors.Is(err, ErrIteratorDone) {
+			return all, nil
+		}
+		if err != nil {
+			return all, err
+		}
+		all = append(all, item)
+	}
+}
+
+// Cursor returns the cursor of the next page to fetch, so that a walk can be resumed later
+// from a request's cursor parameter.
+func (it *Iterator[T]) Cursor() string {
+	return it.cursor
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	pages := map[string]Page[int]{
+		"":   {Items: []int{1, 2}, NextCursor: "c1"},
+		"c1": {Items: nil, NextCursor: "c2"},
+		"c2": {Items: []int{3}},
+	}
+	var cursors []string
+	it := NewIterator(func(_ context.Context, cursor string) (Page[int], error) {
+		cursors = append(cursors, cursor)
+		return pages[cursor], nil
+	})
+
+	all, err := it.All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(all, []int{1, 2, 3}) || !reflect.DeepEqual(cursors, []string{"", "c1", "c2"}) {
+		t.Errorf("unexpected items %v fetched with cursors %v", all, cursors)
+	}
+	if _, err := it.Next(context.Background()); !errors.Is(err, ErrIteratorDone) {
+		t.Errorf("expected ErrIteratorDone, got %v", err)
+	}
+}
+
+func TestIteratorErrors(t *testing.T) {
+	fail := true
+	it := NewIterator(func(_ context.Context, cursor string) (Page[int], error) {
+		if fail {
+			fail = false
+			return Page[int]{}, errors.New("rate limited")
+		}
+		return Page[int]{Items: []int{1}, NextCursor: "loop"}, nil
+	})
+
+	if _, err := it.Next(context.Background()); err == nil {
+		t.Fatal("expected the fetch error")
+	}
+	if item, err := it.Next(context.Background()); err != nil || item != 1 {
+		t.Fatalf("expected the failed page to be retried, got %v, %v", item, err)
+	}
+	if item, err := it.Next(context.Background()); err != nil || item != 1 {
+		t.Fatalf("expected the second page, got %v, %v", item, err)
+	}
+	if _, err := it.Next(context.Background()); err == nil || errors.Is(err, ErrIteratorDone) {
+		t.Errorf("expected a repeated cursor error, got %v", err)
+	}
+}
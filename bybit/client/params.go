@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query returns the canonical query string of p, which is both sent and signed for GET
+// requests. Keys are sorted, nil values and nil pointers are left out, pointers are
+// dereferenced, slices are joined with commas and times are given in Unix milliseconds.
+func (p Params) Query() (string, error) {
+	values := make(url.Values, len(p))
+	for key, value := range p {
+		value, ok := indirect(value)
+		if !ok {
+			continue
+		}
+		formatted, err := formatParam(value)
+		if err != nil {
+			return "", fmt.Errorf("parameter %s: %w", key, err)
+		}
+		values.Set(key, formatted)
+	}
+	// Encode sorts by key
+	return values.Encode(), nil
+}
+
+// Body returns the canonical JSON body of p, which is both sent and signed for POST
+// requests. Keys are sorted, nil values and nil pointers are left out and HTML characters
+// are not escaped.
+func (p Params) Body() ([]byte, error) {
+	params := make(map[string]any, len(p))
+	for key, value := range p {
+		if value, ok := indirect(value); ok {
+			params[key] = value
+		}
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(params); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// indirect dereferences pointers, reporting false for nil values.
+func indirect(value any) (any, bool) {
+	if value == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	return v.Interface(), true
+}
+
+// formatParam formats a query parameter value.
+func formatParam(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case time.Time:
+		return strconv.FormatInt(v.UnixMilli(), 10), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, ok := indirect(v.Index(i).Interface())
+			if !ok {
+				continue
+			}
+			formatted, err := formatParam(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formatted)
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", fmt.Errorf("unsupported type %T", value)
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParamsQuery(t *testing.T) {
+	symbol := "BTCUSDT"
+	limit := 50
+	var cursor *string
+	tests := []struct {
+		name   string
+		params Params
+		want   string
+	}{
+		{"empty", Params{}, ""},
+		{"sorted", Params{"symbol": "BTCUSDT", "category": "linear", "limit": 20}, "category=linear&limit=20&symbol=BTCUSDT"},
+		{"empty value", Params{"orderLinkId": "", "category": "spot"}, "category=spot&orderLinkId="},
+		{"nil values", Params{"cursor": cursor, "baseCoin": nil, "category": "option"}, "category=option"},
+		{"pointers", Params{"symbol": &symbol, "limit": &limit}, "limit=50&symbol=BTCUSDT"},
+		{"special characters", Params{"cursor": "page_args=abc%3D&symbol=BTC", "note": "a b+c"}, "cursor=page_args%3Dabc%253D%26symbol%3DBTC&note=a+b%2Bc"},
+		{"arrays", Params{"symbol": []string{"BTCUSDT", "ETHUSDT"}, "ids": []*int{&limit, nil}}, "ids=50&symbol=BTCUSDT%2CETHUSDT"},
+		{"numbers", Params{"qty": 0.00001, "price": MustParseDecimal("65000.50"), "reduceOnly": true, "startTime": time.UnixMilli(1700000000000)}, "price=65000.5&qty=0.00001&reduceOnly=true&startTime=1700000000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				got, err := tt.params.Query()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tt.want {
+					t.Fatalf("expected %q, got %q", tt.want, got)
+				}
+			}
+		})
+	}
+
+	if _, err := (Params{"filter": map[string]string{}}).Query(); err == nil {
+		t.Error("expected an error for an unsupported type")
+	}
+}
+
+func TestParamsBody(t *testing.T) {
+	var orderLinkID *string
+	body, err := Params{
+		"symbol":      "BTCUSDT",
+		"category":    "linear",
+		"orderLinkId": orderLinkID,
+		"note":        "<a&b>",
+		"request":     []Params{{"side": "Buy", "qty": "1"}},
+	}.Body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"category":"linear","note":"<a&b>","request":[{"qty":"1","side":"Buy"}],"symbol":"BTCUSDT"}`
+	if string(body) != want {
+		t.Errorf("expected %s, got %s", want, body)
+	}
+}
+
+func TestConcurrentSigning(t *testing.T) {
+	c := NewClient("key", "secret", false, WithTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		payload := req.URL.RawQuery
+		if req.Method == http.MethodPost {
+			body, _ := io.ReadAll(req.Body)
+			payload = string(body)
+		}
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(req.Header.Get(timestampKey) + "key" + req.Header.Get(recvWindowKey) + payload))
+		body := `{"retCode":0,"retMsg":"OK","result":{},"time":1}`
+		if hex.EncodeToString(mac.Sum(nil)) != req.Header.Get(signatureKey) {
+			body = `{"retCode":10004,"retMsg":"error sign!","result":{},"time":1}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			params := Params{"category": "linear", "symbol": fmt.Sprintf("SYM%dUSDT", i)}
+			var err error
+			if i%2 == 0 {
+				_, err = c.Get(fmt.Sprintf("/v5/test/%d", i), params)
+			} else {
+				_, err = c.Post(fmt.Sprintf("/v5/test/%d", i), params)
+			}
+			if err != nil {
+				t.Errorf("request %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -1,6 +1,12 @@
 package client
 
 import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
 	"golang.org/x/time/rate"
 )
 
@@ -77,28 +83,144 @@ var endpointLimits = map[string]rate.Limit{
 	"POST /v5/spot-cross-margin-trade/switch":       rate.Limit(twentyPerMinute),
 }
 
+// Rate limit headers Bybit sends with every response.
+const (
+	limitHeader          = "X-Bapi-Limit"
+	limitStatusHeader    = "X-Bapi-Limit-Status"
+	limitResetHeader     = "X-Bapi-Limit-Reset-Timestamp"
+	defaultLimiterBursts = 1
+	// endpointLimitBursts is the burst of the buckets built from endpointLimits.
+	endpointLimitBursts = 5
+	// Bybit allows an IP address 600 requests within any 5 seconds across all endpoints.
+	ipLimitRequests = 600
+	ipLimitWindow   = 5 * time.Second
+)
+
+// EndpointRateLimiter holds a token bucket per endpoint. The buckets start from the static
+// endpointLimits and adapt to the X-Bapi-Limit-* headers of the responses: the bucket rate
+// follows X-Bapi-Limit and, once X-Bapi-Limit-Status reports no remaining requests, callers
+// wait until X-Bapi-Limit-Reset-Timestamp.
+//
+// On top of its own bucket, a request waits on the buckets of the groups its endpoint
+// belongs to, see SetGroupLimiter, and on the global bucket, which by default enforces the
+// IP limit of 600 requests per 5 seconds. The limiter is safe for concurrent use, so the
+// account, position, trade and market modules of a client, or several clients sharing it
+// with WithRateLimiter, draw from the same budgets.
 type EndpointRateLimiter struct {
+	mu       sync.Mutex
 	limiters map[string]*rate.Limiter
+	// groups holds, per endpoint, the buckets shared with other endpoints.
+	groups map[string][]*rate.Limiter
+	global *rate.Limiter
+	// blockedUntil holds, per endpoint, the reset time of an exhausted limit.
+	blockedUntil map[string]time.Time
 }
 
+// NewEndpointRateLimiter creates a limiter loaded with the static endpointLimits, so a
+// limiter shared between clients with WithRateLimiter enforces them too.
 func NewEndpointRateLimiter() *EndpointRateLimiter {
+	limiters := make(map[string]*rate.Limiter, len(endpointLimits))
+	for endpoint, limit := range endpointLimits {
+		limiters[endpoint] = rate.NewLimiter(limit, endpointLimitBursts)
+	}
 	return &EndpointRateLimiter{
-		limiters: make(map[string]*rate.Limiter),
+		limiters:     limiters,
+		groups:       make(map[string][]*rate.Limiter),
+		global:       rate.NewLimiter(rate.Limit(ipLimitRequests/ipLimitWindow.Seconds()), ipLimitRequests),
+		blockedUntil: make(map[string]time.Time),
+	}
+}
+
+// SetGroupLimiter makes the endpoints, given as "METHOD /path" such as
+// "POST /v5/order/create", share limiter in addition to their own limiters. An endpoint
+// may belong to several groups.
+func (e *EndpointRateLimiter) SetGroupLimiter(limiter *rate.Limiter, endpointKeys ...string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, key := range endpointKeys {
+		e.groups[key] = append(e.groups[key], limiter)
 	}
 }
 
+// SetGlobalLimiter replaces the bucket every request waits on. A nil limiter removes it.
+func (e *EndpointRateLimiter) SetGlobalLimiter(limiter *rate.Limiter) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.global = limiter
+}
+
 // SetLimiter updates or creates a rate limiter for a specific endpoint
 func (e *EndpointRateLimiter) SetLimiter(endpointKey string, limiter *rate.Limiter) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	e.limiters[endpointKey] = limiter
 }
 
-// GetLimiter retrieves an existing rate limiter for an endpoint, returning nil if not found
+// GetLimiter retrieves the rate limiter of an endpoint. Endpoints without a configured
+// limit, such as the public market endpoints, get an unthrottled bucket: they are only held
+// to the global IP limit until a response reports their own limit.
 func (e *EndpointRateLimiter) GetLimiter(endpointKey string) *rate.Limiter {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	if limiter, ok := e.limiters[endpointKey]; ok {
 		return limiter
 	}
+	limiter := rate.NewLimiter(rate.Inf, defaultLimiterBursts)
+	e.limiters[endpointKey] = limiter
+	return limiter
+}
+
+// Wait blocks until a request to the endpoint is allowed or ctx is done.
+func (e *EndpointRateLimiter) Wait(ctx context.Context, endpointKey string) error {
+	e.mu.Lock()
+	until := e.blockedUntil[endpointKey]
+	shared := append([]*rate.Limiter(nil), e.groups[endpointKey]...)
+	if e.global != nil {
+		shared = append(shared, e.global)
+	}
+	e.mu.Unlock()
+
+	if wait := time.Until(until); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	for _, limiter := range shared {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return e.GetLimiter(endpointKey).Wait(ctx)
+}
+
+// Update adapts the endpoint's limiter to the rate limit headers of a response. Missing or
+// malformed headers are ignored.
+func (e *EndpointRateLimiter) Update(endpointKey string, header http.Header) {
+	limit, err := strconv.Atoi(header.Get(limitHeader))
+	if err != nil || limit <= 0 {
+		return
+	}
+	limiter := e.GetLimiter(endpointKey)
+	if limiter.Limit() != rate.Limit(limit) {
+		limiter.SetLimit(rate.Limit(limit))
+		limiter.SetBurst(limit)
+	}
 
-	// Set default rate limiter to 30 requests per minute
-	defaultRate := rate.Limit(30.0 / 60.0) // 30 requests per minute (30/60 = 0.5 requests per second)
-	return rate.NewLimiter(defaultRate, 1) // Burst size of 1
+	remaining, err := strconv.Atoi(header.Get(limitStatusHeader))
+	if err != nil || remaining > 0 {
+		return
+	}
+	reset, err := strconv.ParseInt(header.Get(limitResetHeader), 10, 64)
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if until := time.UnixMilli(reset); until.After(e.blockedUntil[endpointKey]) {
+		e.blockedUntil[endpointKey] = until
+	}
 }
@@ -1,9 +1,13 @@
 package client
 
 import (
+	"compress/gzip"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type Response interface {
@@ -12,6 +16,7 @@ type Response interface {
 	Status() string
 	StatusCode() int
 	Error() error
+	Metadata() Metadata
 }
 
 type ResponseImpl struct {
@@ -19,17 +24,24 @@ type ResponseImpl struct {
 	err        error
 	statusCode int
 	status     string
+	metadata   Metadata
 }
 
 func NewResponse(response *http.Response) Response {
+	return newResponse(response, 0)
+}
+
+// newResponse reads response and records elapsed as the request latency.
+func newResponse(response *http.Response, elapsed time.Duration) *ResponseImpl {
 	var res ResponseImpl
-	body, err := io.ReadAll(response.Body)
+	body, err := readBody(response)
 	if err != nil {
 		res.err = err
 	}
 	res.statusCode = response.StatusCode
 	res.data = body
 	res.status = response.Status
+	res.metadata = newMetadata(response, elapsed)
 	return &res
 }
 
@@ -55,3 +67,26 @@ func (r *ResponseImpl) Status() string {
 func (r *ResponseImpl) Error() error {
 	return r.err
 }
+
+// Metadata returns the status, trace id, rate limit headers and latency of the response.
+func (r *ResponseImpl) Metadata() Metadata {
+	return r.metadata
+}
+
+// readBody reads the body of response, decompressing it when the server gzipped it in
+// answer to the Accept-Encoding header set by the client.
+func readBody(response *http.Response) ([]byte, error) {
+	if !strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		return io.ReadAll(response.Body)
+	}
+	reader, err := gzip.NewReader(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	defer reader.Close()
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	return body, nil
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// retryPolicy decides which failed requests are sent again. The zero value does not retry.
+type retryPolicy struct {
+	maxRetries int
+	backoff    time.Duration
+}
+
+// WithRetries sends a failed GET request up to maxRetries more times, waiting backoff
+// before the first retry and doubling the wait before each further one. Network errors,
+// HTTP 5xx responses and rate limit errors are retried, IP bans are not. POST requests are never retried,
+// since a request that failed on the way back may still have placed or changed an order.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retry = retryPolicy{maxRetries: maxRetries, backoff: backoff}
+	}
+}
+
+// retryable reports whether req may be sent again after failing with err.
+func (p retryPolicy) retryable(req *Request, err error) bool {
+	if err == nil || req.method != GET {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrIPBanned) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.IsRateLimited() || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// wait sleeps before the retry following attempt, or returns early when ctx is done.
+func (p retryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff << attempt)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// Signer signs the payload of an authenticated request. Bybit accepts HMAC signatures made
+// with the API secret and RSA signatures made with the private key of an RSA API key.
+type Signer interface {
+	Sign(payload string) (string, error)
+}
+
+// HMACSigner signs with HMAC-SHA256 and hex encodes the result. It is the signer of API keys
+// generated by Bybit.
+type HMACSigner struct {
+	secret []byte
+}
+
+// NewHMACSigner creates an HMACSigner for the API secret.
+func NewHMACSigner(secret string) *HMACSigner {
+	return &HMACSigner{secret: []byte(secret)}
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of payload.
+func (s *HMACSigner) Sign(payload string) (string, error) {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// RSASigner signs with RSASSA-PKCS1-v1_5 over SHA256 and base64 encodes the result. It is the
+// signer of self-generated RSA API keys whose public key is registered with Bybit.
+type RSASigner struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSASigner creates an RSASigner for the private key.
+func NewRSASigner(key *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{key: key}
+}
+
+// NewRSASignerFromPEM creates an RSASigner from a PEM encoded PKCS #1 or PKCS #8 private key.
+func NewRSASignerFromPEM(data []byte) (*RSASigner, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found in RSA private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return NewRSASigner(key), nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSA private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is a %T, not an RSA key", parsed)
+	}
+	return NewRSASigner(key), nil
+}
+
+// Sign returns the base64 encoded RSA signature of payload.
+func (s *RSASigner) Sign(payload string) (string, error) {
+	hashed := sha256.Sum256([]byte(payload))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign request: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
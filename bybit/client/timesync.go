@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultTimeSyncInterval is the interval TimeSync uses when none is given.
+const DefaultTimeSyncInterval = 5 * time.Minute
+
+// serverTimePath is the endpoint returning Bybit's clock.
+const serverTimePath = "/" + APIVersion + "/market/time"
+
+// TimeSync keeps the timestamps of signed requests in line with Bybit's clock. It measures
+// the offset between the local and the server clock and applies it to the client, which
+// avoids retCode 10002 rejections on machines whose clock drifts.
+type TimeSync struct {
+	client   *Client
+	interval time.Duration
+	// OnError is called when a periodic synchronization fails. It may be nil.
+	OnError func(err error)
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewTimeSync creates a TimeSync for c that synchronizes every interval, or every
+// DefaultTimeSyncInterval when interval is not positive.
+func NewTimeSync(c *Client, interval time.Duration) *TimeSync {
+	if interval <= 0 {
+		interval = DefaultTimeSyncInterval
+	}
+	return &TimeSync{client: c, interval: interval}
+}
+
+// Sync fetches the server time once, applies the offset to the client and returns it. The
+// round trip is split evenly between request and response.
+func (t *TimeSync) Sync(ctx context.Context) (time.Duration, error) {
+	sent := time.Now()
+	res, err := t.client.GetContext(ctx, serverTimePath, Params{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch server time: %w", err)
+	}
+	received := time.Now()
+
+	var serverTime struct {
+		Result struct {
+			TimeSecond string `json:"timeSecond"`
+			TimeNano   string `json:"timeNano"`
+		} `json:"result"`
+	}
+	if err := res.Unmarshal(&serverTime); err != nil {
+		return 0, fmt.Errorf("failed to decode server time: %w", err)
+	}
+	now, err := ParseServerTime(serverTime.Result.TimeSecond, serverTime.Result.TimeNano)
+	if err != nil {
+		return 0, err
+	}
+
+	local := sent.Add(received.Sub(sent) / 2)
+	offset := now.Sub(local)
+	t.client.SetTimeOffset(offset)
+	return offset, nil
+}
+
+// ParseServerTime returns the time reported by Bybit's server time endpoint. timeNano is
+// used when it is set, timeSecond otherwise.
+func ParseServerTime(timeSecond, timeNano string) (time.Time, error) {
+	if timeNano != "" {
+		nanos, err := strconv.ParseInt(timeNano, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid server time %q: %w", timeNano, err)
+		}
+		return time.Unix(0, nanos), nil
+	}
+	seconds, err := strconv.ParseInt(timeSecond, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid server time %q: %w", timeSecond, err)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// Start synchronizes once and then every interval until ctx is done or Stop is called. It
+// returns the error of the first synchronization; later failures go to OnError.
+func (t *TimeSync) Start(ctx context.Context) error {
+	if _, err := t.Sync(ctx); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cancel != nil {
+		return nil
+	}
+	ctx, t.cancel = context.WithCancel(ctx)
+	t.done = make(chan struct{})
+	go t.run(ctx, t.done)
+	return nil
+}
+
+// Stop ends the periodic synchronization and waits for it to return. The last offset stays
+// applied to the client.
+func (t *TimeSync) Stop() {
+	t.mu.Lock()
+	cancel, done := t.cancel, t.done
+	t.cancel, t.done = nil, nil
+	t.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+func (t *TimeSync) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := t.Sync(ctx); err != nil && ctx.Err() == nil && t.OnError != nil {
+			t.OnError(err)
+		}
+	}
+}
+
+// Clock tells the time. *Client is a Clock that follows Bybit's clock once a TimeSync has
+// run.
+type Clock interface {
+	Now() time.Time
+}
+
+// SleepUntil blocks until clock reaches t, such as the next funding time of a contract, or
+// until ctx is done. It returns immediately when t has passed.
+func SleepUntil(ctx context.Context, clock Clock, t time.Time) error {
+	timer := time.NewTimer(t.Sub(clock.Now()))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,7 @@
+package bybit
+
+import "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+
+// APIError is returned by every REST call that Bybit answers with a non-zero retCode or an
+// HTTP error status. Use errors.As to inspect it.
+type APIError = client.APIError
@@ -0,0 +1,222 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// DefaultInstrumentTTL is the refresh interval NewInstrumentCache uses when none is given.
+const DefaultInstrumentTTL = time.Hour
+
+// ErrUnknownInstrument is returned by InstrumentCache.Get for a symbol Bybit does not list
+// in the category.
+var ErrUnknownInstrument = errors.New("unknown instrument")
+
+// TickSize returns the price increment of the instrument.
+func (i Instrument) TickSize() client.Decimal {
+	return i.PriceFilter.TickSize
+}
+
+// QtyStep returns the quantity increment of the instrument: the base precision of a spot
+// pair, the quantity step of a contract or option.
+func (i Instrument) QtyStep() client.Decimal {
+	if i.LotSizeFilter.QtyStep.IsZero() {
+		return i.LotSizeFilter.BasePrecision
+	}
+	return i.LotSizeFilter.QtyStep
+}
+
+// MinNotional returns the smallest order value of the instrument in the quote coin: the
+// minimum order amount of a spot pair, the minimum notional value of a contract.
+func (i Instrument) MinNotional() client.Decimal {
+	if i.LotSizeFilter.MinNotionalValue.IsZero() {
+		return i.LotSizeFilter.MinOrderAmt
+	}
+	return i.LotSizeFilter.MinNotionalValue
+}
+
+// LeverageBounds returns the minimum and maximum leverage of a contract, zero for spot
+// pairs and options.
+func (i Instrument) LeverageBounds() (minLeverage, maxLeverage client.Decimal) {
+	return i.LeverageFilter.MinLeverage, i.LeverageFilter.MaxLeverage
+}
+
+// instrumentSet is the loaded instruments of a category, or of one base coin of options.
+type instrumentSet struct {
+	loaded  time.Time
+	symbols map[string]Instrument
+}
+
+// setKey identifies an instrumentSet. BaseCoin is only set for options, which Bybit lists
+// per base coin.
+type setKey struct {
+	category string
+	baseCoin string
+}
+
+// loadCall is a load in progress. Concurrent misses of the same key wait for it instead of
+// fetching the instruments again.
+type loadCall struct {
+	done chan struct{}
+	set  *instrumentSet
+	err  error
+}
+
+// InstrumentCache holds the instruments-info of the categories it is asked about, so that
+// order validation can look up tick sizes and lot sizes without a request per order. A
+// category is loaded on first use, following nextPageCursor across pages, and reloaded
+// once it is older than the TTL or when Refresh is called. Options are loaded per base
+// coin, taken from the prefix of the option symbol. It is safe for concurrent use.
+type InstrumentCache struct {
+	m   Market
+	ttl time.Duration
+	now func() time.Time
+
+	mu    sync.Mutex
+	sets  map[setKey]*instrumentSet
+	calls map[setKey]*loadCall
+}
+
+// NewInstrumentCache creates an InstrumentCache that fetches instruments through m and
+// reloads them every ttl, or every DefaultInstrumentTTL when ttl is not positive.
+func NewInstrumentCache(m Market, ttl time.Duration) *InstrumentCache {
+	if ttl <= 0 {
+		ttl = DefaultInstrumentTTL
+	}
+	return &InstrumentCache{
+		m:     m,
+		ttl:   ttl,
+		now:   time.Now,
+		sets:  make(map[setKey]*instrumentSet),
+		calls: make(map[setKey]*loadCall),
+	}
+}
+
+// Get returns the instrument of symbol in category, loading the category when it is not
+// cached or has expired. It returns an error wrapping ErrUnknownInstrument when the
+// category does not list symbol.
+func (c *InstrumentCache) Get(ctx context.Context, category, symbol string) (Instrument, error) {
+	key := setKey{category: category}
+	if category == "option" {
+		baseCoin, ok := optionBaseCoin(symbol)
+		if !ok {
+			return Instrument{}, fmt.Errorf("%w: %s %s", ErrUnknownInstrument, category, symbol)
+		}
+		key.baseCoin = baseCoin
+	}
+	set, err := c.get(ctx, key, false)
+	if err != nil {
+		return Instrument{}, err
+	}
+	instrument, ok := set.symbols[symbol]
+	if !ok {
+		return Instrument{}, fmt.Errorf("%w: %s %s", ErrUnknownInstrument, category, symbol)
+	}
+	return instrument, nil
+}
+
+// Refresh reloads the instruments of category now. For options it reloads every base coin
+// loaded so far.
+func (c *InstrumentCache) Refresh(ctx context.Context, category string) error {
+	keys := []setKey{{category: category}}
+	if category == "option" {
+		keys = c.keys(category)
+	}
+	for _, key := range keys {
+		if _, err := c.get(ctx, key, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Invalidate drops the instruments of category, which are loaded again on the next Get.
+func (c *InstrumentCache) Invalidate(category string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.sets {
+		if key.category == category {
+			delete(c.sets, key)
+		}
+	}
+}
+
+// keys returns the keys of the cached sets of category.
+func (c *InstrumentCache) keys(category string) []setKey {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var keys []setKey
+	for key := range c.sets {
+		if key.category == category {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// get returns the set of key, loading it when it is not cached, has expired or reload is
+// set. A load already in progress for key is waited for rather than repeated.
+func (c *InstrumentCache) get(ctx context.Context, key setKey, reload bool) (*instrumentSet, error) {
+	c.mu.Lock()
+	if set, ok := c.sets[key]; ok && !reload && c.now().Sub(set.loaded) < c.ttl {
+		c.mu.Unlock()
+		return set, nil
+	}
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.set, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &loadCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.set, call.err = c.load(ctx, key)
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	if call.err == nil {
+		c.sets[key] = call.set
+	}
+	c.mu.Unlock()
+	close(call.done)
+	return call.set, call.err
+}
+
+// load fetches every page of the instruments of key.
+func (c *InstrumentCache) load(ctx context.Context, key setKey) (*instrumentSet, error) {
+	req := InstrumentsInfoRequest{Category: key.category, Limit: 1000}
+	name := key.category
+	if key.baseCoin != "" {
+		req.BaseCoin = key.baseCoin
+		name = key.baseCoin + " " + key.category
+	}
+	instruments, err := Instruments(c.m, req).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s instruments: %w", name, err)
+	}
+	set := &instrumentSet{loaded: c.now(), symbols: make(map[string]Instrument, len(instruments))}
+	for _, instrument := range instruments {
+		set.symbols[instrument.Symbol] = instrument
+	}
+	return set, nil
+}
+
+// optionBaseCoin returns the base coin of an option symbol such as "ETH-27DEC24-3000-C",
+// and false for a symbol that is not an option.
+func optionBaseCoin(symbol string) (string, bool) {
+	if strings.Count(symbol, "-") < 3 {
+		return "", false
+	}
+	return symbol[:strings.Index(symbol, "-")], true
+}
@@ -0,0 +1,128 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestInstrumentCache(t *testing.T) {
+	mock := bybittest.NewMock()
+	cache := NewInstrumentCache(New(mock.Client()), time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			btc, err := cache.Get(ctx, "linear", "BTCUSDT")
+			assert.NoError(t, err)
+			assert.True(t, btc.TickSize().Equal(client.MustParseDecimal("0.1")))
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, mock.Requests(), 1)
+
+	btc, _ := cache.Get(ctx, "linear", "BTCUSDT")
+	assert.True(t, btc.QtyStep().Equal(client.MustParseDecimal("0.001")))
+	assert.True(t, btc.MinNotional().Equal(client.MustParseDecimal("5")))
+	minLeverage, maxLeverage := btc.LeverageBounds()
+	assert.True(t, minLeverage.Equal(client.MustParseDecimal("1")))
+	assert.True(t, maxLeverage.Equal(client.MustParseDecimal("100")))
+
+	_, err := cache.Get(ctx, "linear", "XYZUSDT")
+	assert.True(t, errors.Is(err, ErrUnknownInstrument))
+	assert.Len(t, mock.Requests(), 1)
+
+	now = now.Add(time.Minute)
+	_, err = cache.Get(ctx, "linear", "BTCUSDT")
+	assert.NoError(t, err)
+	assert.Len(t, mock.Requests(), 2)
+
+	assert.NoError(t, cache.Refresh(ctx, "linear"))
+	assert.Len(t, mock.Requests(), 3)
+	cache.Invalidate("linear")
+	_, err = cache.Get(ctx, "linear", "BTCUSDT")
+	assert.NoError(t, err)
+	assert.Len(t, mock.Requests(), 4)
+}
+
+func TestInstrumentCache_Pages(t *testing.T) {
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/market/instruments-info", func(req bybittest.Request) string {
+		if req.Query.Get("cursor") == "page2" {
+			return `{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[{"symbol":"ETHUSDT"}],"nextPageCursor":""},"time":1}`
+		}
+		return `{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[{"symbol":"BTCUSDT"}],"nextPageCursor":"page2"},"time":1}`
+	})
+	cache := NewInstrumentCache(New(mock.Client()), time.Minute)
+	ctx := context.Background()
+
+	for _, symbol := range []string{"BTCUSDT", "ETHUSDT"} {
+		_, err := cache.Get(ctx, "linear", symbol)
+		assert.NoError(t, err)
+	}
+	requests := mock.Requests()
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "", requests[0].Query.Get("cursor"))
+		assert.Equal(t, "page2", requests[1].Query.Get("cursor"))
+	}
+}
+
+func TestInstrumentCache_OptionBaseCoins(t *testing.T) {
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/market/instruments-info", func(req bybittest.Request) string {
+		baseCoin := req.Query.Get("baseCoin")
+		if baseCoin == "" {
+			baseCoin = "BTC"
+		}
+		return fmt.Sprintf(`{"retCode":0,"retMsg":"OK","result":{"category":"option","list":[{"symbol":"%s-27DEC24-3000-C"}],"nextPageCursor":""},"time":1}`, baseCoin)
+	})
+	cache := NewInstrumentCache(New(mock.Client()), time.Minute)
+	ctx := context.Background()
+
+	for _, symbol := range []string{"BTC-27DEC24-3000-C", "ETH-27DEC24-3000-C", "SOL-27DEC24-3000-C", "ETH-27DEC24-3000-C"} {
+		instrument, err := cache.Get(ctx, "option", symbol)
+		assert.NoError(t, err)
+		assert.Equal(t, symbol, instrument.Symbol)
+	}
+	_, err := cache.Get(ctx, "option", "BTCUSDT")
+	assert.True(t, errors.Is(err, ErrUnknownInstrument))
+
+	requests := mock.Requests()
+	if assert.Len(t, requests, 3) {
+		for i, baseCoin := range []string{"BTC", "ETH", "SOL"} {
+			assert.Equal(t, baseCoin, requests[i].Query.Get("baseCoin"))
+		}
+	}
+
+	assert.NoError(t, cache.Refresh(ctx, "option"))
+	assert.Len(t, mock.Requests(), 6)
+	cache.Invalidate("option")
+	_, err = cache.Get(ctx, "option", "ETH-27DEC24-3000-C")
+	assert.NoError(t, err)
+	assert.Len(t, mock.Requests(), 7)
+}
+
+func TestInstrument_SpotLookups(t *testing.T) {
+	spot := Instrument{LotSizeFilter: LotSizeFilter{
+		BasePrecision: client.MustParseDecimal("0.000001"),
+		MinOrderAmt:   client.MustParseDecimal("1"),
+	}}
+	assert.True(t, spot.QtyStep().Equal(client.MustParseDecimal("0.000001")))
+	assert.True(t, spot.MinNotional().Equal(client.MustParseDecimal("1")))
+	minLeverage, _ := spot.LeverageBounds()
+	assert.True(t, minLeverage.IsZero())
+}
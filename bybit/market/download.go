@@ -0,0 +1,71 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxKlineLimit is the largest number of candles Bybit returns per kline request.
+const maxKlineLimit = 1000
+
+// CandleSink receives the candles of DownloadKlines. Returning an error stops the download.
+type CandleSink func(Candle) error
+
+// DownloadKlines fetches every candle of symbol in category that starts between from and
+// to, inclusive, and passes them to sink oldest first. The range is split into requests of
+// at most 1000 candles, which wait on the client's rate limiter like any other request, and
+// candles returned twice by overlapping windows are passed once.
+func DownloadKlines(ctx context.Context, m Market, category, symbol string, interval Interval, from, to time.Time, sink CandleSink) error {
+	if err := interval.Validate(); err != nil {
+		return err
+	}
+	if to.Before(from) {
+		return fmt.Errorf("download klines: to %v is before from %v", to, from)
+	}
+	window := time.Duration(maxKlineLimit-1) * interval.Duration()
+	var last time.Time
+	for start := from; !start.After(to); {
+		end := start.Add(window)
+		if end.After(to) {
+			end = to
+		}
+		candles, err := m.GetKline(ctx, category, symbol, interval, start, end, maxKlineLimit)
+		if err != nil {
+			return fmt.Errorf("download klines from %v: %w", start, err)
+		}
+		for i := len(candles) - 1; i >= 0; i-- {
+			candle := candles[i]
+			if candle.Start.Before(from) || candle.Start.After(to) || (!last.IsZero() && !candle.Start.After(last)) {
+				continue
+			}
+			if err := sink(candle); err != nil {
+				return err
+			}
+			last = candle.Start
+		}
+		start = end.Add(time.Millisecond)
+	}
+	return nil
+}
+
+// StreamKlines runs DownloadKlines in a goroutine and sends the candles on the returned
+// channel, which is closed when the download ends. The error channel then receives the
+// result of the download, nil on success.
+func StreamKlines(ctx context.Context, m Market, category, symbol string, interval Interval, from, to time.Time) (<-chan Candle, <-chan error) {
+	candles := make(chan Candle, maxKlineLimit)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(candles)
+		errc <- DownloadKlines(ctx, m, category, symbol, interval, from, to, func(candle Candle) error {
+			select {
+			case candles <- candle:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return candles, errc
+}
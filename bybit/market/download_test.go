@@ -0,0 +1,75 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+)
+
+// klineMock serves one-minute candles between the start and end of each request, newest
+// first, plus the candle before start to simulate overlapping windows.
+func klineMock(t *testing.T) *bybittest.Mock {
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/market/kline", func(req bybittest.Request) string {
+		start, _ := strconv.ParseInt(req.Query.Get("start"), 10, 64)
+		end, _ := strconv.ParseInt(req.Query.Get("end"), 10, 64)
+		assert.Equal(t, "1000", req.Query.Get("limit"))
+		var rows []string
+		minute := time.Minute.Milliseconds()
+		for ts := end - end%minute; ts >= start-minute; ts -= minute {
+			rows = append(rows, fmt.Sprintf(`["%d","1","2","0.5","1.5","10","15"]`, ts))
+		}
+		return fmt.Sprintf(`{"retCode":0,"retMsg":"OK","result":{"symbol":"BTCUSDT","category":"linear","list":[%s]},"time":1}`, strings.Join(rows, ","))
+	})
+	return mock
+}
+
+func TestDownloadKlines(t *testing.T) {
+	mock := klineMock(t)
+	m := New(mock.Client())
+	from := time.UnixMilli(1_700_000_040_000)
+	to := from.Add(2500 * time.Minute)
+
+	var candles []Candle
+	err := DownloadKlines(context.Background(), m, "linear", "BTCUSDT", Interval1m, from, to, func(candle Candle) error {
+		candles = append(candles, candle)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, mock.Requests(), 3)
+	if assert.Equal(t, 2501, len(candles)) { // assert.Len would print every candle
+		for i, candle := range candles {
+			assert.True(t, candle.Start.Equal(from.Add(time.Duration(i)*time.Minute)), "candle %d starts at %v", i, candle.Start)
+		}
+	}
+
+	assert.Error(t, DownloadKlines(context.Background(), m, "linear", "BTCUSDT", Interval1m, to, from, nil))
+	assert.Error(t, DownloadKlines(context.Background(), m, "linear", "BTCUSDT", "2", from, to, nil))
+}
+
+func TestStreamKlines(t *testing.T) {
+	from := time.UnixMilli(1_700_000_040_000)
+	candles, errc := StreamKlines(context.Background(), New(klineMock(t).Client()), "linear", "BTCUSDT", Interval1m, from, from.Add(1500*time.Minute))
+	var count int
+	for range candles {
+		count++
+	}
+	assert.NoError(t, <-errc)
+	assert.Equal(t, 1501, count)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	candles, errc = StreamKlines(ctx, New(klineMock(t).Client()), "linear", "BTCUSDT", Interval1m, from, from.Add(5000*time.Minute))
+	<-candles
+	cancel()
+	for range candles {
+	}
+	assert.ErrorIs(t, <-errc, context.Canceled)
+}
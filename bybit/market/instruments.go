@@ -0,0 +1,149 @@
+package market
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// InstrumentsInfoRequest selects the instruments returned by GetInstrumentsInfo. Category
+// is required; the other fields are optional filters.
+type InstrumentsInfoRequest struct {
+	Category string
+	Symbol   string
+	// BaseCoin filters linear, inverse and option instruments by base coin.
+	BaseCoin string
+	// Status is one of PreLaunch, Trading, Delivering and Closed.
+	Status string
+	// Limit is the page size, at most 1000.
+	Limit  int
+	Cursor string
+}
+
+// LeverageFilter holds the leverage limits of a contract.
+type LeverageFilter struct {
+	MinLeverage  client.Decimal `json:"minLeverage"`
+	MaxLeverage  client.Decimal `json:"maxLeverage"`
+	LeverageStep client.Decimal `json:"leverageStep"`
+}
+
+// PriceFilter holds the price limits of an instrument. Spot instruments only set TickSize.
+type PriceFilter struct {
+	MinPrice client.Decimal `json:"minPrice"`
+	MaxPrice client.Decimal `json:"maxPrice"`
+	TickSize client.Decimal `json:"tickSize"`
+}
+
+// LotSizeFilter holds the order size limits of an instrument. Spot instruments set the
+// precisions and order amounts, contracts the quantity step and notional value.
+type LotSizeFilter struct {
+	BasePrecision       client.Decimal `json:"basePrecision"`
+	QuotePrecision      client.Decimal `json:"quotePrecision"`
+	MinOrderQty         client.Decimal `json:"minOrderQty"`
+	MaxOrderQty         client.Decimal `json:"maxOrderQty"`
+	MinOrderAmt         client.Decimal `json:"minOrderAmt"`
+	MaxOrderAmt         client.Decimal `json:"maxOrderAmt"`
+	QtyStep             client.Decimal `json:"qtyStep"`
+	PostOnlyMaxOrderQty client.Decimal `json:"postOnlyMaxOrderQty"`
+	MaxMktOrderQty      client.Decimal `json:"maxMktOrderQty"`
+	MinNotionalValue    client.Decimal `json:"minNotionalValue"`
+}
+
+// RiskParameters holds the price limits of spot orders as ratios of the last price.
+type RiskParameters struct {
+	LimitParameter  client.Decimal `json:"limitParameter"`
+	MarketParameter client.Decimal `json:"marketParameter"`
+}
+
+// Instrument is the specification of a spot pair, contract or option. Fields that do not
+// apply to the instrument's category are zero.
+type Instrument struct {
+	Symbol string `json:"symbol"`
+	// ContractType is LinearPerpetual, LinearFutures, InversePerpetual or InverseFutures.
+	ContractType string `json:"contractType"`
+	// OptionsType is Call or Put.
+	OptionsType string `json:"optionsType"`
+	Status      string `json:"status"`
+	BaseCoin    string `json:"baseCoin"`
+	QuoteCoin   string `json:"quoteCoin"`
+	SettleCoin  string `json:"settleCoin"`
+	// LaunchTime and DeliveryTime are Unix milliseconds; DeliveryTime is 0 for
+	// perpetuals.
+	LaunchTime      client.Int     `json:"launchTime"`
+	DeliveryTime    client.Int     `json:"deliveryTime"`
+	DeliveryFeeRate client.Decimal `json:"deliveryFeeRate"`
+	PriceScale      client.Int     `json:"priceScale"`
+	// FundingInterval is in minutes.
+	FundingInterval    int            `json:"fundingInterval"`
+	UpperFundingRate   client.Decimal `json:"upperFundingRate"`
+	LowerFundingRate   client.Decimal `json:"lowerFundingRate"`
+	UnifiedMarginTrade bool           `json:"unifiedMarginTrade"`
+	CopyTrading        string         `json:"copyTrading"`
+	IsPreListing       bool           `json:"isPreListing"`
+	// Innovation is 1 for spot pairs in the innovation zone.
+	Innovation     client.Int      `json:"innovation"`
+	MarginTrading  string          `json:"marginTrading"`
+	LeverageFilter LeverageFilter  `json:"leverageFilter"`
+	PriceFilter    PriceFilter     `json:"priceFilter"`
+	LotSizeFilter  LotSizeFilter   `json:"lotSizeFilter"`
+	RiskParameters *RiskParameters `json:"riskParameters,omitempty"`
+}
+
+// InstrumentsPage is a page of instruments.
+type InstrumentsPage struct {
+	Category       string       `json:"category"`
+	List           []Instrument `json:"list"`
+	NextPageCursor string       `json:"nextPageCursor"`
+}
+
+// InstrumentsResponse is the typed response of the /v5/market/instruments-info endpoint.
+type InstrumentsResponse struct {
+	APIBaseResponse
+	Result InstrumentsPage `json:"result"`
+}
+
+// GetInstrumentsInfo returns a page of the instruments matching req. Use Instruments to
+// walk every page.
+func (m *marketImpl) GetInstrumentsInfo(ctx context.Context, req InstrumentsInfoRequest) (*InstrumentsPage, error) {
+	if req.Category == "" {
+		return nil, fmt.Errorf("instruments info: category is required")
+	}
+	params := client.Params{"category": req.Category}
+	for key, value := range map[string]string{"symbol": req.Symbol, "baseCoin": req.BaseCoin, "status": req.Status, "cursor": req.Cursor} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	if req.Limit > 0 {
+		params["limit"] = req.Limit
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/instruments-info", client.APIVersion), params)
+	if err != nil {
+		return nil, err
+	}
+	var instruments InstrumentsResponse
+	if err := res.Unmarshal(&instruments); err != nil {
+		return nil, err
+	}
+	return &instruments.Result, nil
+}
+
+// Instruments returns an iterator over every instrument matching req, following
+// nextPageCursor across pages:
+//
+//	all, err := market.Instruments(m, market.InstrumentsInfoRequest{Category: "linear", Limit: 1000}).All(ctx)
+func Instruments(m Market, req InstrumentsInfoRequest) *client.Iterator[Instrument] {
+	start := req.Cursor
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[Instrument], error) {
+		req.Cursor = start
+		if cursor != "" {
+			req.Cursor = cursor
+		}
+		page, err := m.GetInstrumentsInfo(ctx, req)
+		if err != nil {
+			return client.Page[Instrument]{}, err
+		}
+		return client.Page[Instrument]{Items: page.List, NextCursor: page.NextPageCursor}, nil
+	})
+}
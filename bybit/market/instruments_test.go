@@ -0,0 +1,75 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetInstrumentsInfo(t *testing.T) {
+	mock := bybittest.NewMock()
+	page, err := New(mock.Client()).GetInstrumentsInfo(context.Background(), InstrumentsInfoRequest{Category: "linear", Symbol: "BTCUSDT", Status: "Trading"})
+	assert.NoError(t, err)
+	if assert.Len(t, page.List, 1) {
+		btc := page.List[0]
+		assert.Equal(t, "LinearPerpetual", btc.ContractType)
+		assert.EqualValues(t, 1585526400000, btc.LaunchTime)
+		assert.EqualValues(t, 2, btc.PriceScale)
+		assert.Equal(t, 480, btc.FundingInterval)
+		assert.True(t, btc.LeverageFilter.MaxLeverage.Equal(client.MustParseDecimal("100")))
+		assert.True(t, btc.PriceFilter.TickSize.Equal(client.MustParseDecimal("0.1")))
+		assert.True(t, btc.LotSizeFilter.QtyStep.Equal(client.MustParseDecimal("0.001")))
+		assert.True(t, btc.LotSizeFilter.MinNotionalValue.Equal(client.MustParseDecimal("5")))
+		assert.True(t, btc.LowerFundingRate.Equal(client.MustParseDecimal("-0.00375")))
+		assert.Nil(t, btc.RiskParameters)
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "BTCUSDT", requests[0].Query.Get("symbol"))
+		assert.Equal(t, "Trading", requests[0].Query.Get("status"))
+		assert.False(t, requests[0].Query.Has("baseCoin"))
+	}
+
+	_, err = New(mock.Client()).GetInstrumentsInfo(context.Background(), InstrumentsInfoRequest{})
+	assert.Error(t, err)
+}
+
+func TestInstrument_Spot(t *testing.T) {
+	var spot Instrument
+	err := json.Unmarshal([]byte(`{"symbol":"BTCUSDT","baseCoin":"BTC","quoteCoin":"USDT","innovation":"0","status":"Trading","marginTrading":"both",
+		"lotSizeFilter":{"basePrecision":"0.000001","quotePrecision":"0.00000001","minOrderQty":"0.000048","maxOrderQty":"71.73956243","minOrderAmt":"1","maxOrderAmt":"2000000"},
+		"priceFilter":{"tickSize":"0.01"},"riskParameters":{"limitParameter":"0.05","marketParameter":"0.05"}}`), &spot)
+	assert.NoError(t, err)
+	assert.Equal(t, "both", spot.MarginTrading)
+	assert.True(t, spot.LotSizeFilter.BasePrecision.Equal(client.MustParseDecimal("0.000001")))
+	assert.True(t, spot.LotSizeFilter.MaxOrderAmt.Equal(client.MustParseDecimal("2000000")))
+	if assert.NotNil(t, spot.RiskParameters) {
+		assert.True(t, spot.RiskParameters.LimitParameter.Equal(client.MustParseDecimal("0.05")))
+	}
+}
+
+func TestInstruments(t *testing.T) {
+	var cursors []string
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/market/instruments-info", func(req bybittest.Request) string {
+		cursor := req.Query.Get("cursor")
+		cursors = append(cursors, cursor)
+		if cursor == "page2" {
+			return `{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"symbol":"ETHUSDT"}],"nextPageCursor":""},"time":1}`
+		}
+		return `{"retCode":0,"retMsg":"OK","result":{"category":"spot","list":[{"symbol":"BTCUSDT"}],"nextPageCursor":"page2"},"time":1}`
+	})
+
+	instruments, err := Instruments(New(mock.Client()), InstrumentsInfoRequest{Category: "spot"}).All(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, instruments, 2) {
+		assert.Equal(t, "BTCUSDT", instruments[0].Symbol)
+		assert.Equal(t, "ETHUSDT", instruments[1].Symbol)
+	}
+	assert.Equal(t, []string{"", "page2"}, cursors)
+}
@@ -0,0 +1,51 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// InsurancePool is the balance of the insurance fund of a coin. Value is the balance in
+// USD.
+type InsurancePool struct {
+	Coin    string         `json:"coin"`
+	Balance client.Decimal `json:"balance"`
+	Value   client.Decimal `json:"value"`
+}
+
+// InsuranceFund is the typed insurance fund, updated once a day.
+type InsuranceFund struct {
+	UpdatedTime client.Int      `json:"updatedTime"`
+	List        []InsurancePool `json:"list"`
+}
+
+// UpdatedAt returns the time of the last update of the fund.
+func (f InsuranceFund) UpdatedAt() time.Time {
+	return time.UnixMilli(int64(f.UpdatedTime))
+}
+
+// InsuranceResponse is the typed response of the /v5/market/insurance endpoint.
+type InsuranceResponse struct {
+	APIBaseResponse
+	Result InsuranceFund `json:"result"`
+}
+
+// GetInsurance returns the insurance pool of coin, or of every coin when coin is empty.
+func (m *marketImpl) GetInsurance(ctx context.Context, coin string) (*InsuranceFund, error) {
+	params := client.Params{}
+	if coin != "" {
+		params["coin"] = coin
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/insurance", client.APIVersion), params)
+	if err != nil {
+		return nil, err
+	}
+	var insurance InsuranceResponse
+	if err := res.Unmarshal(&insurance); err != nil {
+		return nil, err
+	}
+	return &insurance.Result, nil
+}
@@ -0,0 +1,26 @@
+package market
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetInsurance(t *testing.T) {
+	mock := bybittest.NewMock()
+	fund, err := New(mock.Client()).GetInsurance(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1714003200000), fund.UpdatedAt().UnixMilli())
+	if assert.Len(t, fund.List, 2) {
+		assert.True(t, fund.List[0].Balance.Equal(client.MustParseDecimal("0.00000018")))
+		assert.Equal(t, "BTC", fund.List[1].Coin)
+		assert.True(t, fund.List[1].Value.Equal(client.MustParseDecimal("73843962.09")))
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.False(t, requests[0].Query.Has("coin"))
+	}
+}
@@ -0,0 +1,94 @@
+package market
+
+import (
+	"fmt"
+	"time"
+)
+
+// Interval is a kline interval as accepted by the REST kline endpoints and the kline
+// WebSocket topics.
+type Interval string
+
+// Kline intervals supported by Bybit.
+const (
+	Interval1m  Interval = "1"
+	Interval3m  Interval = "3"
+	Interval5m  Interval = "5"
+	Interval15m Interval = "15"
+	Interval30m Interval = "30"
+	Interval1h  Interval = "60"
+	Interval2h  Interval = "120"
+	Interval4h  Interval = "240"
+	Interval6h  Interval = "360"
+	Interval12h Interval = "720"
+	Interval1d  Interval = "D"
+	Interval1w  Interval = "W"
+	Interval1M  Interval = "M"
+)
+
+// intervalDurations holds the length of each interval. A month is counted as 30 days.
+var intervalDurations = map[Interval]time.Duration{
+	Interval1m:  time.Minute,
+	Interval3m:  3 * time.Minute,
+	Interval5m:  5 * time.Minute,
+	Interval15m: 15 * time.Minute,
+	Interval30m: 30 * time.Minute,
+	Interval1h:  time.Hour,
+	Interval2h:  2 * time.Hour,
+	Interval4h:  4 * time.Hour,
+	Interval6h:  6 * time.Hour,
+	Interval12h: 12 * time.Hour,
+	Interval1d:  24 * time.Hour,
+	Interval1w:  7 * 24 * time.Hour,
+	Interval1M:  30 * 24 * time.Hour,
+}
+
+// ParseInterval returns s as an Interval, or an error if Bybit does not support it.
+func ParseInterval(s string) (Interval, error) {
+	interval := Interval(s)
+	if err := interval.Validate(); err != nil {
+		return "", err
+	}
+	return interval, nil
+}
+
+// Validate returns an error if Bybit does not support the interval.
+func (i Interval) Validate() error {
+	if _, ok := intervalDurations[i]; !ok {
+		return fmt.Errorf("invalid kline interval %q", string(i))
+	}
+	return nil
+}
+
+// Duration returns the length of the interval, counting a month as 30 days. It returns 0
+// for an invalid interval.
+func (i Interval) Duration() time.Duration {
+	return intervalDurations[i]
+}
+
+// String returns the interval as sent to Bybit.
+func (i Interval) String() string {
+	return string(i)
+}
+
+// IntervalTime is the period of the open interest and long/short ratio statistics.
+type IntervalTime string
+
+// Statistics periods supported by Bybit.
+const (
+	IntervalTime5m  IntervalTime = "5min"
+	IntervalTime15m IntervalTime = "15min"
+	IntervalTime30m IntervalTime = "30min"
+	IntervalTime1h  IntervalTime = "1h"
+	IntervalTime4h  IntervalTime = "4h"
+	IntervalTime1d  IntervalTime = "1d"
+)
+
+// Validate returns an error if Bybit does not support the period.
+func (i IntervalTime) Validate() error {
+	switch i {
+	case IntervalTime5m, IntervalTime15m, IntervalTime30m, IntervalTime1h, IntervalTime4h, IntervalTime1d:
+		return nil
+	}
+	return fmt.Errorf("invalid interval time %q", string(i))
+}
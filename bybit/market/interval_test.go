@@ -0,0 +1,25 @@
+package market
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInterval(t *testing.T) {
+	interval, err := ParseInterval("240")
+	assert.NoError(t, err)
+	assert.Equal(t, Interval4h, interval)
+	assert.Equal(t, 4*time.Hour, interval.Duration())
+
+	_, err = ParseInterval("2")
+	assert.Error(t, err)
+	assert.Zero(t, Interval("2").Duration())
+}
+
+func TestValidateInterval(t *testing.T) {
+	assert.NoError(t, validateInterval(&client.Params{"interval": Interval1d}))
+	assert.Error(t, validateInterval(&client.Params{"interval": "1h"}))
+}
@@ -0,0 +1,136 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// Candle is a kline with exact prices. Volume and Turnover are zero for the mark, index
+// and premium index price klines, which carry prices only.
+type Candle struct {
+	Start    time.Time
+	Open     client.Decimal
+	High     client.Decimal
+	Low      client.Decimal
+	Close    client.Decimal
+	Volume   client.Decimal
+	Turnover client.Decimal
+}
+
+// Candles parses the list of the result, newest candle first as Bybit returns it.
+func (r KlineResult) Candles() ([]Candle, error) {
+	candles := make([]Candle, len(r.List))
+	for i, row := range r.List {
+		if len(row) < 5 {
+			return nil, fmt.Errorf("malformed kline of %s: %v", r.Symbol, row)
+		}
+		start, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kline start %q: %v", row[0], err)
+		}
+		values := make([]client.Decimal, 6)
+		for j, field := range row[1:min(len(row), 7)] {
+			if values[j], err = client.ParseDecimal(field); err != nil {
+				return nil, fmt.Errorf("invalid kline of %s: %v", r.Symbol, err)
+			}
+		}
+		candles[i] = Candle{
+			Start:    time.UnixMilli(start),
+			Open:     values[0],
+			High:     values[1],
+			Low:      values[2],
+			Close:    values[3],
+			Volume:   values[4],
+			Turnover: values[5],
+		}
+	}
+	return candles, nil
+}
+
+// GetKline returns the candles of symbol in category, such as "linear", newest first.
+// Zero start and end times and a zero limit are left to Bybit's defaults: the latest 200
+// candles.
+func (m *marketImpl) GetKline(ctx context.Context, category, symbol string, interval Interval, start, end time.Time, limit int) ([]Candle, error) {
+	params := klineParams(category, symbol, interval, start, end, limit)
+	res, err := m.Kline(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result.Candles()
+}
+
+// GetMarkPriceKline returns the mark price candles of a linear or inverse contract, newest
+// first. The candles carry no volume or turnover. Zero start and end times and a zero
+// limit are left to Bybit's defaults.
+func (m *marketImpl) GetMarkPriceKline(ctx context.Context, category, symbol string, interval Interval, start, end time.Time, limit int) ([]Candle, error) {
+	if err := requireCategory(category, "linear", "inverse"); err != nil {
+		return nil, fmt.Errorf("mark price kline: %w", err)
+	}
+	params := klineParams(category, symbol, interval, start, end, limit)
+	res, err := m.MarkPriceKline(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result.Candles()
+}
+
+// GetIndexPriceKline returns the index price candles of a linear or inverse contract,
+// newest first, for example to compute the basis of the contract against its index. The
+// candles carry no volume or turnover. Zero start and end times and a zero limit are left
+// to Bybit's defaults.
+func (m *marketImpl) GetIndexPriceKline(ctx context.Context, category, symbol string, interval Interval, start, end time.Time, limit int) ([]Candle, error) {
+	if err := requireCategory(category, "linear", "inverse"); err != nil {
+		return nil, fmt.Errorf("index price kline: %w", err)
+	}
+	params := klineParams(category, symbol, interval, start, end, limit)
+	res, err := m.IndexPriceKline(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result.Candles()
+}
+
+// GetPremiumIndexPriceKline returns the premium index price candles of a linear contract,
+// newest first, from which the next funding rate can be estimated. The candles carry no
+// volume or turnover. Zero start and end times and a zero limit are left to Bybit's
+// defaults.
+func (m *marketImpl) GetPremiumIndexPriceKline(ctx context.Context, symbol string, interval Interval, start, end time.Time, limit int) ([]Candle, error) {
+	params := klineParams("linear", symbol, interval, start, end, limit)
+	res, err := m.PremiumIndexKline(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result.Candles()
+}
+
+// requireCategory returns an error unless category is one of allowed.
+func requireCategory(category string, allowed ...string) error {
+	for _, c := range allowed {
+		if category == c {
+			return nil
+		}
+	}
+	return fmt.Errorf("category must be one of %v, got %q", allowed, category)
+}
+
+// klineParams builds the parameters of the kline endpoints.
+func klineParams(category, symbol string, interval Interval, start, end time.Time, limit int) client.Params {
+	params := client.Params{"symbol": symbol, "interval": interval}
+	if category != "" {
+		params["category"] = category
+	}
+	if !start.IsZero() {
+		params["start"] = start.UnixMilli()
+	}
+	if !end.IsZero() {
+		params["end"] = end.UnixMilli()
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	return params
+}
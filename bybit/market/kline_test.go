@@ -0,0 +1,110 @@
+package market
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetKline(t *testing.T) {
+	mock := bybittest.NewMock()
+	start := time.UnixMilli(1670601600000)
+	candles, err := New(mock.Client()).GetKline(context.Background(), "linear", "BTCUSDT", Interval1h, start, time.Time{}, 2)
+	assert.NoError(t, err)
+
+	if assert.Len(t, candles, 2) {
+		assert.Equal(t, time.UnixMilli(1670608800000), candles[0].Start)
+		assert.True(t, candles[0].Open.Equal(client.MustParseDecimal("17071")))
+		assert.True(t, candles[0].Close.Equal(client.MustParseDecimal("17055.5")))
+		assert.True(t, candles[0].Volume.Equal(client.MustParseDecimal("268611")))
+		assert.True(t, candles[1].Turnover.Equal(client.MustParseDecimal("0.24469757")))
+	}
+
+	requests := mock.Requests()
+	if assert.Len(t, requests, 1) {
+		query := requests[0].Query
+		assert.Equal(t, "/v5/market/kline", requests[0].Path)
+		assert.Equal(t, "linear", query.Get("category"))
+		assert.Equal(t, "60", query.Get("interval"))
+		assert.Equal(t, "1670601600000", query.Get("start"))
+		assert.Equal(t, "2", query.Get("limit"))
+		assert.False(t, query.Has("end"))
+	}
+
+	_, err = New(mock.Client()).GetKline(context.Background(), "linear", "BTCUSDT", "7", start, time.Time{}, 0)
+	assert.Error(t, err)
+}
+
+func TestKlineResult_Candles(t *testing.T) {
+	candles, err := KlineResult{Symbol: "BTCUSDT", List: [][]string{{"1670608800000", "17071", "17073", "17027", "17055.5"}}}.Candles()
+	assert.NoError(t, err)
+	if assert.Len(t, candles, 1) {
+		assert.True(t, candles[0].High.Equal(client.MustParseDecimal("17073")))
+		assert.True(t, candles[0].Volume.IsZero())
+	}
+
+	_, err = KlineResult{List: [][]string{{"1670608800000", "17071"}}}.Candles()
+	assert.Error(t, err)
+	_, err = KlineResult{List: [][]string{{"1670608800000", "x", "1", "1", "1"}}}.Candles()
+	assert.Error(t, err)
+}
+
+func TestGetMarkPriceKline(t *testing.T) {
+	mock := bybittest.NewMock()
+	m := New(mock.Client())
+	candles, err := m.GetMarkPriceKline(context.Background(), "linear", "BTCUSDT", Interval1h, time.Time{}, time.Time{}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, candles, 2) {
+		assert.Equal(t, time.UnixMilli(1670608800000), candles[0].Start)
+		assert.True(t, candles[0].Close.Equal(client.MustParseDecimal("17131.64")))
+		assert.True(t, candles[1].Open.Equal(client.MustParseDecimal("17202.51")))
+		assert.True(t, candles[0].Volume.IsZero())
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "/v5/market/mark-price-kline", requests[0].Path)
+		assert.False(t, requests[0].Query.Has("limit"))
+	}
+
+	_, err = m.GetMarkPriceKline(context.Background(), "spot", "BTCUSDT", Interval1h, time.Time{}, time.Time{}, 0)
+	assert.Error(t, err)
+	assert.Len(t, mock.Requests(), 1)
+}
+
+func TestGetIndexPriceKline(t *testing.T) {
+	mock := bybittest.NewMock()
+	m := New(mock.Client())
+	end := time.UnixMilli(1670612400000)
+	candles, err := m.GetIndexPriceKline(context.Background(), "inverse", "BTCUSDZ22", Interval1h, time.Time{}, end, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, candles, 2) {
+		assert.True(t, candles[0].Low.Equal(client.MustParseDecimal("17161.9")))
+		assert.True(t, candles[1].Close.Equal(client.MustParseDecimal("17167")))
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "/v5/market/index-price-kline", requests[0].Path)
+		assert.Equal(t, "inverse", requests[0].Query.Get("category"))
+		assert.Equal(t, "1670612400000", requests[0].Query.Get("end"))
+	}
+
+	_, err = m.GetIndexPriceKline(context.Background(), "option", "BTC-30DEC22-18000-C", Interval1h, time.Time{}, time.Time{}, 0)
+	assert.Error(t, err)
+}
+
+func TestGetPremiumIndexPriceKline(t *testing.T) {
+	mock := bybittest.NewMock()
+	candles, err := New(mock.Client()).GetPremiumIndexPriceKline(context.Background(), "BTCUSDT", Interval1m, time.Time{}, time.Time{}, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, candles, 2) {
+		assert.True(t, candles[0].Close.IsZero())
+		assert.True(t, candles[1].Low.Equal(client.MustParseDecimal("-0.000188")))
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "/v5/market/premium-index-price-kline", requests[0].Path)
+		assert.Equal(t, "linear", requests[0].Query.Get("category"))
+	}
+}
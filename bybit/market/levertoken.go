@@ -0,0 +1,102 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// LeverageTokenInfo is the specification of a leveraged token, such as BTC3L.
+type LeverageTokenInfo struct {
+	LtCoin           string         `json:"ltCoin"`
+	LtName           string         `json:"ltName"`
+	MaxPurchase      client.Decimal `json:"maxPurchase"`
+	MinPurchase      client.Decimal `json:"minPurchase"`
+	MaxPurchaseDaily client.Decimal `json:"maxPurchaseDaily"`
+	MaxRedeem        client.Decimal `json:"maxRedeem"`
+	MinRedeem        client.Decimal `json:"minRedeem"`
+	MaxRedeemDaily   client.Decimal `json:"maxRedeemDaily"`
+	PurchaseFeeRate  client.Decimal `json:"purchaseFeeRate"`
+	RedeemFeeRate    client.Decimal `json:"redeemFeeRate"`
+	// LtStatus is "1" when the token can be purchased and redeemed, "2" when it can be
+	// purchased only, "3" when it can be redeemed only, "4" when neither is possible and
+	// "5" while its position is being adjusted.
+	LtStatus      string         `json:"ltStatus"`
+	FundFee       client.Decimal `json:"fundFee"`
+	FundFeeTime   client.Int     `json:"fundFeeTime"`
+	ManageFeeRate client.Decimal `json:"manageFeeRate"`
+	ManageFeeTime client.Int     `json:"manageFeeTime"`
+	// Value is the total value of the token in USDT, NetValue the value of one token.
+	Value    client.Decimal `json:"value"`
+	NetValue client.Decimal `json:"netValue"`
+	Total    client.Decimal `json:"total"`
+}
+
+// LeverageTokenMarket is the net asset value of a leveraged token and the basket behind it.
+type LeverageTokenMarket struct {
+	LtCoin      string         `json:"ltCoin"`
+	Nav         client.Decimal `json:"nav"`
+	NavTime     client.Int     `json:"navTime"`
+	Circulation client.Decimal `json:"circulation"`
+	// Basket is the position held per token, Leverage the real leverage of the basket.
+	Basket   client.Decimal `json:"basket"`
+	Leverage client.Decimal `json:"leverage"`
+}
+
+// NavAt returns the time the net asset value was computed.
+func (m LeverageTokenMarket) NavAt() time.Time {
+	return time.UnixMilli(int64(m.NavTime))
+}
+
+// LeverageTokenInfoResponse is the typed response of the /v5/spot-lever-token/info
+// endpoint.
+type LeverageTokenInfoResponse struct {
+	APIBaseResponse
+	Result struct {
+		List []LeverageTokenInfo `json:"list"`
+	} `json:"result"`
+}
+
+// LeverageTokenMarketResponse is the typed response of the /v5/spot-lever-token/reference
+// endpoint.
+type LeverageTokenMarketResponse struct {
+	APIBaseResponse
+	Result LeverageTokenMarket `json:"result"`
+}
+
+// GetLeverageTokenInfo returns the specification of ltCoin, or of every leveraged token
+// when ltCoin is empty.
+func (m *marketImpl) GetLeverageTokenInfo(ctx context.Context, ltCoin string) ([]LeverageTokenInfo, error) {
+	params := client.Params{}
+	if ltCoin != "" {
+		params["ltCoin"] = ltCoin
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/spot-lever-token/info", client.APIVersion), params)
+	if err != nil {
+		return nil, err
+	}
+	var info LeverageTokenInfoResponse
+	if err := res.Unmarshal(&info); err != nil {
+		return nil, err
+	}
+	return info.Result.List, nil
+}
+
+// GetLeverageTokenMarket returns the net asset value, basket and leverage of ltCoin, the
+// REST equivalent of the lt WebSocket topic.
+func (m *marketImpl) GetLeverageTokenMarket(ctx context.Context, ltCoin string) (*LeverageTokenMarket, error) {
+	if ltCoin == "" {
+		return nil, fmt.Errorf("leverage token market: ltCoin is required")
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/spot-lever-token/reference", client.APIVersion), client.Params{"ltCoin": ltCoin})
+	if err != nil {
+		return nil, err
+	}
+	var market LeverageTokenMarketResponse
+	if err := res.Unmarshal(&market); err != nil {
+		return nil, err
+	}
+	return &market.Result, nil
+}
@@ -0,0 +1,41 @@
+package market
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetLeverageTokenInfo(t *testing.T) {
+	mock := bybittest.NewMock()
+	tokens, err := New(mock.Client()).GetLeverageTokenInfo(context.Background(), "BTC3L")
+	assert.NoError(t, err)
+	if assert.Len(t, tokens, 1) {
+		assert.Equal(t, "BTC3L", tokens[0].LtCoin)
+		assert.Equal(t, "1", tokens[0].LtStatus)
+		assert.True(t, tokens[0].NetValue.Equal(client.MustParseDecimal("0.287")))
+		assert.True(t, tokens[0].PurchaseFeeRate.Equal(client.MustParseDecimal("0.0005")))
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "BTC3L", requests[0].Query.Get("ltCoin"))
+	}
+}
+
+func TestGetLeverageTokenMarket(t *testing.T) {
+	mock := bybittest.NewMock()
+	m := New(mock.Client())
+	market, err := m.GetLeverageTokenMarket(context.Background(), "BTC3L")
+	assert.NoError(t, err)
+	assert.True(t, market.Nav.Equal(client.MustParseDecimal("0.287")))
+	assert.True(t, market.Basket.Equal(client.MustParseDecimal("0.00108")))
+	assert.True(t, market.Leverage.Equal(client.MustParseDecimal("2.98")))
+	assert.Equal(t, int64(1672054193000), market.NavAt().UnixMilli())
+
+	_, err = m.GetLeverageTokenMarket(context.Background(), "")
+	assert.Error(t, err)
+	assert.Len(t, mock.Requests(), 1)
+}
@@ -1,28 +1,47 @@
 package market
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
 )
 
 type Market interface {
-	ServerTime(params *client.Params) (*ServerTimeResponse, error)
-	Kline(params *client.Params) (*KlineResponse, error)
-	Announcement(params *client.Params) (*AnnouncementsResponse, error)
-	MarkPriceKline(params *client.Params) (*KlineResponse, error)
-	IndexPriceKline(params *client.Params) (*KlineResponse, error)
-	PremiumIndexKline(params *client.Params) (*KlineResponse, error)
-	OrderBook(params *client.Params) (*OrderBook, error)
-	InstrumentsInfo(params *client.Params) (*InstrumentsInfoResponse, error)
-	Tickers(params *client.Params) (*TickerResponse, error)
-	FundingHistory(params *client.Params) (*FundingRateHistory, error)
-	RiskLimit(params *client.Params) (*RiskLimit, error)
-	OpenInterest(params *client.Params) (*OpenHistory, error)
-	Insurance(params *client.Params) (*Insurance, error)
-	RecentTrade(params *client.Params) (*ResendTrade, error)
-	DeliveryPrice(params *client.Params) (*DeliveryPrice, error)
-	HistoricalVolatility(params *client.Params) (*HistoricalVolatility, error)
+	ServerTime(ctx context.Context, params *client.Params) (*ServerTimeResponse, error)
+	GetServerTime(ctx context.Context) (time.Time, error)
+	Kline(ctx context.Context, params *client.Params) (*KlineResponse, error)
+	GetKline(ctx context.Context, category, symbol string, interval Interval, start, end time.Time, limit int) ([]Candle, error)
+	Announcement(ctx context.Context, params *client.Params) (*AnnouncementsResponse, error)
+	MarkPriceKline(ctx context.Context, params *client.Params) (*KlineResponse, error)
+	GetMarkPriceKline(ctx context.Context, category, symbol string, interval Interval, start, end time.Time, limit int) ([]Candle, error)
+	IndexPriceKline(ctx context.Context, params *client.Params) (*KlineResponse, error)
+	GetIndexPriceKline(ctx context.Context, category, symbol string, interval Interval, start, end time.Time, limit int) ([]Candle, error)
+	PremiumIndexKline(ctx context.Context, params *client.Params) (*KlineResponse, error)
+	GetPremiumIndexPriceKline(ctx context.Context, symbol string, interval Interval, start, end time.Time, limit int) ([]Candle, error)
+	OrderBook(ctx context.Context, params *client.Params) (*OrderBook, error)
+	GetOrderBook(ctx context.Context, category, symbol string, limit int) (*OrderBookSnapshot, error)
+	InstrumentsInfo(ctx context.Context, params *client.Params) (*InstrumentsInfoResponse, error)
+	GetInstrumentsInfo(ctx context.Context, req InstrumentsInfoRequest) (*InstrumentsPage, error)
+	Tickers(ctx context.Context, params *client.Params) (*TickerResponse, error)
+	GetTickers(ctx context.Context, category, symbol, baseCoin, expDate string) ([]Ticker, error)
+	FundingHistory(ctx context.Context, params *client.Params) (*FundingRateHistory, error)
+	RiskLimit(ctx context.Context, params *client.Params) (*RiskLimit, error)
+	OpenInterest(ctx context.Context, params *client.Params) (*OpenHistory, error)
+	GetLongShortRatio(ctx context.Context, category, symbol string, period IntervalTime, limit int) ([]LongShortRatio, error)
+	GetOpenInterest(ctx context.Context, category, symbol string, intervalTime IntervalTime, start, end time.Time, limit int, cursor string) (*OpenInterestPage, error)
+	Insurance(ctx context.Context, params *client.Params) (*Insurance, error)
+	GetInsurance(ctx context.Context, coin string) (*InsuranceFund, error)
+	GetLeverageTokenInfo(ctx context.Context, ltCoin string) ([]LeverageTokenInfo, error)
+	GetLeverageTokenMarket(ctx context.Context, ltCoin string) (*LeverageTokenMarket, error)
+	RecentTrade(ctx context.Context, params *client.Params) (*ResendTrade, error)
+	GetPublicRecentTrades(ctx context.Context, category, symbol, baseCoin, optionType string, limit int) ([]PublicTrade, error)
+	DeliveryPrice(ctx context.Context, params *client.Params) (*DeliveryPrice, error)
+	HistoricalVolatility(ctx context.Context, params *client.Params) (*HistoricalVolatility, error)
+	GetHistoricalVolatility(ctx context.Context, baseCoin string, period int, start, end time.Time) ([]VolatilityPoint, error)
+	SystemStatus(ctx context.Context, params *client.Params) (*SystemStatusResponse, error)
 }
 
 type marketImpl struct {
@@ -33,8 +52,8 @@ func New(c *client.Client) Market {
 	return &marketImpl{c}
 }
 
-func (m *marketImpl) ServerTime(params *client.Params) (*ServerTimeResponse, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/time", client.APIVersion), *params)
+func (m *marketImpl) ServerTime(ctx context.Context, params *client.Params) (*ServerTimeResponse, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/time", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -44,8 +63,21 @@ func (m *marketImpl) ServerTime(params *client.Params) (*ServerTimeResponse, err
 	}
 	return &serverTime, nil
 }
-func (m *marketImpl) Kline(params *client.Params) (*KlineResponse, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/kline", client.APIVersion), *params)
+
+// GetServerTime returns Bybit's clock. Use a client.TimeSync to keep signed requests in line
+// with it.
+func (m *marketImpl) GetServerTime(ctx context.Context) (time.Time, error) {
+	res, err := m.ServerTime(ctx, &client.Params{})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return res.Result.Time()
+}
+func (m *marketImpl) Kline(ctx context.Context, params *client.Params) (*KlineResponse, error) {
+	if err := validateInterval(params); err != nil {
+		return nil, err
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/kline", client.APIVersion), *params)
 
 	if err != nil {
 		return nil, err
@@ -58,8 +90,8 @@ func (m *marketImpl) Kline(params *client.Params) (*KlineResponse, error) {
 	return &kline, nil
 }
 
-func (m *marketImpl) Announcement(params *client.Params) (*AnnouncementsResponse, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/announcements/index", client.APIVersion), *params)
+func (m *marketImpl) Announcement(ctx context.Context, params *client.Params) (*AnnouncementsResponse, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/announcements/index", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +103,11 @@ func (m *marketImpl) Announcement(params *client.Params) (*AnnouncementsResponse
 	return &announcement, nil
 }
 
-func (m *marketImpl) MarkPriceKline(params *client.Params) (*KlineResponse, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/mark-price-kline", client.APIVersion), *params)
+func (m *marketImpl) MarkPriceKline(ctx context.Context, params *client.Params) (*KlineResponse, error) {
+	if err := validateInterval(params); err != nil {
+		return nil, err
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/mark-price-kline", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -84,8 +119,11 @@ func (m *marketImpl) MarkPriceKline(params *client.Params) (*KlineResponse, erro
 	return &markPriceKline, nil
 }
 
-func (m *marketImpl) IndexPriceKline(params *client.Params) (*KlineResponse, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/index-price-kline", client.APIVersion), *params)
+func (m *marketImpl) IndexPriceKline(ctx context.Context, params *client.Params) (*KlineResponse, error) {
+	if err := validateInterval(params); err != nil {
+		return nil, err
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/index-price-kline", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -97,8 +135,11 @@ func (m *marketImpl) IndexPriceKline(params *client.Params) (*KlineResponse, err
 	return &indexPriceKline, nil
 }
 
-func (m *marketImpl) PremiumIndexKline(params *client.Params) (*KlineResponse, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/premium-index-kline", client.APIVersion), *params)
+func (m *marketImpl) PremiumIndexKline(ctx context.Context, params *client.Params) (*KlineResponse, error) {
+	if err := validateInterval(params); err != nil {
+		return nil, err
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/premium-index-price-kline", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -110,8 +151,8 @@ func (m *marketImpl) PremiumIndexKline(params *client.Params) (*KlineResponse, e
 	return &premiumIndexKline, nil
 }
 
-func (m *marketImpl) OrderBook(params *client.Params) (*OrderBook, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/orderbook", client.APIVersion), *params)
+func (m *marketImpl) OrderBook(ctx context.Context, params *client.Params) (*OrderBook, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/orderbook", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -123,8 +164,8 @@ func (m *marketImpl) OrderBook(params *client.Params) (*OrderBook, error) {
 	return &orderBook, nil
 }
 
-func (m *marketImpl) InstrumentsInfo(params *client.Params) (*InstrumentsInfoResponse, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/instruments-info", client.APIVersion), *params)
+func (m *marketImpl) InstrumentsInfo(ctx context.Context, params *client.Params) (*InstrumentsInfoResponse, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/instruments-info", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -136,8 +177,8 @@ func (m *marketImpl) InstrumentsInfo(params *client.Params) (*InstrumentsInfoRes
 	return &instrumentsInfo, nil
 }
 
-func (m *marketImpl) Tickers(params *client.Params) (*TickerResponse, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/tickers", client.APIVersion), *params)
+func (m *marketImpl) Tickers(ctx context.Context, params *client.Params) (*TickerResponse, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/tickers", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -149,8 +190,8 @@ func (m *marketImpl) Tickers(params *client.Params) (*TickerResponse, error) {
 	return &tickers, nil
 }
 
-func (m *marketImpl) FundingHistory(params *client.Params) (*FundingRateHistory, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/funding/history", client.APIVersion), *params)
+func (m *marketImpl) FundingHistory(ctx context.Context, params *client.Params) (*FundingRateHistory, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/funding/history", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -162,8 +203,8 @@ func (m *marketImpl) FundingHistory(params *client.Params) (*FundingRateHistory,
 	return &fundingHistory, nil
 }
 
-func (m *marketImpl) RiskLimit(params *client.Params) (*RiskLimit, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/insurance", client.APIVersion), *params)
+func (m *marketImpl) RiskLimit(ctx context.Context, params *client.Params) (*RiskLimit, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/insurance", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -175,8 +216,8 @@ func (m *marketImpl) RiskLimit(params *client.Params) (*RiskLimit, error) {
 	return &riskLimit, nil
 }
 
-func (m *marketImpl) OpenInterest(params *client.Params) (*OpenHistory, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/open-interest", client.APIVersion), *params)
+func (m *marketImpl) OpenInterest(ctx context.Context, params *client.Params) (*OpenHistory, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/open-interest", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -188,8 +229,8 @@ func (m *marketImpl) OpenInterest(params *client.Params) (*OpenHistory, error) {
 	return &openInterest, nil
 }
 
-func (m *marketImpl) Insurance(params *client.Params) (*Insurance, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/insurance", client.APIVersion), *params)
+func (m *marketImpl) Insurance(ctx context.Context, params *client.Params) (*Insurance, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/insurance", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -201,8 +242,8 @@ func (m *marketImpl) Insurance(params *client.Params) (*Insurance, error) {
 	return &insurance, nil
 }
 
-func (m *marketImpl) RecentTrade(params *client.Params) (*ResendTrade, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/market/trading-records", client.APIVersion), *params)
+func (m *marketImpl) RecentTrade(ctx context.Context, params *client.Params) (*ResendTrade, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/recent-trade", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -214,8 +255,8 @@ func (m *marketImpl) RecentTrade(params *client.Params) (*ResendTrade, error) {
 	return &recentTrade, nil
 }
 
-func (m *marketImpl) DeliveryPrice(params *client.Params) (*DeliveryPrice, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/public/delivery-price", client.APIVersion), *params)
+func (m *marketImpl) DeliveryPrice(ctx context.Context, params *client.Params) (*DeliveryPrice, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/public/delivery-price", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -227,8 +268,8 @@ func (m *marketImpl) DeliveryPrice(params *client.Params) (*DeliveryPrice, error
 	return &deliveryPrice, nil
 }
 
-func (m *marketImpl) HistoricalVolatility(params *client.Params) (*HistoricalVolatility, error) {
-	res, err := m.c.Get(fmt.Sprintf("/%s/public/historical-volatility", client.APIVersion), *params)
+func (m *marketImpl) HistoricalVolatility(ctx context.Context, params *client.Params) (*HistoricalVolatility, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/historical-volatility", client.APIVersion), *params)
 	if err != nil {
 		return nil, err
 	}
@@ -239,3 +280,13 @@ func (m *marketImpl) HistoricalVolatility(params *client.Params) (*HistoricalVol
 	}
 	return &historicalVolatility, nil
 }
+
+// validateInterval checks the "interval" parameter of a kline request, which may be an
+// Interval or a string.
+func validateInterval(params *client.Params) error {
+	interval, ok := (*params)["interval"]
+	if !ok {
+		return errors.New("kline interval is required")
+	}
+	return Interval(fmt.Sprint(interval)).Validate()
+}
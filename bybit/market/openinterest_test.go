@@ -0,0 +1,58 @@
+package market
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetOpenInterest(t *testing.T) {
+	mock := bybittest.NewMock()
+	m := New(mock.Client())
+	start := time.UnixMilli(1669570000000)
+	page, err := m.GetOpenInterest(context.Background(), "inverse", "BTCUSD", IntervalTime5m, start, time.Time{}, 2, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Mzk0MDUyMA==", page.NextPageCursor)
+	if assert.Len(t, page.List, 2) {
+		assert.True(t, page.List[0].OpenInterest.Equal(client.MustParseDecimal("461134384")))
+		assert.Equal(t, int64(1669571400000), page.List[0].Time().UnixMilli())
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "5min", requests[0].Query.Get("intervalTime"))
+		assert.Equal(t, "1669570000000", requests[0].Query.Get("startTime"))
+		assert.False(t, requests[0].Query.Has("endTime"))
+		assert.False(t, requests[0].Query.Has("cursor"))
+	}
+
+	_, err = m.GetOpenInterest(context.Background(), "spot", "BTCUSDT", IntervalTime5m, time.Time{}, time.Time{}, 0, "")
+	assert.Error(t, err)
+	_, err = m.GetOpenInterest(context.Background(), "linear", "BTCUSDT", "5m", time.Time{}, time.Time{}, 0, "")
+	assert.Error(t, err)
+	assert.Len(t, mock.Requests(), 1)
+}
+
+func TestOpenInterestHistory(t *testing.T) {
+	var cursors []string
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/market/open-interest", func(req bybittest.Request) string {
+		cursor := req.Query.Get("cursor")
+		cursors = append(cursors, cursor)
+		if cursor == "page2" {
+			return `{"retCode":0,"retMsg":"OK","result":{"symbol":"BTCUSDT","category":"linear","list":[{"openInterest":"1","timestamp":"1669571100000"}],"nextPageCursor":""},"time":1}`
+		}
+		return `{"retCode":0,"retMsg":"OK","result":{"symbol":"BTCUSDT","category":"linear","list":[{"openInterest":"2","timestamp":"1669571400000"}],"nextPageCursor":"page2"},"time":1}`
+	})
+
+	points, err := OpenInterestHistory(New(mock.Client()), "linear", "BTCUSDT", IntervalTime1h, time.Time{}, time.Time{}, 200).All(context.Background())
+	assert.NoError(t, err)
+	if assert.Len(t, points, 2) {
+		assert.True(t, points[1].OpenInterest.Equal(client.MustParseDecimal("1")))
+	}
+	assert.Equal(t, []string{"", "page2"}, cursors)
+}
@@ -0,0 +1,125 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// OptionQuote is an option with its latest ticker, which carries the mark price, implied
+// volatility and greeks.
+type OptionQuote struct {
+	Instrument Instrument
+	Ticker     Ticker
+}
+
+// StrikeRow pairs the call and the put of a strike. Either is nil when Bybit lists only one
+// side.
+type StrikeRow struct {
+	Strike client.Decimal
+	Call   *OptionQuote
+	Put    *OptionQuote
+}
+
+// OptionExpiry holds the strikes of an expiry, sorted by ascending strike.
+type OptionExpiry struct {
+	Expiry  time.Time
+	Strikes []StrikeRow
+}
+
+// OptionChain is the options of a base coin grouped by expiry and strike.
+type OptionChain struct {
+	BaseCoin string
+	// Expiries are sorted by ascending expiry.
+	Expiries []OptionExpiry
+}
+
+// Expiry returns the options expiring at t, or nil if there are none.
+func (c *OptionChain) Expiry(t time.Time) *OptionExpiry {
+	for i := range c.Expiries {
+		if c.Expiries[i].Expiry.Equal(t) {
+			return &c.Expiries[i]
+		}
+	}
+	return nil
+}
+
+// BuildOptionChain fetches the option instruments and tickers of baseCoin, such as "BTC",
+// and organizes them into a chain.
+func BuildOptionChain(ctx context.Context, m Market, baseCoin string) (*OptionChain, error) {
+	instruments, err := Instruments(m, InstrumentsInfoRequest{Category: "option", BaseCoin: baseCoin, Limit: 1000}).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("option chain: %w", err)
+	}
+	tickers, err := m.GetTickers(ctx, "option", "", baseCoin, "")
+	if err != nil {
+		return nil, fmt.Errorf("option chain: %w", err)
+	}
+	return NewOptionChain(baseCoin, instruments, tickers)
+}
+
+// NewOptionChain organizes option instruments and their tickers into a chain. Instruments
+// without a ticker are kept with a zero Ticker.
+func NewOptionChain(baseCoin string, instruments []Instrument, tickers []Ticker) (*OptionChain, error) {
+	bySymbol := make(map[string]Ticker, len(tickers))
+	for _, ticker := range tickers {
+		bySymbol[ticker.Symbol] = ticker
+	}
+
+	expiries := make(map[int64]map[string]*StrikeRow)
+	for _, instrument := range instruments {
+		strike, err := optionStrike(instrument.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		strikes, ok := expiries[int64(instrument.DeliveryTime)]
+		if !ok {
+			strikes = make(map[string]*StrikeRow)
+			expiries[int64(instrument.DeliveryTime)] = strikes
+		}
+		row, ok := strikes[strike.String()]
+		if !ok {
+			row = &StrikeRow{Strike: strike}
+			strikes[strike.String()] = row
+		}
+		quote := &OptionQuote{Instrument: instrument, Ticker: bySymbol[instrument.Symbol]}
+		switch instrument.OptionsType {
+		case "Call":
+			row.Call = quote
+		case "Put":
+			row.Put = quote
+		default:
+			return nil, fmt.Errorf("option chain: unknown options type %q of %s", instrument.OptionsType, instrument.Symbol)
+		}
+	}
+
+	chain := &OptionChain{BaseCoin: baseCoin, Expiries: make([]OptionExpiry, 0, len(expiries))}
+	for deliveryTime, strikes := range expiries {
+		expiry := OptionExpiry{Expiry: time.UnixMilli(deliveryTime), Strikes: make([]StrikeRow, 0, len(strikes))}
+		for _, row := range strikes {
+			expiry.Strikes = append(expiry.Strikes, *row)
+		}
+		sort.Slice(expiry.Strikes, func(i, j int) bool { return expiry.Strikes[i].Strike.Cmp(expiry.Strikes[j].Strike) < 0 })
+		chain.Expiries = append(chain.Expiries, expiry)
+	}
+	sort.Slice(chain.Expiries, func(i, j int) bool { return chain.Expiries[i].Expiry.Before(chain.Expiries[j].Expiry) })
+	return chain, nil
+}
+
+// optionStrike parses the strike of an option symbol such as "BTC-30DEC22-18000-C" or
+// "BTC-30DEC22-18000-C-USDT".
+func optionStrike(symbol string) (client.Decimal, error) {
+	parts := strings.Split(symbol, "-")
+	if len(parts) < 4 {
+		return client.Decimal{}, fmt.Errorf("option chain: malformed option symbol %q", symbol)
+	}
+	strike, err := client.ParseDecimal(parts[2])
+	if err != nil {
+		return client.Decimal{}, fmt.Errorf("option chain: malformed option symbol %q", symbol)
+	}
+	return strike, nil
+}
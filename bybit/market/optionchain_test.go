@@ -0,0 +1,74 @@
+package market
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestNewOptionChain(t *testing.T) {
+	d := client.MustParseDecimal
+	dec30 := time.Date(2022, 12, 30, 8, 0, 0, 0, time.UTC)
+	jan27 := time.Date(2023, 1, 27, 8, 0, 0, 0, time.UTC)
+	option := func(symbol, optionsType string, expiry time.Time) Instrument {
+		return Instrument{Symbol: symbol, OptionsType: optionsType, BaseCoin: "BTC", DeliveryTime: client.Int(expiry.UnixMilli())}
+	}
+	instruments := []Instrument{
+		option("BTC-27JAN23-20000-C", "Call", jan27),
+		option("BTC-30DEC22-18000-P", "Put", dec30),
+		option("BTC-30DEC22-9000-C", "Call", dec30),
+		option("BTC-30DEC22-18000-C", "Call", dec30),
+	}
+	tickers := []Ticker{
+		{Symbol: "BTC-30DEC22-18000-C", MarkIv: d("0.7567"), Delta: d("0.12")},
+		{Symbol: "BTC-30DEC22-18000-P", MarkIv: d("0.7612"), Delta: d("-0.88")},
+	}
+
+	chain, err := NewOptionChain("BTC", instruments, tickers)
+	assert.NoError(t, err)
+	if !assert.Len(t, chain.Expiries, 2) {
+		return
+	}
+	assert.True(t, chain.Expiries[0].Expiry.Equal(dec30))
+	assert.True(t, chain.Expiries[1].Expiry.Equal(jan27))
+
+	expiry := chain.Expiry(dec30)
+	if assert.NotNil(t, expiry) && assert.Len(t, expiry.Strikes, 2) {
+		low, high := expiry.Strikes[0], expiry.Strikes[1]
+		assert.Equal(t, "9000", low.Strike.String())
+		assert.NotNil(t, low.Call)
+		assert.Nil(t, low.Put)
+		assert.True(t, low.Call.Ticker.MarkIv.IsZero())
+		assert.Equal(t, "18000", high.Strike.String())
+		assert.True(t, high.Call.Ticker.MarkIv.Equal(d("0.7567")))
+		assert.True(t, high.Put.Ticker.Delta.Equal(d("-0.88")))
+	}
+	assert.Nil(t, chain.Expiry(time.Now()))
+
+	_, err = NewOptionChain("BTC", []Instrument{option("BTCUSDT", "Call", dec30)}, nil)
+	assert.Error(t, err)
+}
+
+func TestBuildOptionChain(t *testing.T) {
+	mock := bybittest.NewMock()
+	mock.Handle(http.MethodGet, "/v5/market/instruments-info", http.StatusOK,
+		`{"retCode":0,"retMsg":"OK","result":{"category":"option","list":[{"symbol":"ETH-3JAN23-1250-P","optionsType":"Put","baseCoin":"ETH","deliveryTime":"1672732800000"}],"nextPageCursor":""},"time":1}`)
+	mock.Handle(http.MethodGet, "/v5/market/tickers", http.StatusOK,
+		`{"retCode":0,"retMsg":"OK","result":{"category":"option","list":[{"symbol":"ETH-3JAN23-1250-P","markIv":"0.5234"}]},"time":1}`)
+
+	chain, err := BuildOptionChain(context.Background(), New(mock.Client()), "ETH")
+	assert.NoError(t, err)
+	if assert.Len(t, chain.Expiries, 1) && assert.Len(t, chain.Expiries[0].Strikes, 1) {
+		assert.True(t, chain.Expiries[0].Strikes[0].Put.Ticker.MarkIv.Equal(client.MustParseDecimal("0.5234")))
+	}
+	for _, req := range mock.Requests() {
+		assert.Equal(t, "option", req.Query.Get("category"))
+		assert.Equal(t, "ETH", req.Query.Get("baseCoin"))
+	}
+}
@@ -0,0 +1,81 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// PriceLevel is a price level of an order book.
+type PriceLevel struct {
+	Price client.Decimal
+	Size  client.Decimal
+}
+
+// OrderBookSnapshot is a typed order book snapshot. Bids are sorted by descending and Asks
+// by ascending price.
+type OrderBookSnapshot struct {
+	Symbol string
+	Bids   []PriceLevel
+	Asks   []PriceLevel
+	// Time is when the snapshot was generated.
+	Time time.Time
+	// MatchingTime is when the matching engine produced the data.
+	MatchingTime time.Time
+	// UpdateID and Seq order the snapshot against WebSocket order book updates.
+	UpdateID int64
+	Seq      int64
+}
+
+// Snapshot parses the price levels of the result.
+func (r OrderBookResult) Snapshot() (*OrderBookSnapshot, error) {
+	bids, err := parseLevels(r.B)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order book bid of %s: %v", r.S, err)
+	}
+	asks, err := parseLevels(r.A)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order book ask of %s: %v", r.S, err)
+	}
+	snapshot := &OrderBookSnapshot{Symbol: r.S, Bids: bids, Asks: asks, Time: time.UnixMilli(r.TS), UpdateID: int64(r.U), Seq: r.Seq}
+	if r.CTS > 0 {
+		snapshot.MatchingTime = time.UnixMilli(r.CTS)
+	}
+	return snapshot, nil
+}
+
+// parseLevels parses [price, size] pairs.
+func parseLevels(rows [][]string) ([]PriceLevel, error) {
+	levels := make([]PriceLevel, len(rows))
+	for i, row := range rows {
+		if len(row) < 2 {
+			return nil, fmt.Errorf("malformed level %v", row)
+		}
+		price, err := client.ParseDecimal(row[0])
+		if err != nil {
+			return nil, err
+		}
+		size, err := client.ParseDecimal(row[1])
+		if err != nil {
+			return nil, err
+		}
+		levels[i] = PriceLevel{Price: price, Size: size}
+	}
+	return levels, nil
+}
+
+// GetOrderBook returns the order book of symbol in category, limited to limit levels per
+// side. A zero limit is left to Bybit's default depth for the category.
+func (m *marketImpl) GetOrderBook(ctx context.Context, category, symbol string, limit int) (*OrderBookSnapshot, error) {
+	params := client.Params{"category": category, "symbol": symbol}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	res, err := m.OrderBook(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	return res.Result.Snapshot()
+}
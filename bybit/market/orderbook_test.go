@@ -0,0 +1,35 @@
+package market
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetOrderBook(t *testing.T) {
+	mock := bybittest.NewMock()
+	book, err := New(mock.Client()).GetOrderBook(context.Background(), "spot", "BTCUSDT", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", book.Symbol)
+	if assert.Len(t, book.Bids, 1) && assert.Len(t, book.Asks, 1) {
+		assert.True(t, book.Bids[0].Price.Equal(client.MustParseDecimal("16638.27")))
+		assert.True(t, book.Bids[0].Size.Equal(client.MustParseDecimal("0.305749")))
+		assert.True(t, book.Asks[0].Price.Equal(client.MustParseDecimal("16638.64")))
+	}
+	assert.Equal(t, time.UnixMilli(1672765737733), book.Time)
+	assert.Equal(t, time.UnixMilli(1672765737730), book.MatchingTime)
+	assert.EqualValues(t, 5277055, book.UpdateID)
+	assert.EqualValues(t, 7961638724, book.Seq)
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "1", requests[0].Query.Get("limit"))
+		assert.Equal(t, "spot", requests[0].Query.Get("category"))
+	}
+
+	_, err = OrderBookResult{S: "BTCUSDT", B: [][]string{{"1"}}}.Snapshot()
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// LongShortRatio is the share of accounts holding long (BuyRatio) and short (SellRatio)
+// positions in a contract at the end of a period.
+type LongShortRatio struct {
+	Symbol    string         `json:"symbol"`
+	BuyRatio  client.Decimal `json:"buyRatio"`
+	SellRatio client.Decimal `json:"sellRatio"`
+	Timestamp client.Int     `json:"timestamp"`
+}
+
+// Time returns the time of the ratio.
+func (r LongShortRatio) Time() time.Time {
+	return time.UnixMilli(int64(r.Timestamp))
+}
+
+// LongShortRatioResponse is the typed response of the /v5/market/account-ratio endpoint.
+type LongShortRatioResponse struct {
+	APIBaseResponse
+	Result struct {
+		List           []LongShortRatio `json:"list"`
+		NextPageCursor string           `json:"nextPageCursor"`
+	} `json:"result"`
+}
+
+// GetLongShortRatio returns the long/short account ratio of a linear or inverse contract
+// per period, newest first. A zero limit is left to Bybit's default of 50 ratios; at most
+// 500 are returned.
+func (m *marketImpl) GetLongShortRatio(ctx context.Context, category, symbol string, period IntervalTime, limit int) ([]LongShortRatio, error) {
+	if err := requireCategory(category, "linear", "inverse"); err != nil {
+		return nil, fmt.Errorf("long/short ratio: %w", err)
+	}
+	if err := period.Validate(); err != nil {
+		return nil, fmt.Errorf("long/short ratio: %w", err)
+	}
+	params := client.Params{"category": category, "symbol": symbol, "period": period}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/account-ratio", client.APIVersion), params)
+	if err != nil {
+		return nil, err
+	}
+	var ratios LongShortRatioResponse
+	if err := res.Unmarshal(&ratios); err != nil {
+		return nil, err
+	}
+	return ratios.Result.List, nil
+}
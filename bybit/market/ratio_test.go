@@ -0,0 +1,33 @@
+package market
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetLongShortRatio(t *testing.T) {
+	mock := bybittest.NewMock()
+	m := New(mock.Client())
+	ratios, err := m.GetLongShortRatio(context.Background(), "linear", "BTCUSDT", IntervalTime1h, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, ratios, 2) {
+		assert.True(t, ratios[0].BuyRatio.Equal(client.MustParseDecimal("0.5777")))
+		assert.True(t, ratios[0].SellRatio.Equal(client.MustParseDecimal("0.4223")))
+		assert.Equal(t, int64(1695772800000), ratios[0].Time().UnixMilli())
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "1h", requests[0].Query.Get("period"))
+		assert.Equal(t, "2", requests[0].Query.Get("limit"))
+	}
+
+	_, err = m.GetLongShortRatio(context.Background(), "spot", "BTCUSDT", IntervalTime1h, 0)
+	assert.Error(t, err)
+	_, err = m.GetLongShortRatio(context.Background(), "linear", "BTCUSDT", "2h", 0)
+	assert.Error(t, err)
+	assert.Len(t, mock.Requests(), 1)
+}
@@ -0,0 +1,106 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// ErrInvalidOrder is wrapped by the errors of ValidateOrder, which describe the violated
+// instrument filter.
+var ErrInvalidOrder = errors.New("invalid order")
+
+// RoundPrice returns price snapped to the nearest tick of the instrument.
+func (i Instrument) RoundPrice(price client.Decimal) client.Decimal {
+	return price.Round(i.TickSize())
+}
+
+// RoundQty returns qty rounded down to the quantity step of the instrument, so that an order
+// never exceeds the intended size.
+func (i Instrument) RoundQty(qty client.Decimal) client.Decimal {
+	return qty.Floor(i.QtyStep())
+}
+
+// ValidateOrder checks price and qty against the filters of the instrument, as Bybit would
+// before rejecting the order with retCode 10001. A zero price stands for a market order,
+// which skips the price and notional checks and is limited by the maximum market order
+// quantity of contracts.
+func (i Instrument) ValidateOrder(price, qty client.Decimal) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w for %s: %s", ErrInvalidOrder, i.Symbol, fmt.Sprintf(format, args...))
+	}
+	filter, lot := i.PriceFilter, i.LotSizeFilter
+	if price.Sign() < 0 {
+		return invalid("negative price %s", price)
+	}
+	if !price.IsZero() {
+		if !price.IsMultipleOf(filter.TickSize) {
+			return invalid("price %s is not a multiple of the tick size %s", price, filter.TickSize)
+		}
+		if !filter.MinPrice.IsZero() && price.Cmp(filter.MinPrice) < 0 {
+			return invalid("price %s is below the minimum %s", price, filter.MinPrice)
+		}
+		if !filter.MaxPrice.IsZero() && price.Cmp(filter.MaxPrice) > 0 {
+			return invalid("price %s is above the maximum %s", price, filter.MaxPrice)
+		}
+	}
+
+	if qty.Sign() <= 0 {
+		return invalid("quantity %s is not positive", qty)
+	}
+	if step := i.QtyStep(); !qty.IsMultipleOf(step) {
+		return invalid("quantity %s is not a multiple of the step %s", qty, step)
+	}
+	if !lot.MinOrderQty.IsZero() && qty.Cmp(lot.MinOrderQty) < 0 {
+		return invalid("quantity %s is below the minimum %s", qty, lot.MinOrderQty)
+	}
+	maxQty := lot.MaxOrderQty
+	if price.IsZero() && !lot.MaxMktOrderQty.IsZero() {
+		maxQty = lot.MaxMktOrderQty
+	}
+	if !maxQty.IsZero() && qty.Cmp(maxQty) > 0 {
+		return invalid("quantity %s is above the maximum %s", qty, maxQty)
+	}
+
+	if price.IsZero() {
+		return nil
+	}
+	notional := price.Mul(qty)
+	if minNotional := i.MinNotional(); !minNotional.IsZero() && notional.Cmp(minNotional) < 0 {
+		return invalid("order value %s is below the minimum %s", notional, minNotional)
+	}
+	if !lot.MaxOrderAmt.IsZero() && notional.Cmp(lot.MaxOrderAmt) > 0 {
+		return invalid("order value %s is above the maximum %s", notional, lot.MaxOrderAmt)
+	}
+	return nil
+}
+
+// RoundPrice returns price snapped to the tick size of symbol in category.
+func (c *InstrumentCache) RoundPrice(ctx context.Context, category, symbol string, price client.Decimal) (client.Decimal, error) {
+	instrument, err := c.Get(ctx, category, symbol)
+	if err != nil {
+		return client.Decimal{}, err
+	}
+	return instrument.RoundPrice(price), nil
+}
+
+// RoundQty returns qty rounded down to the quantity step of symbol in category.
+func (c *InstrumentCache) RoundQty(ctx context.Context, category, symbol string, qty client.Decimal) (client.Decimal, error) {
+	instrument, err := c.Get(ctx, category, symbol)
+	if err != nil {
+		return client.Decimal{}, err
+	}
+	return instrument.RoundQty(qty), nil
+}
+
+// ValidateOrder checks an order for symbol in category against the instrument's filters
+// before it is sent. A zero price stands for a market order.
+func (c *InstrumentCache) ValidateOrder(ctx context.Context, category, symbol string, price, qty client.Decimal) error {
+	instrument, err := c.Get(ctx, category, symbol)
+	if err != nil {
+		return err
+	}
+	return instrument.ValidateOrder(price, qty)
+}
@@ -0,0 +1,64 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestInstrumentCache_Rounding(t *testing.T) {
+	cache := NewInstrumentCache(New(bybittest.NewMock().Client()), 0)
+	ctx := context.Background()
+	d := client.MustParseDecimal
+
+	price, err := cache.RoundPrice(ctx, "linear", "BTCUSDT", d("16638.27"))
+	assert.NoError(t, err)
+	assert.Equal(t, "16638.3", price.String())
+	qty, err := cache.RoundQty(ctx, "linear", "BTCUSDT", d("0.0129"))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.012", qty.String())
+	_, err = cache.RoundQty(ctx, "linear", "ETHUSDT", d("1"))
+	assert.True(t, errors.Is(err, ErrUnknownInstrument))
+
+	assert.NoError(t, cache.ValidateOrder(ctx, "linear", "BTCUSDT", d("16638.3"), d("0.012")))
+	assert.NoError(t, cache.ValidateOrder(ctx, "linear", "BTCUSDT", client.Decimal{}, d("500")))
+	for _, tc := range []struct {
+		price, qty string
+	}{
+		{"16638.27", "0.012"},
+		{"0.05", "0.012"},
+		{"2000000", "0.012"},
+		{"16638.3", "0.0125"},
+		{"16638.3", "0"},
+		{"16638.3", "1191"},
+		{"0", "501"},
+		{"1000", "0.001"},
+	} {
+		err := cache.ValidateOrder(ctx, "linear", "BTCUSDT", d(tc.price), d(tc.qty))
+		assert.True(t, errors.Is(err, ErrInvalidOrder), "price %s qty %s: %v", tc.price, tc.qty, err)
+	}
+}
+
+func TestInstrument_ValidateSpotOrder(t *testing.T) {
+	d := client.MustParseDecimal
+	spot := Instrument{
+		Symbol:      "BTCUSDT",
+		PriceFilter: PriceFilter{TickSize: d("0.01")},
+		LotSizeFilter: LotSizeFilter{
+			BasePrecision: d("0.000001"),
+			MinOrderQty:   d("0.000048"),
+			MaxOrderQty:   d("71.73956243"),
+			MinOrderAmt:   d("1"),
+			MaxOrderAmt:   d("2000000"),
+		},
+	}
+	assert.Equal(t, "0.123456", spot.RoundQty(d("0.1234567")).String())
+	assert.NoError(t, spot.ValidateOrder(d("30000.01"), d("0.001")))
+	assert.Error(t, spot.ValidateOrder(d("30000"), d("0.00003")))
+	assert.Error(t, spot.ValidateOrder(d("30000"), d("70")))
+}
@@ -10,12 +10,12 @@ type APIResponse struct {
 
 // KlineRequest represents a request for querying historical klines
 type KlineRequest struct {
-	Category string `json:"category,omitempty"` // Optional: 'spot', 'linear', 'inverse'. Defaults to 'linear' if not specified.
-	Symbol   string `json:"symbol"`             // Required: Symbol name.
-	Interval string `json:"interval"`           // Required: Kline interval. Accepts '1', '3', '5', '15', '30', '60', '120', '240', '360', '720', 'D', 'M', 'W'.
-	Start    *int64 `json:"start,omitempty"`    // Optional: The start timestamp in milliseconds.
-	End      *int64 `json:"end,omitempty"`      // Optional: The end timestamp in milliseconds.
-	Limit    *int   `json:"limit,omitempty"`    // Optional: Limit the number of klines returned.
+	Category string   `json:"category,omitempty"` // Optional: 'spot', 'linear', 'inverse'. Defaults to 'linear' if not specified.
+	Symbol   string   `json:"symbol"`             // Required: Symbol name.
+	Interval Interval `json:"interval"`           // Required: Kline interval, one of the Interval constants.
+	Start    *int64   `json:"start,omitempty"`    // Optional: The start timestamp in milliseconds.
+	End      *int64   `json:"end,omitempty"`      // Optional: The end timestamp in milliseconds.
+	Limit    *int     `json:"limit,omitempty"`    // Optional: Limit the number of klines returned.
 }
 
 type KlineResult struct {
@@ -30,11 +30,13 @@ type ServerTimeResult struct {
 }
 
 type OrderBookResult struct {
-	S  string     `json:"s"`
-	A  [][]string `json:"a"`
-	B  [][]string `json:"b"`
-	TS int64      `json:"ts"`
-	U  int        `json:"u"`
+	S   string     `json:"s"`
+	A   [][]string `json:"a"`
+	B   [][]string `json:"b"`
+	TS  int64      `json:"ts"`
+	U   int        `json:"u"`
+	Seq int64      `json:"seq"`
+	CTS int64      `json:"cts"`
 }
 
 type RiskLimitResult struct {
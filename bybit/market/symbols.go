@@ -0,0 +1,65 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SymbolError reports a symbol that is not listed in the requested category, together with
+// the categories that do list it.
+type SymbolError struct {
+	Symbol   string
+	Category string
+	// Suggestions are the categories listing Symbol, empty if none does.
+	Suggestions []string
+}
+
+func (e *SymbolError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("symbol %s is not listed in any category", e.Symbol)
+	}
+	return fmt.Sprintf("symbol %s is not listed in %s, use category %s", e.Symbol, e.Category, strings.Join(e.Suggestions, " or "))
+}
+
+// Is makes a SymbolError match ErrUnknownInstrument.
+func (e *SymbolError) Is(target error) bool {
+	return target == ErrUnknownInstrument
+}
+
+// ValidateSymbol returns nil if category lists symbol. Otherwise it returns a *SymbolError
+// suggesting the categories that list it, so that a "BTCUSDT" passed with the wrong
+// category fails loudly instead of returning empty results.
+func (c *InstrumentCache) ValidateSymbol(ctx context.Context, category, symbol string) error {
+	_, err := c.Get(ctx, category, symbol)
+	if !errors.Is(err, ErrUnknownInstrument) {
+		return err
+	}
+	categories, err := c.InferCategories(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	return &SymbolError{Symbol: symbol, Category: category, Suggestions: categories}
+}
+
+// InferCategories returns the categories listing symbol: spot, linear or inverse. A
+// symbol such as "BTCUSDT" can be both a spot pair and a linear contract. Option symbols
+// such as "ETH-27DEC24-3000-C" are only looked up among the options of their base coin.
+func (c *InstrumentCache) InferCategories(ctx context.Context, symbol string) ([]string, error) {
+	candidates := []string{"spot", "linear", "inverse"}
+	if _, ok := optionBaseCoin(symbol); ok {
+		candidates = []string{"option"}
+	}
+	var categories []string
+	for _, category := range candidates {
+		_, err := c.Get(ctx, category, symbol)
+		switch {
+		case err == nil:
+			categories = append(categories, category)
+		case !errors.Is(err, ErrUnknownInstrument):
+			return nil, err
+		}
+	}
+	return categories, nil
+}
@@ -0,0 +1,80 @@
+package market
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+)
+
+func TestInstrumentCache_ValidateSymbol(t *testing.T) {
+	listings := map[string][]string{
+		"spot":    {"BTCUSDT", "ETHUSDT"},
+		"linear":  {"BTCUSDT", "BTCPERP"},
+		"inverse": {"BTCUSD"},
+		"option":  {"BTC-30DEC22-18000-C", "ETH-27DEC24-3000-C", "SOL-27DEC24-150-P"},
+	}
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/market/instruments-info", func(req bybittest.Request) string {
+		category := req.Query.Get("category")
+		var list []string
+		for _, symbol := range listings[category] {
+			if baseCoin := req.Query.Get("baseCoin"); baseCoin != "" && !strings.HasPrefix(symbol, baseCoin+"-") {
+				continue
+			}
+			list = append(list, fmt.Sprintf(`{"symbol":%q}`, symbol))
+		}
+		return fmt.Sprintf(`{"retCode":0,"retMsg":"OK","result":{"category":%q,"list":[%s],"nextPageCursor":""},"time":1}`, category, strings.Join(list, ","))
+	})
+	cache := NewInstrumentCache(New(mock.Client()), 0)
+	ctx := context.Background()
+
+	assert.NoError(t, cache.ValidateSymbol(ctx, "spot", "BTCUSDT"))
+
+	err := cache.ValidateSymbol(ctx, "inverse", "BTCUSDT")
+	var symbolErr *SymbolError
+	if assert.True(t, errors.As(err, &symbolErr)) {
+		assert.Equal(t, []string{"spot", "linear"}, symbolErr.Suggestions)
+		assert.Equal(t, "symbol BTCUSDT is not listed in inverse, use category spot or linear", err.Error())
+	}
+	assert.True(t, errors.Is(err, ErrUnknownInstrument))
+
+	for _, symbol := range []string{"BTC-30DEC22-18000-C", "ETH-27DEC24-3000-C", "SOL-27DEC24-150-P"} {
+		categories, err := cache.InferCategories(ctx, symbol)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"option"}, categories)
+		assert.NoError(t, cache.ValidateSymbol(ctx, "option", symbol))
+	}
+
+	var baseCoins []string
+	for _, req := range mock.Requests() {
+		if req.Query.Get("category") == "option" {
+			baseCoins = append(baseCoins, req.Query.Get("baseCoin"))
+		}
+	}
+	assert.Equal(t, []string{"BTC", "ETH", "SOL"}, baseCoins)
+
+	err = cache.ValidateSymbol(ctx, "linear", "ETH-27DEC24-3000-C")
+	if assert.True(t, errors.As(err, &symbolErr)) {
+		assert.Equal(t, []string{"option"}, symbolErr.Suggestions)
+	}
+	err = cache.ValidateSymbol(ctx, "option", "ETH-27DEC24-4000-C")
+	if assert.True(t, errors.As(err, &symbolErr)) {
+		assert.Empty(t, symbolErr.Suggestions)
+	}
+	err = cache.ValidateSymbol(ctx, "option", "ETHUSDT")
+	if assert.True(t, errors.As(err, &symbolErr)) {
+		assert.Equal(t, []string{"spot"}, symbolErr.Suggestions)
+	}
+
+	err = cache.ValidateSymbol(ctx, "linear", "DOGEBTC")
+	if assert.True(t, errors.As(err, &symbolErr)) {
+		assert.Empty(t, symbolErr.Suggestions)
+	}
+}
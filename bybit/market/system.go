@@ -0,0 +1,131 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// Time returns the server time, to the nanosecond when Bybit reports it.
+func (r ServerTimeResult) Time() (time.Time, error) {
+	return client.ParseServerTime(r.TimeSecond, r.TimeNano)
+}
+
+// Maintenance states reported by the system status endpoint.
+const (
+	MaintenanceScheduled = "scheduled"
+	MaintenanceOngoing   = "ongoing"
+	MaintenanceCompleted = "completed"
+	MaintenanceCanceled  = "canceled"
+)
+
+// Maintenance is a planned or ongoing maintenance window.
+type Maintenance struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	State        string `json:"state"`
+	Begin        string `json:"begin"`
+	End          string `json:"end"`
+	Href         string `json:"href"`
+	ServiceTypes []int  `json:"serviceTypes"`
+	Product      []int  `json:"product"`
+	UIDSuffix    []int  `json:"uidSuffix"`
+	MaintainType string `json:"maintainType"`
+	Env          string `json:"env"`
+}
+
+// Window returns the begin and end of the maintenance.
+func (m Maintenance) Window() (begin, end time.Time, err error) {
+	beginMs, err := strconv.ParseInt(m.Begin, 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid maintenance begin %q: %v", m.Begin, err)
+	}
+	endMs, err := strconv.ParseInt(m.End, 10, 64)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid maintenance end %q: %v", m.End, err)
+	}
+	return time.UnixMilli(beginMs), time.UnixMilli(endMs), nil
+}
+
+// ActiveAt reports whether the maintenance is not canceled or completed and t falls within
+// its window.
+func (m Maintenance) ActiveAt(t time.Time) bool {
+	if m.State == MaintenanceCanceled || m.State == MaintenanceCompleted {
+		return false
+	}
+	begin, end, err := m.Window()
+	if err != nil {
+		return m.State == MaintenanceOngoing
+	}
+	return !t.Before(begin) && t.Before(end)
+}
+
+type SystemStatus struct {
+	List []Maintenance `json:"list"`
+}
+
+type SystemStatusResponse struct {
+	APIBaseResponse
+	Result SystemStatus `json:"result"`
+}
+
+// InMaintenance reports whether any listed maintenance is active at t.
+func (s *SystemStatus) InMaintenance(t time.Time) bool {
+	for _, m := range s.List {
+		if m.ActiveAt(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Upcoming returns the maintenances that are scheduled to begin after t.
+func (s *SystemStatus) Upcoming(t time.Time) []Maintenance {
+	var upcoming []Maintenance
+	for _, m := range s.List {
+		if m.State != MaintenanceScheduled {
+			continue
+		}
+		if begin, _, err := m.Window(); err == nil && begin.After(t) {
+			upcoming = append(upcoming, m)
+		}
+	}
+	return upcoming
+}
+
+func (m *marketImpl) SystemStatus(ctx context.Context, params *client.Params) (*SystemStatusResponse, error) {
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/system/status", client.APIVersion), *params)
+	if err != nil {
+		return nil, err
+	}
+	var status SystemStatusResponse
+	if err := res.Unmarshal(&status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// WatchSystemStatus polls the system status every interval until ctx is done and passes each
+// result to callback, so that an application can pause trading while InMaintenance reports
+// true. Failed polls are passed to onError when it is not nil and retried on the next tick.
+func WatchSystemStatus(ctx context.Context, m Market, interval time.Duration, callback func(*SystemStatus), onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		res, err := m.SystemStatus(ctx, &client.Params{})
+		if err == nil {
+			callback(&res.Result)
+		} else if onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
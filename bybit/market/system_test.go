@@ -0,0 +1,32 @@
+package market
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+)
+
+func TestGetServerTime(t *testing.T) {
+	now, err := New(bybittest.NewMock().Client()).GetServerTime(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1688639403423213947), now.UnixNano())
+}
+
+func TestSystemStatus_InMaintenance(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	status := SystemStatus{List: []Maintenance{
+		{ID: "1", State: MaintenanceOngoing, Begin: "1699999000000", End: "1700001000000"},
+		{ID: "2", State: MaintenanceScheduled, Begin: "1700100000000", End: "1700200000000"},
+		{ID: "3", State: MaintenanceCanceled, Begin: "1699999000000", End: "1700001000000"},
+	}}
+
+	assert.True(t, status.InMaintenance(now))
+	assert.False(t, status.InMaintenance(now.Add(time.Hour)))
+	if upcoming := status.Upcoming(now); assert.Len(t, upcoming, 1) {
+		assert.Equal(t, "2", upcoming[0].ID)
+	}
+}
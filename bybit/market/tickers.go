@@ -0,0 +1,94 @@
+package market
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// Ticker is the typed market summary of a symbol. Which fields are set depends on the
+// category: funding and open interest for linear and inverse contracts, the USD index
+// price for spot pairs, and implied volatility and greeks for options. Fields that do not
+// apply are zero.
+type Ticker struct {
+	Symbol       string         `json:"symbol"`
+	LastPrice    client.Decimal `json:"lastPrice"`
+	IndexPrice   client.Decimal `json:"indexPrice"`
+	MarkPrice    client.Decimal `json:"markPrice"`
+	Bid1Price    client.Decimal `json:"bid1Price"`
+	Bid1Size     client.Decimal `json:"bid1Size"`
+	Ask1Price    client.Decimal `json:"ask1Price"`
+	Ask1Size     client.Decimal `json:"ask1Size"`
+	PrevPrice24H client.Decimal `json:"prevPrice24h"`
+	Price24HPcnt client.Decimal `json:"price24hPcnt"`
+	HighPrice24H client.Decimal `json:"highPrice24h"`
+	LowPrice24H  client.Decimal `json:"lowPrice24h"`
+	Volume24H    client.Decimal `json:"volume24h"`
+	Turnover24H  client.Decimal `json:"turnover24h"`
+
+	// Spot
+	USDIndexPrice client.Decimal `json:"usdIndexPrice"`
+
+	// Linear and inverse contracts
+	PrevPrice1H       client.Decimal `json:"prevPrice1h"`
+	OpenInterest      client.Decimal `json:"openInterest"`
+	OpenInterestValue client.Decimal `json:"openInterestValue"`
+	FundingRate       client.Decimal `json:"fundingRate"`
+	// NextFundingTime, DeliveryTime are Unix milliseconds, zero when not applicable.
+	NextFundingTime        client.Int     `json:"nextFundingTime"`
+	PredictedDeliveryPrice client.Decimal `json:"predictedDeliveryPrice"`
+	BasisRate              client.Decimal `json:"basisRate"`
+	Basis                  client.Decimal `json:"basis"`
+	DeliveryFeeRate        client.Decimal `json:"deliveryFeeRate"`
+	DeliveryTime           client.Int     `json:"deliveryTime"`
+
+	// Options
+	Bid1Iv          client.Decimal `json:"bid1Iv"`
+	Ask1Iv          client.Decimal `json:"ask1Iv"`
+	MarkIv          client.Decimal `json:"markIv"`
+	UnderlyingPrice client.Decimal `json:"underlyingPrice"`
+	TotalVolume     client.Decimal `json:"totalVolume"`
+	TotalTurnover   client.Decimal `json:"totalTurnover"`
+	Change24H       client.Decimal `json:"change24h"`
+	Delta           client.Decimal `json:"delta"`
+	Gamma           client.Decimal `json:"gamma"`
+	Vega            client.Decimal `json:"vega"`
+	Theta           client.Decimal `json:"theta"`
+}
+
+// TickersResponse is the typed response of the /v5/market/tickers endpoint.
+type TickersResponse struct {
+	APIBaseResponse
+	Result struct {
+		Category string   `json:"category"`
+		List     []Ticker `json:"list"`
+	} `json:"result"`
+}
+
+// GetTickers returns the tickers of category, of symbol only when it is not empty. baseCoin
+// and expDate, such as "25DEC22", filter option tickers; an option request needs a symbol
+// or a baseCoin.
+func (m *marketImpl) GetTickers(ctx context.Context, category, symbol, baseCoin, expDate string) ([]Ticker, error) {
+	if category != "option" && (baseCoin != "" || expDate != "") {
+		return nil, fmt.Errorf("tickers: baseCoin and expDate only apply to options")
+	}
+	if category == "option" && symbol == "" && baseCoin == "" {
+		return nil, fmt.Errorf("tickers: option tickers need a symbol or a baseCoin")
+	}
+	params := client.Params{"category": category}
+	for key, value := range map[string]string{"symbol": symbol, "baseCoin": baseCoin, "expDate": expDate} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/tickers", client.APIVersion), params)
+	if err != nil {
+		return nil, err
+	}
+	var tickers TickersResponse
+	if err := res.Unmarshal(&tickers); err != nil {
+		return nil, err
+	}
+	return tickers.Result.List, nil
+}
@@ -0,0 +1,46 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetTickers(t *testing.T) {
+	mock := bybittest.NewMock()
+	m := New(mock.Client())
+	tickers, err := m.GetTickers(context.Background(), "linear", "BTCUSDT", "", "")
+	assert.NoError(t, err)
+	if assert.Len(t, tickers, 1) {
+		btc := tickers[0]
+		assert.True(t, btc.FundingRate.Equal(client.MustParseDecimal("-0.000212")))
+		assert.True(t, btc.OpenInterest.Equal(client.MustParseDecimal("373504107")))
+		assert.True(t, btc.Bid1Price.Equal(client.MustParseDecimal("16596")))
+		assert.EqualValues(t, 1673280000000, btc.NextFundingTime)
+		assert.True(t, btc.Basis.IsZero())
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "BTCUSDT", requests[0].Query.Get("symbol"))
+		assert.False(t, requests[0].Query.Has("baseCoin"))
+	}
+
+	_, err = m.GetTickers(context.Background(), "spot", "", "BTC", "")
+	assert.Error(t, err)
+	_, err = m.GetTickers(context.Background(), "option", "", "", "25DEC22")
+	assert.Error(t, err)
+	assert.Len(t, mock.Requests(), 1)
+}
+
+func TestTicker_Option(t *testing.T) {
+	var option Ticker
+	err := json.Unmarshal([]byte(`{"symbol":"BTC-30DEC22-18000-C","bid1Price":"0","bid1Size":"0","bid1Iv":"0","ask1Price":"435","ask1Size":"0.66","ask1Iv":"5","lastPrice":"435","highPrice24h":"435","lowPrice24h":"165","markPrice":"0.00000009","indexPrice":"16600.55","markIv":"0.7567","underlyingPrice":"16590.42","openInterest":"6.3","turnover24h":"2482.73","volume24h":"0.15","totalVolume":"99","totalTurnover":"1967653","delta":"0.00000001","gamma":"0.00000001","vega":"0.00000004","theta":"-0.00000152","predictedDeliveryPrice":"0","change24h":"86"}`), &option)
+	assert.NoError(t, err)
+	assert.True(t, option.MarkIv.Equal(client.MustParseDecimal("0.7567")))
+	assert.True(t, option.Theta.Equal(client.MustParseDecimal("-0.00000152")))
+	assert.True(t, option.UnderlyingPrice.Equal(client.MustParseDecimal("16590.42")))
+}
@@ -0,0 +1,70 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// PublicTrade is a typed public execution. The mark and index price and implied volatility
+// fields are only set for options.
+type PublicTrade struct {
+	ExecID       string         `json:"execId"`
+	Symbol       string         `json:"symbol"`
+	Price        client.Decimal `json:"price"`
+	Size         client.Decimal `json:"size"`
+	Side         string         `json:"side"`
+	Time         client.Int     `json:"time"`
+	IsBlockTrade bool           `json:"isBlockTrade"`
+	MarkPrice    client.Decimal `json:"mP"`
+	IndexPrice   client.Decimal `json:"iP"`
+	MarkIv       client.Decimal `json:"mIv"`
+	Iv           client.Decimal `json:"iv"`
+}
+
+// ExecutedAt returns the execution time of the trade.
+func (t PublicTrade) ExecutedAt() time.Time {
+	return time.UnixMilli(int64(t.Time))
+}
+
+// PublicTradesResponse is the typed response of the /v5/market/recent-trade endpoint.
+type PublicTradesResponse struct {
+	APIBaseResponse
+	Result struct {
+		Category string        `json:"category"`
+		List     []PublicTrade `json:"list"`
+	} `json:"result"`
+}
+
+// GetPublicRecentTrades returns the latest public trades of category, newest first. Option
+// trades are selected by symbol or by baseCoin, and optionType, "Call" or "Put", narrows a
+// baseCoin request; both are rejected for other categories. A zero limit is left to
+// Bybit's default: 60 trades for spot and 500 for the other categories.
+func (m *marketImpl) GetPublicRecentTrades(ctx context.Context, category, symbol, baseCoin, optionType string, limit int) ([]PublicTrade, error) {
+	if category != "option" && (baseCoin != "" || optionType != "") {
+		return nil, fmt.Errorf("recent trades: baseCoin and optionType only apply to options")
+	}
+	if category != "option" && symbol == "" {
+		return nil, fmt.Errorf("recent trades: symbol is required for %s", category)
+	}
+	params := client.Params{"category": category}
+	for key, value := range map[string]string{"symbol": symbol, "baseCoin": baseCoin, "optionType": optionType} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/recent-trade", client.APIVersion), params)
+	if err != nil {
+		return nil, err
+	}
+	var trades PublicTradesResponse
+	if err := res.Unmarshal(&trades); err != nil {
+		return nil, err
+	}
+	return trades.Result.List, nil
+}
@@ -0,0 +1,46 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetPublicRecentTrades(t *testing.T) {
+	mock := bybittest.NewMock()
+	m := New(mock.Client())
+	trades, err := m.GetPublicRecentTrades(context.Background(), "spot", "BTCUSDT", "", "", 2)
+	assert.NoError(t, err)
+	if assert.Len(t, trades, 2) {
+		assert.Equal(t, "2100000000007764263", trades[0].ExecID)
+		assert.True(t, trades[0].Price.Equal(client.MustParseDecimal("16618.49")))
+		assert.True(t, trades[1].Size.Equal(client.MustParseDecimal("0.0505")))
+		assert.True(t, trades[1].IsBlockTrade)
+		assert.Equal(t, int64(1672052955758), trades[0].ExecutedAt().UnixMilli())
+		assert.True(t, trades[0].MarkPrice.IsZero())
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "/v5/market/recent-trade", requests[0].Path)
+		assert.Equal(t, "2", requests[0].Query.Get("limit"))
+		assert.False(t, requests[0].Query.Has("optionType"))
+	}
+
+	_, err = m.GetPublicRecentTrades(context.Background(), "linear", "", "", "", 0)
+	assert.Error(t, err)
+	_, err = m.GetPublicRecentTrades(context.Background(), "spot", "BTCUSDT", "", "Call", 0)
+	assert.Error(t, err)
+	assert.Len(t, mock.Requests(), 1)
+}
+
+func TestPublicTrade_Option(t *testing.T) {
+	var trade PublicTrade
+	err := json.Unmarshal([]byte(`{"execId":"1","symbol":"BTC-30DEC22-18000-C","price":"435","size":"0.1","side":"Buy","time":"1672052955758","isBlockTrade":false,"mP":"434.5","iP":"16600.55","mIv":"0.7567","iv":"0.7601"}`), &trade)
+	assert.NoError(t, err)
+	assert.True(t, trade.MarkIv.Equal(client.MustParseDecimal("0.7567")))
+	assert.True(t, trade.IndexPrice.Equal(client.MustParseDecimal("16600.55")))
+}
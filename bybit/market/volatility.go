@@ -0,0 +1,66 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// VolatilityPoint is the annualised historical volatility of an option base coin over
+// Period days, as a fraction.
+type VolatilityPoint struct {
+	Period int            `json:"period"`
+	Value  client.Decimal `json:"value"`
+	Time   client.Int     `json:"time"`
+}
+
+// At returns the time of the point.
+func (p VolatilityPoint) At() time.Time {
+	return time.UnixMilli(int64(p.Time))
+}
+
+// HistoricalVolatilityResponse is the typed response of the
+// /v5/market/historical-volatility endpoint.
+type HistoricalVolatilityResponse struct {
+	APIBaseResponse
+	Category string            `json:"category"`
+	Result   []VolatilityPoint `json:"result"`
+}
+
+// volatilityPeriods are the periods, in days, Bybit computes historical volatility over.
+var volatilityPeriods = map[int]bool{7: true, 14: true, 21: true, 30: true, 60: true, 90: true, 180: true, 270: true}
+
+// GetHistoricalVolatility returns the historical volatility of the options of baseCoin
+// over period days, one of 7, 14, 21, 30, 60, 90, 180 or 270. An empty baseCoin, a zero
+// period and zero start and end times are left to Bybit's defaults: BTC, 7 days and the
+// latest hour. Bybit limits a start to end range to 30 days within the past two years.
+func (m *marketImpl) GetHistoricalVolatility(ctx context.Context, baseCoin string, period int, start, end time.Time) ([]VolatilityPoint, error) {
+	if period != 0 && !volatilityPeriods[period] {
+		return nil, fmt.Errorf("historical volatility: invalid period %d", period)
+	}
+	if start.IsZero() != end.IsZero() {
+		return nil, fmt.Errorf("historical volatility: start and end must be set together")
+	}
+	params := client.Params{"category": "option"}
+	if baseCoin != "" {
+		params["baseCoin"] = baseCoin
+	}
+	if period != 0 {
+		params["period"] = period
+	}
+	if !start.IsZero() {
+		params["startTime"] = start.UnixMilli()
+		params["endTime"] = end.UnixMilli()
+	}
+	res, err := m.c.GetContext(ctx, fmt.Sprintf("/%s/market/historical-volatility", client.APIVersion), params)
+	if err != nil {
+		return nil, err
+	}
+	var volatility HistoricalVolatilityResponse
+	if err := res.Unmarshal(&volatility); err != nil {
+		return nil, err
+	}
+	return volatility.Result, nil
+}
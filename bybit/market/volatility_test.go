@@ -0,0 +1,37 @@
+package market
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetHistoricalVolatility(t *testing.T) {
+	mock := bybittest.NewMock()
+	m := New(mock.Client())
+	end := time.UnixMilli(1672232400000)
+	points, err := m.GetHistoricalVolatility(context.Background(), "ETH", 7, end.Add(-time.Hour), end)
+	assert.NoError(t, err)
+	if assert.Len(t, points, 2) {
+		assert.Equal(t, 7, points[0].Period)
+		assert.True(t, points[0].Value.Equal(client.MustParseDecimal("0.2754562")))
+		assert.True(t, points[0].At().Equal(end))
+	}
+	if requests := mock.Requests(); assert.Len(t, requests, 1) {
+		assert.Equal(t, "/v5/market/historical-volatility", requests[0].Path)
+		assert.Equal(t, "option", requests[0].Query.Get("category"))
+		assert.Equal(t, "ETH", requests[0].Query.Get("baseCoin"))
+		assert.Equal(t, "1672228800000", requests[0].Query.Get("startTime"))
+	}
+
+	_, err = m.GetHistoricalVolatility(context.Background(), "BTC", 8, time.Time{}, time.Time{})
+	assert.Error(t, err)
+	_, err = m.GetHistoricalVolatility(context.Background(), "BTC", 0, end, time.Time{})
+	assert.Error(t, err)
+	assert.Len(t, mock.Requests(), 1)
+}
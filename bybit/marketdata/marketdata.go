@@ -0,0 +1,259 @@
+// Package marketdata defines category independent market data types and converts the REST
+// and WebSocket payloads of the other packages into them, so that strategy code does not
+// depend on category specific structs. Prices and sizes are client.Decimal, as in the
+// market package, and timestamps are time.Time.
+package marketdata
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/kline"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/orderbook"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/ticker"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/trade"
+)
+
+// Candle is an OHLCV bar.
+type Candle struct {
+	Symbol    string
+	Interval  market.Interval
+	Start     time.Time
+	Open      client.Decimal
+	High      client.Decimal
+	Low       client.Decimal
+	Close     client.Decimal
+	Volume    client.Decimal
+	Turnover  client.Decimal
+	Confirmed bool
+}
+
+// Ticker is a market summary. Fields that the source category does not publish are zero.
+type Ticker struct {
+	Symbol      string
+	Time        time.Time
+	LastPrice   client.Decimal
+	MarkPrice   client.Decimal
+	IndexPrice  client.Decimal
+	BidPrice    client.Decimal
+	BidSize     client.Decimal
+	AskPrice    client.Decimal
+	AskSize     client.Decimal
+	Volume24H   client.Decimal
+	Turnover24H client.Decimal
+	FundingRate client.Decimal
+}
+
+// Side is the taker side of a trade.
+type Side string
+
+// Trade sides.
+const (
+	Buy  Side = "Buy"
+	Sell Side = "Sell"
+)
+
+// Trade is a single public trade.
+type Trade struct {
+	ID         string
+	Symbol     string
+	Time       time.Time
+	Side       Side
+	Price      client.Decimal
+	Size       client.Decimal
+	BlockTrade bool
+}
+
+// BookLevel is an order book price level.
+type BookLevel struct {
+	Price client.Decimal
+	Size  client.Decimal
+}
+
+// CandleFromWS converts a kline stream update of symbol.
+func CandleFromWS(symbol string, d kline.Data) (Candle, error) {
+	values, err := parseDecimals(d.Open, d.High, d.Low, d.Close, d.Volume, d.Turnover)
+	if err != nil {
+		return Candle{}, fmt.Errorf("invalid kline of %s: %v", symbol, err)
+	}
+	return Candle{
+		Symbol:    symbol,
+		Interval:  market.Interval(d.Interval),
+		Start:     time.UnixMilli(d.Start),
+		Open:      values[0],
+		High:      values[1],
+		Low:       values[2],
+		Close:     values[3],
+		Volume:    values[4],
+		Turnover:  values[5],
+		Confirmed: d.Confirm,
+	}, nil
+}
+
+// CandlesFromREST converts the result of a REST kline request made with interval. Every
+// returned candle is confirmed except the most recent one, which Bybit lists first and
+// which may still be open.
+func CandlesFromREST(res market.KlineResult, interval market.Interval) ([]Candle, error) {
+	candles := make([]Candle, len(res.List))
+	for i, row := range res.List {
+		if len(row) < 7 {
+			return nil, fmt.Errorf("malformed kline of %s: %v", res.Symbol, row)
+		}
+		start, err := strconv.ParseInt(row[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kline start %q: %v", row[0], err)
+		}
+		values, err := parseDecimals(row[1:7]...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kline of %s: %v", res.Symbol, err)
+		}
+		candles[i] = Candle{
+			Symbol:    res.Symbol,
+			Interval:  interval,
+			Start:     time.UnixMilli(start),
+			Open:      values[0],
+			High:      values[1],
+			Low:       values[2],
+			Close:     values[3],
+			Volume:    values[4],
+			Turnover:  values[5],
+			Confirmed: i > 0,
+		}
+	}
+	return candles, nil
+}
+
+// TickerFromWS converts a linear, inverse or option ticker update received at at.
+func TickerFromWS(d ticker.Data, at time.Time) (Ticker, error) {
+	bidPrice, bidSize, askPrice, askSize := d.Bid1Price, d.Bid1Size, d.Ask1Price, d.Ask1Size
+	if bidPrice == "" && askPrice == "" {
+		bidPrice, bidSize, askPrice, askSize = d.BidPrice, d.BidSize, d.AskPrice, d.AskSize
+	}
+	values, err := parseDecimals(d.LastPrice, d.MarkPrice, d.IndexPrice, bidPrice, bidSize, askPrice, askSize,
+		d.Volume24H, d.Turnover24H, d.FundingRate)
+	if err != nil {
+		return Ticker{}, fmt.Errorf("invalid ticker of %s: %v", d.Symbol, err)
+	}
+	return newTicker(d.Symbol, at, values), nil
+}
+
+// TickerFromSpotWS converts a spot ticker update received at at.
+func TickerFromSpotWS(d ticker.SpotData, at time.Time) (Ticker, error) {
+	values, err := parseDecimals(d.LastPrice, "", d.USDIndexPrice, "", "", "", "", d.Volume24H, d.Turnover24H, "")
+	if err != nil {
+		return Ticker{}, fmt.Errorf("invalid ticker of %s: %v", d.Symbol, err)
+	}
+	return newTicker(d.Symbol, at, values), nil
+}
+
+// TickerFromREST converts an entry of a REST tickers response received at at.
+func TickerFromREST(t market.TickerInfo, at time.Time) (Ticker, error) {
+	values, err := parseDecimals(t.LastPrice, t.MarkPrice, t.IndexPrice, t.Bid1Price, t.Bid1Size, t.Ask1Price, t.Ask1Size,
+		t.Volume24H, t.Turnover24H, t.FundingRate)
+	if err != nil {
+		return Ticker{}, fmt.Errorf("invalid ticker of %s: %v", t.Symbol, err)
+	}
+	return newTicker(t.Symbol, at, values), nil
+}
+
+// newTicker builds a Ticker from the values parsed by the Ticker converters, in field order.
+func newTicker(symbol string, at time.Time, values []client.Decimal) Ticker {
+	return Ticker{
+		Symbol:      symbol,
+		Time:        at,
+		LastPrice:   values[0],
+		MarkPrice:   values[1],
+		IndexPrice:  values[2],
+		BidPrice:    values[3],
+		BidSize:     values[4],
+		AskPrice:    values[5],
+		AskSize:     values[6],
+		Volume24H:   values[7],
+		Turnover24H: values[8],
+		FundingRate: values[9],
+	}
+}
+
+// TradeFromWS converts a public trade stream update.
+func TradeFromWS(d trade.Data) Trade {
+	return Trade{
+		ID:         d.TradeID,
+		Symbol:     d.Symbol,
+		Time:       time.UnixMilli(d.Timestamp),
+		Side:       Side(d.Side),
+		Price:      floatDecimal(d.Price),
+		Size:       floatDecimal(d.Size),
+		BlockTrade: d.BlockTrade,
+	}
+}
+
+// TradeFromREST converts an entry of a REST recent trades response.
+func TradeFromREST(item market.ResendTradeItem) (Trade, error) {
+	ts, err := strconv.ParseInt(item.Time, 10, 64)
+	if err != nil {
+		return Trade{}, fmt.Errorf("invalid trade time %q: %v", item.Time, err)
+	}
+	values, err := parseDecimals(item.Price, item.Size)
+	if err != nil {
+		return Trade{}, fmt.Errorf("invalid trade of %s: %v", item.Symbol, err)
+	}
+	return Trade{
+		ID:         item.ExecID,
+		Symbol:     item.Symbol,
+		Time:       time.UnixMilli(ts),
+		Side:       Side(item.Side),
+		Price:      values[0],
+		Size:       values[1],
+		BlockTrade: item.IsBlockTrade,
+	}, nil
+}
+
+// BookLevels converts the [price, size] pairs of a REST or WebSocket order book payload.
+func BookLevels(entries [][]string) ([]BookLevel, error) {
+	levels := make([]BookLevel, len(entries))
+	for i, entry := range entries {
+		if len(entry) < 2 {
+			return nil, fmt.Errorf("malformed order book level %v", entry)
+		}
+		values, err := parseDecimals(entry[0], entry[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid order book level: %v", err)
+		}
+		levels[i] = BookLevel{Price: values[0], Size: values[1]}
+	}
+	return levels, nil
+}
+
+// BookLevelsFromBook converts the levels returned by orderbook.Book.
+func BookLevelsFromBook(levels []orderbook.Level) ([]BookLevel, error) {
+	entries := make([][]string, len(levels))
+	for i, level := range levels {
+		entries[i] = []string{level.Price, level.Size}
+	}
+	return BookLevels(entries)
+}
+
+// parseDecimals parses decimal strings, treating an empty string as zero.
+func parseDecimals(values ...string) ([]client.Decimal, error) {
+	parsed := make([]client.Decimal, len(values))
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		d, err := client.ParseDecimal(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decimal %q", value)
+		}
+		parsed[i] = d
+	}
+	return parsed, nil
+}
+
+// floatDecimal converts a number the trade stream has already parsed. Formatting it with the
+// fewest digits that round trip restores the decimal string Bybit sent.
+func floatDecimal(f float64) client.Decimal {
+	return client.MustParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
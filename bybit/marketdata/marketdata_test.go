@@ -0,0 +1,65 @@
+package marketdata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/kline"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/ticker"
+	wstrade "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/trade"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandles(t *testing.T) {
+	ws, err := CandleFromWS("BTCUSDT", kline.Data{Start: 1672324800000, Interval: "5", Open: "16649.5", High: "16677", Low: "16608", Close: "16677", Volume: "2.081", Turnover: "34666.4005", Confirm: true})
+	assert.NoError(t, err)
+
+	rest, err := CandlesFromREST(market.KlineResult{Symbol: "BTCUSDT", List: [][]string{
+		{"1672325100000", "16677", "16680", "16670", "16675", "1", "16675"},
+		{"1672324800000", "16649.5", "16677", "16608", "16677", "2.081", "34666.4005"},
+	}}, market.Interval5m)
+	if assert.NoError(t, err) && assert.Len(t, rest, 2) {
+		assert.False(t, rest[0].Confirmed)
+		assert.Equal(t, ws, rest[1])
+	}
+
+	_, err = CandleFromWS("BTCUSDT", kline.Data{Open: "x"})
+	assert.Error(t, err)
+}
+
+func TestTickers(t *testing.T) {
+	at := time.UnixMilli(1)
+	linear, err := TickerFromWS(ticker.Data{Symbol: "BTCUSDT", LastPrice: "100", Bid1Price: "99", Ask1Price: "101", FundingRate: "0.0001"}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, Ticker{Symbol: "BTCUSDT", Time: at, LastPrice: client.MustParseDecimal("100"), BidPrice: client.MustParseDecimal("99"),
+		AskPrice: client.MustParseDecimal("101"), FundingRate: client.MustParseDecimal("0.0001")}, linear)
+
+	option, err := TickerFromWS(ticker.Data{Symbol: "BTC-6JAN23-17500-C", BidPrice: "5", AskPrice: "6"}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", option.BidPrice.String())
+	assert.Equal(t, "6", option.AskPrice.String())
+
+	rest, err := TickerFromREST(market.TickerInfo{Symbol: "BTCUSDT", LastPrice: "100", Bid1Price: "99", Ask1Price: "101", FundingRate: "0.0001"}, at)
+	assert.NoError(t, err)
+	assert.Equal(t, linear, rest)
+}
+
+func TestTradesAndLevels(t *testing.T) {
+	trade, err := TradeFromREST(market.ResendTradeItem{ExecID: "t-1", Symbol: "BTCUSDT", Side: "Sell", Price: "16578.5", Size: "0.003", Time: "1672052955758"})
+	assert.NoError(t, err)
+	assert.Equal(t, Sell, trade.Side)
+	assert.Equal(t, "16578.5", trade.Price.String())
+	assert.Equal(t, time.UnixMilli(1672052955758), trade.Time)
+
+	ws := TradeFromWS(wstrade.Data{TradeID: "t-1", Symbol: "BTCUSDT", Side: "Sell", Price: 16578.5, Size: 0.003, Timestamp: 1672052955758})
+	assert.Equal(t, trade, ws)
+
+	levels, err := BookLevels([][]string{{"16493.50", "0.006"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []BookLevel{{Price: client.MustParseDecimal("16493.5"), Size: client.MustParseDecimal("0.006")}}, levels)
+
+	_, err = BookLevels([][]string{{"1"}})
+	assert.Error(t, err)
+}
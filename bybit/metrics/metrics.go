@@ -0,0 +1,150 @@
+// Package metrics exports the activity of the Bybit REST and WebSocket clients as
+// Prometheus metrics:
+//
+//	m := metrics.New()
+//	if err := m.Register(nil); err != nil { // nil registers with prometheus.DefaultRegisterer
+//		return err
+//	}
+//	rest := client.NewClient(key, secret, false, client.WithRequestObserver(m))
+//	ws, err := wsClient.NewClient(wsClient.WithMetrics(m))
+//
+// The exported metrics are:
+//
+//	bybit_rest_requests_total{method, endpoint, status, ret_code}
+//	bybit_rest_request_duration_seconds{method, endpoint}
+//	bybit_ws_messages_total{topic}
+//	bybit_ws_handler_duration_seconds{topic}
+//	bybit_ws_handler_lag_seconds{topic}
+//	bybit_ws_reconnects_total{result}
+//
+// The handler lag is the time from Bybit timestamping a message until its handlers
+// returned, which grows when handlers fall behind the stream.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	wsClient "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+const namespace = "bybit"
+
+// Metrics collects the metrics of any number of REST and WebSocket clients. It implements
+// client.RequestObserver, wsClient.MetricsObserver and prometheus.Collector.
+type Metrics struct {
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	messages        *prometheus.CounterVec
+	handlerDuration *prometheus.HistogramVec
+	handlerLag      *prometheus.HistogramVec
+	reconnects      *prometheus.CounterVec
+}
+
+var (
+	_ client.RequestObserver   = (*Metrics)(nil)
+	_ wsClient.MetricsObserver = (*Metrics)(nil)
+	_ prometheus.Collector     = (*Metrics)(nil)
+)
+
+// New creates the metrics. They are exported once registered, see Register.
+func New() *Metrics {
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "rest",
+			Name:      "requests_total",
+			Help:      "REST requests by endpoint, HTTP status and Bybit return code. The status is 0 when no response was received.",
+		}, []string{"method", "endpoint", "status", "ret_code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "rest",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of REST requests.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "endpoint"}),
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ws",
+			Name:      "messages_total",
+			Help:      "WebSocket messages received by topic.",
+		}, []string{"topic"}),
+		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "ws",
+			Name:      "handler_duration_seconds",
+			Help:      "Time the handlers of a topic took to process a message.",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+		}, []string{"topic"}),
+		handlerLag: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "ws",
+			Name:      "handler_lag_seconds",
+			Help:      "Time from Bybit timestamping a message until its handlers returned.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"topic"}),
+		reconnects: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ws",
+			Name:      "reconnects_total",
+			Help:      "WebSocket reconnection attempts by result.",
+		}, []string{"result"}),
+	}
+}
+
+// Register registers the metrics with registerer, or with prometheus.DefaultRegisterer
+// when registerer is nil.
+func (m *Metrics) Register(registerer prometheus.Registerer) error {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	return registerer.Register(m)
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.requestDuration.Describe(ch)
+	m.messages.Describe(ch)
+	m.handlerDuration.Describe(ch)
+	m.handlerLag.Describe(ch)
+	m.reconnects.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.requestDuration.Collect(ch)
+	m.messages.Collect(ch)
+	m.handlerDuration.Collect(ch)
+	m.handlerLag.Collect(ch)
+	m.reconnects.Collect(ch)
+}
+
+// ObserveRequest implements client.RequestObserver.
+func (m *Metrics) ObserveRequest(info client.RequestInfo) {
+	method := string(info.Method)
+	m.requests.WithLabelValues(method, info.Path, strconv.Itoa(info.StatusCode), strconv.Itoa(info.RetCode)).Inc()
+	m.requestDuration.WithLabelValues(method, info.Path).Observe(info.Elapsed.Seconds())
+}
+
+// ObserveMessage implements wsClient.MetricsObserver.
+func (m *Metrics) ObserveMessage(topic string, sent time.Time, handling time.Duration) {
+	m.messages.WithLabelValues(topic).Inc()
+	m.handlerDuration.WithLabelValues(topic).Observe(handling.Seconds())
+	if !sent.IsZero() {
+		m.handlerLag.WithLabelValues(topic).Observe(time.Since(sent).Seconds())
+	}
+}
+
+// ObserveReconnect implements wsClient.MetricsObserver.
+func (m *Metrics) ObserveReconnect(err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.reconnects.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestMetrics_REST(t *testing.T) {
+	m := New()
+	registry := prometheus.NewRegistry()
+	if err := m.Register(registry); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := bybittest.NewMock()
+	mock.HandleError("POST", "/v5/order/create", 110007, "insufficient balance")
+	c := mock.Client(client.WithRequestObserver(m))
+	if _, err := c.GetContext(context.Background(), "/v5/market/time", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.PostContext(context.Background(), "/v5/order/create", client.Params{}); err == nil {
+		t.Fatal("expected the order to fail")
+	}
+
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("GET", "/v5/market/time", "200", "0")); got != 1 {
+		t.Errorf("expected 1 successful request, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("POST", "/v5/order/create", "200", "110007")); got != 1 {
+		t.Errorf("expected 1 failed order, got %v", got)
+	}
+	if got := testutil.CollectAndCount(m.requestDuration); got != 2 {
+		t.Errorf("expected latencies of 2 endpoints, got %d", got)
+	}
+}
+
+func TestMetrics_WebSocket(t *testing.T) {
+	m := New()
+	m.ObserveMessage("orderbook.50.BTCUSDT", time.Now().Add(-20*time.Millisecond), time.Millisecond)
+	m.ObserveMessage("orderbook.50.BTCUSDT", time.Time{}, time.Millisecond)
+	m.ObserveReconnect(errors.New("dial failed"))
+	m.ObserveReconnect(nil)
+
+	if got := testutil.ToFloat64(m.messages.WithLabelValues("orderbook.50.BTCUSDT")); got != 2 {
+		t.Errorf("expected 2 messages, got %v", got)
+	}
+	if got := testutil.CollectAndCount(m.handlerLag); got != 1 {
+		t.Errorf("expected the lag of 1 topic, got %d", got)
+	}
+	if got := testutil.ToFloat64(m.reconnects.WithLabelValues("failure")); got != 1 {
+		t.Errorf("expected 1 failed reconnect, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.reconnects.WithLabelValues("success")); got != 1 {
+		t.Errorf("expected 1 successful reconnect, got %v", got)
+	}
+}
@@ -0,0 +1,42 @@
+package position
+
+import (
+	"context"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// Positions returns an iterator over the positions matching params, following
+// nextPageCursor across pages. params is copied, so it can be reused.
+func Positions(p Position, params RequestParams) *client.Iterator[Details] {
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[Details], error) {
+		params.Cursor = cursorParam(cursor, params.Cursor)
+		res, err := p.GetPositionInfo(ctx, &params)
+		if err != nil {
+			return client.Page[Details]{}, err
+		}
+		return client.Page[Details]{Items: res.Result.List, NextCursor: res.Result.NextPageCursor}, nil
+	})
+}
+
+// ClosedPnL returns an iterator over the closed PnL records matching req, following
+// nextPageCursor across pages. req is copied, so it can be reused.
+func ClosedPnL(p Position, req GetClosedPnLRequest) *client.Iterator[PnLPosition] {
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[PnLPosition], error) {
+		req.Cursor = cursorParam(cursor, req.Cursor)
+		res, err := p.GetClosedPnLup2Years(ctx, &req)
+		if err != nil {
+			return client.Page[PnLPosition]{}, err
+		}
+		return client.Page[PnLPosition]{Items: res.Result.List, NextCursor: res.Result.NextPageCursor}, nil
+	})
+}
+
+// cursorParam returns the cursor of the page to fetch: the iterator's cursor once it has one
+// and the request's starting cursor before.
+func cursorParam(cursor string, start *string) *string {
+	if cursor == "" {
+		return start
+	}
+	return &cursor
+}
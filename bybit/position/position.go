@@ -1,6 +1,7 @@
 package position
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -14,74 +15,74 @@ type Position interface {
 	// params: RequestParams - the parameters for the position info request.
 	// returns: *Response - the response containing position information.
 	//          error - an error if the request fails.
-	GetPositionInfo(params *RequestParams) (*Response, error)
+	GetPositionInfo(ctx context.Context, params *RequestParams) (*Response, error)
 
 	// SetLeverage sets the leverage for a position based on the provided request.
 	// req: SetLeverageRequest - the request containing leverage settings.
 	// returns: *Response - the response after setting the leverage.
 	//          error - an error if the request fails.
-	SetLeverage(req *SetLeverageRequest) (*Response, error)
+	SetLeverage(ctx context.Context, req *SetLeverageRequest) (*Response, error)
 
 	// SwitchMarginMode switches the margin mode (cross or isolated) for a position.
 	// req: SwitchMarginModeRequest - the request containing margin mode settings.
 	// returns: *Response - the response after switching the margin mode.
 	//          error - an error if the request fails.
-	SwitchMarginMode(req *SwitchMarginModeRequest) (*Response, error)
+	SwitchMarginMode(ctx context.Context, req *SwitchMarginModeRequest) (*Response, error)
 
 	// SetTPSLMode sets the Take Profit/Stop Loss mode for a given symbol.
 	// req: SetTPSLModeRequest - the request containing TP/SL mode settings.
 	// returns: *Response - the response after setting the TP/SL mode.
 	//          error - an error if the request fails.
-	SetTPSLMode(req *SetTPSLModeRequest) (*Response, error)
+	SetTPSLMode(ctx context.Context, req *SetTPSLModeRequest) (*Response, error)
 
 	// SwitchPositionMode switches the position mode for USDT perpetual and Inverse futures.
 	// req: SwitchPositionModeRequest - the request containing position mode settings.
 	// returns: *Response - the response after switching the position mode.
 	//          error - an error if the request fails.
-	SwitchPositionMode(req *SwitchPositionModeRequest) (*Response, error)
+	SwitchPositionMode(ctx context.Context, req *SwitchPositionModeRequest) (*Response, error)
 
 	// SetRiskLimit sets the risk limit for a specific symbol.
 	// req: SetRiskLimitRequest - the request containing risk limit settings.
 	// returns: *Response - the response after setting the risk limit.
 	//          error - an error if the request fails.
-	SetRiskLimit(req *SetRiskLimitRequest) (*Response, error)
+	SetRiskLimit(ctx context.Context, req *SetRiskLimitRequest) (*Response, error)
 
 	// SetTradingStop sets take profit, stop loss, or trailing stop for the position.
 	// req: SetTradingStopRequest - the request containing trading stop settings.
 	// returns: *Response - the response after setting the trading stop.
 	//          error - an error if the request fails.
-	SetTradingStop(req *SetTradingStopRequest) (*Response, error)
+	SetTradingStop(ctx context.Context, req *SetTradingStopRequest) (*Response, error)
 
 	// SetAutoAddMargin toggles auto-add-margin for an isolated margin position.
 	// req: SetAutoAddMarginRequest - the request containing auto-add-margin settings.
 	// returns: *Response - the response after setting auto-add-margin.
 	//          error - an error if the request fails.
-	SetAutoAddMargin(req *SetAutoAddMarginRequest) (*Response, error)
+	SetAutoAddMargin(ctx context.Context, req *SetAutoAddMarginRequest) (*Response, error)
 
 	// AddOrReduceMargin manually adds or reduces margin for an isolated margin position.
 	// req: AddReduceMarginRequest - the request containing add/reduce margin settings.
 	// returns: *Response - the response after adding or reducing margin.
 	//          error - an error if the request fails.
-	AddOrReduceMargin(req *AddReduceMarginRequest) (*Response, error)
+	AddOrReduceMargin(ctx context.Context, req *AddReduceMarginRequest) (*Response, error)
 
 	// MovePositions transfers positions between UIDs.
 	// req: MovePositionRequest - the request containing move position settings.
 	// returns: *MovePositionResponse - the response after moving positions.
 	//          error - an error if the request fails.
-	MovePositions(req *MovePositionRequest) (*MovePositionResponse, error)
+	MovePositions(ctx context.Context, req *MovePositionRequest) (*MovePositionResponse, error)
 
 	// GetMovePositionHistory queries the history of moved positions.
 	// req: GetMovePositionHistoryRequest - the request containing query parameters for move position history.
 	// returns: *GetMovePositionHistoryResponse - the response containing the move position history.
 	//          error - an error if the request fails.
-	GetMovePositionHistory(req *GetMovePositionHistoryRequest) (*GetMovePositionHistoryResponse, error)
+	GetMovePositionHistory(ctx context.Context, req *GetMovePositionHistoryRequest) (*GetMovePositionHistoryResponse, error)
 
 	// ConfirmNewRiskLimit confirms the new risk limit for a position, removing the reduceOnly mark if successful.
 	// req: ConfirmNewRiskLimitRequest - the request containing new risk limit settings.
 	// returns: *Response - the response after confirming the new risk limit.
 	//          error - an error if the request fails.
-	ConfirmNewRiskLimit(req *ConfirmNewRiskLimitRequest) (*Response, error)
-	GetClosedPnLup2Years(req *GetClosedPnLRequest) (*ClosedPnLResponse, error)
+	ConfirmNewRiskLimit(ctx context.Context, req *ConfirmNewRiskLimitRequest) (*Response, error)
+	GetClosedPnLup2Years(ctx context.Context, req *GetClosedPnLRequest) (*ClosedPnLResponse, error)
 }
 type impl struct {
 	client *client.Client
@@ -93,9 +94,9 @@ func New(c *client.Client) Position {
 }
 
 // GetPositionInfo fetches position information from Bybit.
-func (i *impl) GetPositionInfo(params *RequestParams) (*Response, error) {
+func (i *impl) GetPositionInfo(ctx context.Context, params *RequestParams) (*Response, error) {
 	requestParams := ConvertPositionRequestParams(params)
-	response, err := i.client.Get("/v5/position/list", requestParams)
+	response, err := i.client.GetContext(ctx, "/v5/position/list", requestParams)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching position info: %w", err)
 	}
@@ -111,10 +112,10 @@ func (i *impl) GetPositionInfo(params *RequestParams) (*Response, error) {
 }
 
 // SetLeverage sets the leverage for a given symbol and account type.
-func (i *impl) SetLeverage(req *SetLeverageRequest) (*Response, error) {
+func (i *impl) SetLeverage(ctx context.Context, req *SetLeverageRequest) (*Response, error) {
 	params := ConvertSetLeverageRequestToParams(req)
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/set-leverage", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/set-leverage", params)
 	if err != nil {
 		return nil, fmt.Errorf("error setting leverage: %w", err)
 	}
@@ -134,11 +135,11 @@ func (i *impl) SetLeverage(req *SetLeverageRequest) (*Response, error) {
 }
 
 // SwitchMarginMode switches between cross-margin mode and isolated margin mode for a symbol.
-func (i *impl) SwitchMarginMode(req *SwitchMarginModeRequest) (*Response, error) {
+func (i *impl) SwitchMarginMode(ctx context.Context, req *SwitchMarginModeRequest) (*Response, error) {
 	// Convert payload to Params type expected by the client.Post method
 	params := ConvertSwitchMarginModeRequestToParams(req)
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/switch-isolated", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/switch-isolated", params)
 	if err != nil {
 		return nil, fmt.Errorf("error switching margin mode: %w", err)
 	}
@@ -157,10 +158,10 @@ func (i *impl) SwitchMarginMode(req *SwitchMarginModeRequest) (*Response, error)
 
 	return &apiResponse, nil
 }
-func (i *impl) SetTPSLMode(req *SetTPSLModeRequest) (*Response, error) {
+func (i *impl) SetTPSLMode(ctx context.Context, req *SetTPSLModeRequest) (*Response, error) {
 	params := ConvertSetTPSLModeRequestToParams(req)
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/set-tpsl-mode", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/set-tpsl-mode", params)
 	if err != nil {
 		return nil, fmt.Errorf("error setting TP/SL mode: %w", err)
 	}
@@ -176,10 +177,10 @@ func (i *impl) SetTPSLMode(req *SetTPSLModeRequest) (*Response, error) {
 
 	return &positionResponse, nil
 }
-func (i *impl) SwitchPositionMode(req *SwitchPositionModeRequest) (*Response, error) {
+func (i *impl) SwitchPositionMode(ctx context.Context, req *SwitchPositionModeRequest) (*Response, error) {
 	params := ConvertSwitchPositionModeRequestToParams(req)
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/switch-mode", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/switch-mode", params)
 	if err != nil {
 		return nil, fmt.Errorf("error switching position mode: %w", err)
 	}
@@ -195,11 +196,11 @@ func (i *impl) SwitchPositionMode(req *SwitchPositionModeRequest) (*Response, er
 	return &positionResponse, nil
 }
 
-func (i *impl) SetRiskLimit(req *SetRiskLimitRequest) (*Response, error) {
+func (i *impl) SetRiskLimit(ctx context.Context, req *SetRiskLimitRequest) (*Response, error) {
 	params := ConvertSetRiskLimitRequestToParams(req)
 
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/set-risk-limit", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/set-risk-limit", params)
 	if err != nil {
 		return nil, fmt.Errorf("error setting risk limit: %w", err)
 	}
@@ -216,10 +217,10 @@ func (i *impl) SetRiskLimit(req *SetRiskLimitRequest) (*Response, error) {
 	return &positionResponse, nil
 }
 
-func (i *impl) SetTradingStop(req *SetTradingStopRequest) (*Response, error) {
+func (i *impl) SetTradingStop(ctx context.Context, req *SetTradingStopRequest) (*Response, error) {
 	params := ConvertSetTradingStopRequestToParams(req)
 
-	response, err := i.client.Post("/v5/position/trading-stop", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/trading-stop", params)
 	if err != nil {
 		return nil, fmt.Errorf("error setting trading stop: %w", err)
 	}
@@ -234,10 +235,10 @@ func (i *impl) SetTradingStop(req *SetTradingStopRequest) (*Response, error) {
 
 	return &positionResponse, nil
 }
-func (i *impl) SetAutoAddMargin(req *SetAutoAddMarginRequest) (*Response, error) {
+func (i *impl) SetAutoAddMargin(ctx context.Context, req *SetAutoAddMarginRequest) (*Response, error) {
 	params := ConvertSetAutoAddMarginRequestToParams(req)
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/set-auto-add-margin", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/set-auto-add-margin", params)
 	if err != nil {
 		return nil, fmt.Errorf("error setting auto add margin: %w", err)
 	}
@@ -253,10 +254,10 @@ func (i *impl) SetAutoAddMargin(req *SetAutoAddMarginRequest) (*Response, error)
 
 	return &positionResponse, nil
 }
-func (i *impl) AddOrReduceMargin(req *AddReduceMarginRequest) (*Response, error) {
+func (i *impl) AddOrReduceMargin(ctx context.Context, req *AddReduceMarginRequest) (*Response, error) {
 	params := ConvertAddReduceMarginRequestToParams(req)
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/add-margin", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/add-margin", params)
 	if err != nil {
 		return nil, fmt.Errorf("error adding or reducing margin: %w", err)
 	}
@@ -274,10 +275,15 @@ func (i *impl) AddOrReduceMargin(req *AddReduceMarginRequest) (*Response, error)
 }
 
 // GetClosedPnLup2Years retrieves closed PnL data with pagination controlled by the user.
-func (i *impl) GetClosedPnLup2Years(req *GetClosedPnLRequest) (*ClosedPnLResponse, error) {
+func (i *impl) GetClosedPnLup2Years(ctx context.Context, req *GetClosedPnLRequest) (*ClosedPnLResponse, error) {
 	params := map[string]any{
 		"category": req.Category,
-		"limit":    req.Limit,
+	}
+	if req.Symbol != nil {
+		params["symbol"] = *req.Symbol
+	}
+	if req.Limit != nil {
+		params["limit"] = *req.Limit
 	}
 	if req.StartTime != nil {
 		params["startTime"] = strconv.FormatInt(*req.StartTime, 10)
@@ -286,11 +292,11 @@ func (i *impl) GetClosedPnLup2Years(req *GetClosedPnLRequest) (*ClosedPnLRespons
 		params["endTime"] = strconv.FormatInt(*req.EndTime, 10)
 	}
 	if req.Cursor != nil {
-		params["cursor"] = req.Cursor
+		params["cursor"] = *req.Cursor
 	}
 
 	// Perform the API GET request
-	responseData, err := i.client.Get("/v5/position/closed-pnl", params)
+	responseData, err := i.client.GetContext(ctx, "/v5/position/closed-pnl", params)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching closed PnL records: %w", err)
 	}
@@ -305,10 +311,10 @@ func (i *impl) GetClosedPnLup2Years(req *GetClosedPnLRequest) (*ClosedPnLRespons
 	return &response, nil
 }
 
-func (i *impl) MovePositions(req *MovePositionRequest) (*MovePositionResponse, error) {
+func (i *impl) MovePositions(ctx context.Context, req *MovePositionRequest) (*MovePositionResponse, error) {
 	params := ConvertMovePositionRequestToParams(req)
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/move-positions", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/move-positions", params)
 	if err != nil {
 		return nil, fmt.Errorf("error moving positions: %w", err)
 	}
@@ -323,7 +329,7 @@ func (i *impl) MovePositions(req *MovePositionRequest) (*MovePositionResponse, e
 
 	return &movePositionResponse, nil
 }
-func (i *impl) GetMovePositionHistory(req *GetMovePositionHistoryRequest) (*GetMovePositionHistoryResponse, error) {
+func (i *impl) GetMovePositionHistory(ctx context.Context, req *GetMovePositionHistoryRequest) (*GetMovePositionHistoryResponse, error) {
 	var allEntries []MovePositionHistoryEntry
 	var finalResponse GetMovePositionHistoryResponse
 
@@ -332,7 +338,7 @@ func (i *impl) GetMovePositionHistory(req *GetMovePositionHistoryRequest) (*GetM
 		params := ConvertGetMovePositionHistoryRequestToParams(req)
 
 		// Perform the GET request
-		response, err := i.client.Get("/v5/position/move-history", params)
+		response, err := i.client.GetContext(ctx, "/v5/position/move-history", params)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching move position history: %w", err)
 		}
@@ -367,11 +373,11 @@ func (i *impl) GetMovePositionHistory(req *GetMovePositionHistoryRequest) (*GetM
 
 	return &finalResponse, nil
 }
-func (i *impl) ConfirmNewRiskLimit(req *ConfirmNewRiskLimitRequest) (*Response, error) {
+func (i *impl) ConfirmNewRiskLimit(ctx context.Context, req *ConfirmNewRiskLimitRequest) (*Response, error) {
 	params := ConvertConfirmNewRiskLimitRequestToParams(req)
 
 	// Perform the POST request
-	response, err := i.client.Post("/v5/position/confirm-pending-mmr", params)
+	response, err := i.client.PostContext(ctx, "/v5/position/confirm-pending-mmr", params)
 	if err != nil {
 		return nil, fmt.Errorf("error confirming new risk limit: %w", err)
 	}
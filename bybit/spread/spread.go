@@ -0,0 +1,113 @@
+// Package spread implements the market data endpoints of Bybit's spread trading, which
+// trades the difference between two legs, such as a perpetual against a future, as one
+// instrument.
+package spread
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
+)
+
+type Spread interface {
+	GetInstruments(ctx context.Context, req InstrumentsRequest) (*InstrumentsPage, error)
+	GetOrderBook(ctx context.Context, symbol string, limit int) (*market.OrderBookSnapshot, error)
+	GetTickers(ctx context.Context, symbol string) ([]Ticker, error)
+	GetRecentTrades(ctx context.Context, symbol string, limit int) ([]Trade, error)
+}
+
+type spreadImpl struct {
+	client *client.Client
+}
+
+func New(client_ *client.Client) Spread {
+	return &spreadImpl{client: client_}
+}
+
+// GetInstruments returns a page of the spread instruments matching req. Use Instruments to
+// walk every page.
+func (s *spreadImpl) GetInstruments(ctx context.Context, req InstrumentsRequest) (*InstrumentsPage, error) {
+	params := client.Params{}
+	for key, value := range map[string]string{"symbol": req.Symbol, "baseCoin": req.BaseCoin, "cursor": req.Cursor} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	if req.Limit > 0 {
+		params["limit"] = req.Limit
+	}
+	var response InstrumentsResponse
+	if err := s.get(ctx, "instrument", params, &response); err != nil {
+		return nil, err
+	}
+	return &response.Result, nil
+}
+
+// Instruments returns an iterator over every spread instrument matching req, following
+// nextPageCursor across pages.
+func Instruments(s Spread, req InstrumentsRequest) *client.Iterator[Instrument] {
+	start := req.Cursor
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[Instrument], error) {
+		req.Cursor = start
+		if cursor != "" {
+			req.Cursor = cursor
+		}
+		page, err := s.GetInstruments(ctx, req)
+		if err != nil {
+			return client.Page[Instrument]{}, err
+		}
+		return client.Page[Instrument]{Items: page.List, NextCursor: page.NextPageCursor}, nil
+	})
+}
+
+// GetOrderBook returns the order book of a spread, limited to limit levels per side. A zero
+// limit is left to Bybit's default depth.
+func (s *spreadImpl) GetOrderBook(ctx context.Context, symbol string, limit int) (*market.OrderBookSnapshot, error) {
+	params := client.Params{"symbol": symbol}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	var response OrderBookResponse
+	if err := s.get(ctx, "orderbook", params, &response); err != nil {
+		return nil, err
+	}
+	return response.Result.Snapshot()
+}
+
+// GetTickers returns the ticker of symbol, or of every spread when symbol is empty.
+func (s *spreadImpl) GetTickers(ctx context.Context, symbol string) ([]Ticker, error) {
+	params := client.Params{}
+	if symbol != "" {
+		params["symbol"] = symbol
+	}
+	var response TickersResponse
+	if err := s.get(ctx, "tickers", params, &response); err != nil {
+		return nil, err
+	}
+	return response.Result.List, nil
+}
+
+// GetRecentTrades returns the latest public trades of a spread, newest first. A zero limit
+// is left to Bybit's default.
+func (s *spreadImpl) GetRecentTrades(ctx context.Context, symbol string, limit int) ([]Trade, error) {
+	params := client.Params{"symbol": symbol}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	var response TradesResponse
+	if err := s.get(ctx, "recent-trade", params, &response); err != nil {
+		return nil, err
+	}
+	return response.Result.List, nil
+}
+
+// get calls the spread endpoint /v5/spread/<endpoint> and decodes the response into v.
+func (s *spreadImpl) get(ctx context.Context, endpoint string, params client.Params, v any) error {
+	res, err := s.client.GetContext(ctx, fmt.Sprintf("/%s/spread/%s", client.APIVersion, endpoint), params)
+	if err != nil {
+		return err
+	}
+	return res.Unmarshal(v)
+}
@@ -0,0 +1,66 @@
+package spread
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestGetInstruments(t *testing.T) {
+	mock := bybittest.NewMock()
+	instruments, err := Instruments(New(mock.Client()), InstrumentsRequest{BaseCoin: "SOL"}).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instruments) != 1 {
+		t.Fatalf("expected 1 instrument, got %d", len(instruments))
+	}
+	sol := instruments[0]
+	if sol.ContractType != "FundingRateArb" || len(sol.Legs) != 2 || sol.Legs[1].ContractType != "Spot" {
+		t.Errorf("unexpected instrument %+v", sol)
+	}
+	if !sol.MinPrice.Equal(client.MustParseDecimal("-1999.9998")) || !sol.TickSize.Equal(client.MustParseDecimal("0.0001")) {
+		t.Errorf("unexpected price filter %s %s", sol.MinPrice, sol.TickSize)
+	}
+	if requests := mock.Requests(); len(requests) != 1 || requests[0].Query.Get("baseCoin") != "SOL" {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+}
+
+func TestGetOrderBook(t *testing.T) {
+	mock := bybittest.NewMock()
+	book, err := New(mock.Client()).GetOrderBook(context.Background(), "SOLUSDT_SOL/USDT", 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(book.Bids) != 2 || len(book.Asks) != 1 || !book.Bids[1].Size.Equal(client.MustParseDecimal("2")) || book.Seq != 3510 {
+		t.Errorf("unexpected order book %+v", book)
+	}
+	if requests := mock.Requests(); len(requests) != 1 || requests[0].Path != "/v5/spread/orderbook" || requests[0].Query.Get("limit") != "25" {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+}
+
+func TestGetTickersAndRecentTrades(t *testing.T) {
+	mock := bybittest.NewMock()
+	s := New(mock.Client())
+	tickers, err := s.GetTickers(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickers) != 1 || !tickers[0].LowPrice.Equal(client.MustParseDecimal("-4")) {
+		t.Errorf("unexpected tickers %+v", tickers)
+	}
+	trades, err := s.GetRecentTrades(context.Background(), "SOLUSDT_SOL/USDT", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trades) != 1 || !trades[0].Price.Equal(client.MustParseDecimal("-2.4")) || trades[0].ExecutedAt().UnixMilli() != 1744079426519 {
+		t.Errorf("unexpected trades %+v", trades)
+	}
+	if requests := mock.Requests(); len(requests) != 2 || requests[0].Query.Has("symbol") || requests[1].Query.Has("limit") {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+}
@@ -0,0 +1,117 @@
+package spread
+
+import (
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
+)
+
+type BaseResponse struct {
+	RetCode    int            `json:"retCode"`
+	RetMsg     string         `json:"retMsg"`
+	Time       int64          `json:"time"`
+	RetExtInfo map[string]any `json:"retExtInfo"`
+}
+
+// InstrumentsRequest filters the spread instruments. Empty fields and a zero Limit are left
+// to Bybit's defaults.
+type InstrumentsRequest struct {
+	Symbol   string
+	BaseCoin string
+	Limit    int
+	Cursor   string
+}
+
+// Leg is one side of a spread.
+type Leg struct {
+	Symbol string `json:"symbol"`
+	// ContractType is LinearPerpetual, LinearFutures or Spot.
+	ContractType string `json:"contractType"`
+}
+
+// Instrument is the specification of a spread.
+type Instrument struct {
+	Symbol string `json:"symbol"`
+	// ContractType is FundingRateArb, CarryTrade, FutureSpread or PerpBasis.
+	ContractType string         `json:"contractType"`
+	Status       string         `json:"status"`
+	BaseCoin     string         `json:"baseCoin"`
+	QuoteCoin    string         `json:"quoteCoin"`
+	SettleCoin   string         `json:"settleCoin"`
+	TickSize     client.Decimal `json:"tickSize"`
+	MinPrice     client.Decimal `json:"minPrice"`
+	MaxPrice     client.Decimal `json:"maxPrice"`
+	LotSize      client.Decimal `json:"lotSize"`
+	MinSize      client.Decimal `json:"minSize"`
+	MaxSize      client.Decimal `json:"maxSize"`
+	// LaunchTime and DeliveryTime are Unix milliseconds; DeliveryTime is 0 when no leg
+	// expires.
+	LaunchTime   client.Int `json:"launchTime"`
+	DeliveryTime client.Int `json:"deliveryTime"`
+	Legs         []Leg      `json:"legs"`
+}
+
+// InstrumentsPage is a page of spread instruments.
+type InstrumentsPage struct {
+	List           []Instrument `json:"list"`
+	NextPageCursor string       `json:"nextPageCursor"`
+}
+
+// InstrumentsResponse is the response of the /v5/spread/instrument endpoint.
+type InstrumentsResponse struct {
+	BaseResponse
+	Result InstrumentsPage `json:"result"`
+}
+
+// OrderBookResponse is the response of the /v5/spread/orderbook endpoint, which has the
+// layout of the market order book.
+type OrderBookResponse struct {
+	BaseResponse
+	Result market.OrderBookResult `json:"result"`
+}
+
+// Ticker is the market summary of a spread.
+type Ticker struct {
+	Symbol       string         `json:"symbol"`
+	BidPrice     client.Decimal `json:"bidPrice"`
+	BidSize      client.Decimal `json:"bidSize"`
+	AskPrice     client.Decimal `json:"askPrice"`
+	AskSize      client.Decimal `json:"askSize"`
+	LastPrice    client.Decimal `json:"lastPrice"`
+	HighPrice    client.Decimal `json:"highPrice"`
+	LowPrice     client.Decimal `json:"lowPrice"`
+	PrevPrice24H client.Decimal `json:"prevPrice24h"`
+	Volume24H    client.Decimal `json:"volume24h"`
+}
+
+// TickersResponse is the response of the /v5/spread/tickers endpoint.
+type TickersResponse struct {
+	BaseResponse
+	Result struct {
+		List []Ticker `json:"list"`
+	} `json:"result"`
+}
+
+// Trade is a public spread execution.
+type Trade struct {
+	ExecID string         `json:"execId"`
+	Symbol string         `json:"symbol"`
+	Price  client.Decimal `json:"price"`
+	Size   client.Decimal `json:"size"`
+	Side   string         `json:"side"`
+	Time   client.Int     `json:"time"`
+}
+
+// ExecutedAt returns the execution time of the trade.
+func (t Trade) ExecutedAt() time.Time {
+	return time.UnixMilli(int64(t.Time))
+}
+
+// TradesResponse is the response of the /v5/spread/recent-trade endpoint.
+type TradesResponse struct {
+	BaseResponse
+	Result struct {
+		List []Trade `json:"list"`
+	} `json:"result"`
+}
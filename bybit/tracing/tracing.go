@@ -0,0 +1,112 @@
+// Package tracing creates OpenTelemetry spans for the Bybit REST and WebSocket clients:
+//
+//	t := tracing.New() // uses otel.GetTracerProvider()
+//	rest := client.NewClient(key, secret, false, client.WithRequestTracer(t))
+//	ws, err := wsClient.NewClient(wsClient.WithTracer(t))
+//
+// Every REST call becomes a client span named after its method and endpoint, such as
+// "POST /v5/order/create", that is a child of the span in the context passed to the call.
+// WebSocket spans are named "bybit.ws.<operation>", such as bybit.ws.connect and
+// bybit.ws.subscribe.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	wsClient "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// instrumentationName identifies the spans of this package.
+const instrumentationName = "github.com/cploutarchou/crypto-sdk-suite/bybit"
+
+// Attribute keys of the spans.
+const (
+	AttrEndpoint   = attribute.Key("bybit.endpoint")
+	AttrCategory   = attribute.Key("bybit.category")
+	AttrRetCode    = attribute.Key("bybit.ret_code")
+	AttrMethod     = attribute.Key("http.request.method")
+	AttrStatusCode = attribute.Key("http.response.status_code")
+)
+
+// Tracer implements client.RequestTracer and wsClient.Tracer with OpenTelemetry.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var (
+	_ client.RequestTracer = (*Tracer)(nil)
+	_ wsClient.Tracer      = (*Tracer)(nil)
+)
+
+// Option configures a Tracer.
+type Option func(*config)
+
+type config struct {
+	provider trace.TracerProvider
+}
+
+// WithTracerProvider creates the spans with provider instead of the global one.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// New creates a Tracer.
+func New(opts ...Option) *Tracer {
+	cfg := config{provider: otel.GetTracerProvider()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Tracer{tracer: cfg.provider.Tracer(instrumentationName)}
+}
+
+// StartRequest implements client.RequestTracer.
+func (t *Tracer) StartRequest(ctx context.Context, method client.Method, path string, params client.Params) (context.Context, func(client.RequestInfo)) {
+	attrs := []attribute.KeyValue{AttrMethod.String(string(method)), AttrEndpoint.String(path)}
+	if category, ok := params["category"]; ok {
+		attrs = append(attrs, AttrCategory.String(fmt.Sprint(category)))
+	}
+	ctx, span := t.tracer.Start(ctx, fmt.Sprintf("%s %s", method, path),
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return ctx, func(info client.RequestInfo) {
+		if info.StatusCode != 0 {
+			span.SetAttributes(AttrStatusCode.Int(info.StatusCode), AttrRetCode.Int(info.RetCode))
+		}
+		endSpan(span, info.Err)
+	}
+}
+
+// Start implements wsClient.Tracer.
+func (t *Tracer) Start(ctx context.Context, operation string, attributes map[string]string) (context.Context, func(error)) {
+	attrs := make([]attribute.KeyValue, 0, len(attributes))
+	for key, value := range attributes {
+		if n, err := strconv.Atoi(value); err == nil {
+			attrs = append(attrs, attribute.Int("bybit.ws."+key, n))
+			continue
+		}
+		attrs = append(attrs, attribute.String("bybit.ws."+key, value))
+	}
+	ctx, span := t.tracer.Start(ctx, "bybit.ws."+operation,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return ctx, func(err error) {
+		endSpan(span, err)
+	}
+}
+
+// endSpan records err, if any, and ends span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func newTracer() (*Tracer, *tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return New(WithTracerProvider(provider)), recorder, provider
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracer_REST(t *testing.T) {
+	tracer, recorder, provider := newTracer()
+	mock := bybittest.NewMock()
+	mock.HandleError("POST", "/v5/order/create", 110007, "insufficient balance")
+	c := mock.Client(client.WithRequestTracer(tracer))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	if _, err := c.GetContext(ctx, "/v5/market/tickers", client.Params{"category": "linear"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.PostContext(ctx, "/v5/order/create", client.Params{"category": "spot"}); err == nil {
+		t.Fatal("expected the order to fail")
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	tickers, order := spans[0], spans[1]
+	if tickers.Name() != "GET /v5/market/tickers" || tickers.SpanKind() != trace.SpanKindClient {
+		t.Errorf("unexpected span %s of kind %v", tickers.Name(), tickers.SpanKind())
+	}
+	if tickers.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected the span to be a child of the caller's span")
+	}
+	attrs := attributes(tickers)
+	if attrs[AttrCategory].AsString() != "linear" || attrs[AttrRetCode].AsInt64() != 0 || attrs[AttrStatusCode].AsInt64() != 200 {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+
+	attrs = attributes(order)
+	if attrs[AttrRetCode].AsInt64() != 110007 || attrs[AttrCategory].AsString() != "spot" {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+	if order.Status().Code != codes.Error || len(order.Events()) != 1 {
+		t.Errorf("expected the error to be recorded, got status %v", order.Status())
+	}
+}
+
+func TestTracer_WebSocket(t *testing.T) {
+	tracer, recorder, _ := newTracer()
+	_, end := tracer.Start(context.Background(), "reconnect", map[string]string{"channel": "public", "attempt": "2"})
+	end(errors.New("dial failed"))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "bybit.ws.reconnect" {
+		t.Fatalf("unexpected spans %v", spans)
+	}
+	attrs := attributes(spans[0])
+	if attrs["bybit.ws.channel"].AsString() != "public" || attrs["bybit.ws.attempt"].AsInt64() != 2 {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("expected an error status, got %v", spans[0].Status())
+	}
+}
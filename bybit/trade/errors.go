@@ -0,0 +1,27 @@
+package trade
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// ErrOrderNotFound is matched by the errors of order operations on an order that does not
+// exist, is already filled or canceled, or is too late to amend. The *client.APIError
+// carrying Bybit's retCode stays available through errors.As.
+var ErrOrderNotFound = errors.New("order not exists or too late to amend")
+
+// ErrUnknownResult is returned by BatchOrderResult.Err for an order whose outcome Bybit did
+// not report.
+var ErrUnknownResult = errors.New("batch order result not reported")
+
+// orderError makes err match ErrOrderNotFound when Bybit rejected the request because of
+// the order's state.
+func orderError(err error) error {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.IsOrderNotFound() {
+		return fmt.Errorf("%w: %w", ErrOrderNotFound, err)
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+// OpenOrders returns an iterator over the open orders matching req, following
+// nextPageCursor across pages. req is copied, so it can be reused.
+func OpenOrders(t Trade, req GetOpenOrdersRequest) *client.Iterator[Order] {
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[Order], error) {
+		req.Cursor = cursorParam(cursor, req.Cursor)
+		res, err := t.GetOpenOrders(ctx, &req)
+		if err != nil {
+			return client.Page[Order]{}, err
+		}
+		return client.Page[Order]{Items: res.Result.List, NextCursor: res.Result.NextPageCursor}, nil
+	})
+}
+
+// OrderHistory returns an iterator over the orders matching req, following nextPageCursor
+// across pages. req is copied, so it can be reused.
+func OrderHistory(t Trade, req GetOrderHistoryRequest) *client.Iterator[Order] {
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[Order], error) {
+		req.Cursor = cursorParam(cursor, req.Cursor)
+		res, err := t.GetOrderHistory(ctx, &req)
+		if err != nil {
+			return client.Page[Order]{}, err
+		}
+		return client.Page[Order]{Items: res.Result.List, NextCursor: res.Result.NextPageCursor}, nil
+	})
+}
+
+// MaxOrderHistorySpan is the longest range Bybit accepts between the startTime and endTime
+// of an order history request.
+const MaxOrderHistorySpan = 7 * 24 * time.Hour
+
+// OrderHistoryBetween passes every order matching req that was created between from and to
+// to fn, newest first, splitting the range into requests of at most MaxOrderHistorySpan as
+// reconciling a longer period needs. The StartTime, EndTime and Cursor of req are ignored.
+// Returning an error from fn stops the walk.
+func OrderHistoryBetween(ctx context.Context, t Trade, req GetOrderHistoryRequest, from, to time.Time, fn func(Order) error) error {
+	if to.Before(from) {
+		return fmt.Errorf("order history: to %v is before from %v", to, from)
+	}
+	req.Cursor = nil
+	for end := to; !end.Before(from); end = end.Add(-MaxOrderHistorySpan - time.Millisecond) {
+		start := end.Add(-MaxOrderHistorySpan)
+		if start.Before(from) {
+			start = from
+		}
+		startTime, endTime := start.UnixMilli(), end.UnixMilli()
+		req.StartTime, req.EndTime = &startTime, &endTime
+		orders := OrderHistory(t, req)
+		for {
+			order, err := orders.Next(ctx)
+			if errors.Is(err, client.ErrIteratorDone) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if err := fn(order); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Executions returns an iterator over the executions matching req, following
+// nextPageCursor across pages. req is copied, so it can be reused.
+func Executions(t Trade, req GetTradeHistoryRequest) *client.Iterator[Execution] {
+	return client.NewIterator(func(ctx context.Context, cursor string) (client.Page[Execution], error) {
+		req.Cursor = cursorParam(cursor, req.Cursor)
+		res, err := t.GetExecutionList(ctx, &req)
+		if err != nil {
+			return client.Page[Execution]{}, err
+		}
+		return client.Page[Execution]{Items: res.Result.List, NextCursor: res.Result.NextPageCursor}, nil
+	})
+}
+
+// TradeHistory returns an iterator over the executions matching req.
+//
+// Deprecated: use Executions.
+func TradeHistory(t Trade, req GetTradeHistoryRequest) *client.Iterator[Execution] {
+	return Executions(t, req)
+}
+
+// cursorParam returns the cursor of the page to fetch: the iterator's cursor once it has one
+// and the request's starting cursor before.
+func cursorParam(cursor string, start *string) *string {
+	if cursor == "" {
+		return start
+	}
+	return &cursor
+}
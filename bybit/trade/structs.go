@@ -1,5 +1,11 @@
 package trade
 
+import (
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
 type PlaceOrderRequest struct {
 	Category         string  `json:"category"`
 	Symbol           string  `json:"symbol"`
@@ -29,6 +35,7 @@ type PlaceOrderRequest struct {
 	SlLimitPrice     *string `json:"slLimitPrice,omitempty"`
 	TpOrderType      *string `json:"tpOrderType,omitempty"`
 	SlOrderType      *string `json:"slOrderType,omitempty"`
+	MarketUnit       *string `json:"marketUnit,omitempty"`
 }
 
 type PlaceOrderResponse struct {
@@ -104,57 +111,90 @@ type GetOpenOrdersResponse struct {
 	RetCode int    `json:"retCode"`
 	RetMsg  string `json:"retMsg"`
 	Result  struct {
-		List           []OrderDetails `json:"list"`
-		NextPageCursor string         `json:"nextPageCursor"`
-		Category       string         `json:"category"`
+		List           []Order `json:"list"`
+		NextPageCursor string  `json:"nextPageCursor"`
+		Category       string  `json:"category"`
 	} `json:"result"`
 	RetExtInfo any   `json:"retExtInfo"`
 	Time       int64 `json:"time"`
 }
 
-type OrderDetails struct {
-	OrderID            string `json:"orderId"`
-	OrderLinkID        string `json:"orderLinkId"`
-	BlockTradeID       string `json:"blockTradeId"`
-	Symbol             string `json:"symbol"`
-	Price              string `json:"price"`
-	Qty                string `json:"qty"`
-	Side               string `json:"side"`
-	IsLeverage         string `json:"isLeverage"`
-	PositionIdx        int    `json:"positionIdx"`
-	OrderStatus        string `json:"orderStatus"`
-	CancelType         string `json:"cancelType"`
-	RejectReason       string `json:"rejectReason"`
-	AvgPrice           string `json:"avgPrice"`
-	LeavesQty          string `json:"leavesQty"`
-	LeavesValue        string `json:"leavesValue"`
-	CumExecQty         string `json:"cumExecQty"`
-	CumExecValue       string `json:"cumExecValue"`
-	CumExecFee         string `json:"cumExecFee"`
-	TimeInForce        string `json:"timeInForce"`
-	OrderType          string `json:"orderType"`
-	StopOrderType      string `json:"stopOrderType"`
-	OrderIv            string `json:"orderIv"`
-	TriggerPrice       string `json:"triggerPrice"`
-	TakeProfit         string `json:"takeProfit"`
-	StopLoss           string `json:"stopLoss"`
-	TpTriggerBy        string `json:"tpTriggerBy"`
-	SlTriggerBy        string `json:"slTriggerBy"`
-	TriggerDirection   int    `json:"triggerDirection"`
-	TriggerBy          string `json:"triggerBy"`
-	LastPriceOnCreated string `json:"lastPriceOnCreated"`
-	ReduceOnly         bool   `json:"reduceOnly"`
-	CloseOnTrigger     bool   `json:"closeOnTrigger"`
-	SmpType            string `json:"smpType"`
-	SmpGroup           int    `json:"smpGroup"`
-	SmpOrderID         string `json:"smpOrderId"`
-	TpslMode           string `json:"tpslMode"`
-	TpLimitPrice       string `json:"tpLimitPrice"`
-	SlLimitPrice       string `json:"slLimitPrice"`
-	PlaceType          string `json:"placeType"`
-	CreatedTime        string `json:"createdTime"`
-	UpdatedTime        string `json:"updatedTime"`
+// Order is an order as returned by the open orders and order history endpoints. Prices and
+// quantities that do not apply to the order, such as the trigger price of a plain limit
+// order, are zero.
+type Order struct {
+	OrderID      string         `json:"orderId"`
+	OrderLinkID  string         `json:"orderLinkId"`
+	BlockTradeID string         `json:"blockTradeId"`
+	Symbol       string         `json:"symbol"`
+	Price        client.Decimal `json:"price"`
+	Qty          client.Decimal `json:"qty"`
+	Side         string         `json:"side"`
+	// IsLeverage is "1" for a spot margin order, "0" or empty otherwise.
+	IsLeverage   string         `json:"isLeverage"`
+	PositionIdx  int            `json:"positionIdx"`
+	OrderStatus  string         `json:"orderStatus"`
+	CancelType   string         `json:"cancelType"`
+	RejectReason string         `json:"rejectReason"`
+	AvgPrice     client.Decimal `json:"avgPrice"`
+	LeavesQty    client.Decimal `json:"leavesQty"`
+	LeavesValue  client.Decimal `json:"leavesValue"`
+	CumExecQty   client.Decimal `json:"cumExecQty"`
+	CumExecValue client.Decimal `json:"cumExecValue"`
+	CumExecFee   client.Decimal `json:"cumExecFee"`
+	TimeInForce  string         `json:"timeInForce"`
+	OrderType    string         `json:"orderType"`
+	// StopOrderType is TakeProfit, StopLoss, TrailingStop, Stop, PartialTakeProfit,
+	// PartialStopLoss, tpslOrder or OcoOrder for conditional orders, empty or UNKNOWN
+	// otherwise.
+	StopOrderType      string         `json:"stopOrderType"`
+	OrderIv            client.Decimal `json:"orderIv"`
+	TriggerPrice       client.Decimal `json:"triggerPrice"`
+	TakeProfit         client.Decimal `json:"takeProfit"`
+	StopLoss           client.Decimal `json:"stopLoss"`
+	TpTriggerBy        string         `json:"tpTriggerBy"`
+	SlTriggerBy        string         `json:"slTriggerBy"`
+	TriggerDirection   int            `json:"triggerDirection"`
+	TriggerBy          string         `json:"triggerBy"`
+	LastPriceOnCreated client.Decimal `json:"lastPriceOnCreated"`
+	ReduceOnly         bool           `json:"reduceOnly"`
+	CloseOnTrigger     bool           `json:"closeOnTrigger"`
+	SmpType            string         `json:"smpType"`
+	SmpGroup           int            `json:"smpGroup"`
+	SmpOrderID         string         `json:"smpOrderId"`
+	TpslMode           string         `json:"tpslMode"`
+	TpLimitPrice       client.Decimal `json:"tpLimitPrice"`
+	SlLimitPrice       client.Decimal `json:"slLimitPrice"`
+	PlaceType          string         `json:"placeType"`
+	// CreatedTime and UpdatedTime are Unix milliseconds.
+	CreatedTime client.Int `json:"createdTime"`
+	UpdatedTime client.Int `json:"updatedTime"`
+}
+
+// openOrderStatuses are the statuses of orders that can still be filled.
+var openOrderStatuses = map[string]bool{"New": true, "PartiallyFilled": true, "Untriggered": true}
+
+// IsOpen reports whether the order can still be filled: it is new, partially filled or a
+// conditional order waiting for its trigger.
+func (o Order) IsOpen() bool {
+	return openOrderStatuses[o.OrderStatus]
 }
+
+// CreatedAt returns the creation time of the order.
+func (o Order) CreatedAt() time.Time {
+	return time.UnixMilli(int64(o.CreatedTime))
+}
+
+// UpdatedAt returns the time of the last update of the order.
+func (o Order) UpdatedAt() time.Time {
+	return time.UnixMilli(int64(o.UpdatedTime))
+}
+
+// OrderDetails is the former name of Order.
+//
+// Deprecated: use Order.
+type OrderDetails = Order
+
 type CancelAllOrdersRequest struct {
 	Category      string  `json:"category"`
 	Symbol        *string `json:"symbol,omitempty"`
@@ -182,6 +222,7 @@ type GetOrderHistoryRequest struct {
 	BaseCoin    *string `json:"baseCoin,omitempty"`
 	SettleCoin  *string `json:"settleCoin,omitempty"`
 	OrderID     *string `json:"orderId,omitempty"`
+	OrderLinkID *string `json:"orderLinkId,omitempty"`
 	OrderFilter *string `json:"orderFilter,omitempty"`
 	OrderStatus *string `json:"orderStatus,omitempty"`
 	StartTime   *int64  `json:"startTime,omitempty"`
@@ -193,9 +234,9 @@ type GetOrderHistoryResponse struct {
 	RetCode int    `json:"retCode"`
 	RetMsg  string `json:"retMsg"`
 	Result  struct {
-		List           []OrderDetails `json:"list"`
-		NextPageCursor string         `json:"nextPageCursor"`
-		Category       string         `json:"category"`
+		List           []Order `json:"list"`
+		NextPageCursor string  `json:"nextPageCursor"`
+		Category       string  `json:"category"`
 	} `json:"result"`
 	RetExtInfo any   `json:"retExtInfo"`
 	Time       int64 `json:"time"`
@@ -216,45 +257,60 @@ type GetTradeHistoryResponse struct {
 	RetCode int    `json:"retCode"`
 	RetMsg  string `json:"retMsg"`
 	Result  struct {
-		List           []Details `json:"list"`
-		NextPageCursor string    `json:"nextPageCursor"`
-		Category       string    `json:"category"`
+		List           []Execution `json:"list"`
+		NextPageCursor string      `json:"nextPageCursor"`
+		Category       string      `json:"category"`
 	} `json:"result"`
 	RetExtInfo any   `json:"retExtInfo"`
 	Time       int64 `json:"time"`
 }
 
-type Details struct {
-	Symbol          string `json:"symbol"`
-	OrderID         string `json:"orderId"`
-	OrderLinkID     string `json:"orderLinkId"`
-	Side            string `json:"side"`
-	OrderPrice      string `json:"orderPrice"`
-	OrderQty        string `json:"orderQty"`
-	LeavesQty       string `json:"leavesQty"`
-	CreateType      string `json:"createType"`
-	OrderType       string `json:"orderType"`
-	StopOrderType   string `json:"stopOrderType"`
-	ExecFee         string `json:"execFee"`
-	ExecID          string `json:"execId"`
-	ExecPrice       string `json:"execPrice"`
-	ExecQty         string `json:"execQty"`
-	ExecType        string `json:"execType"`
-	ExecValue       string `json:"execValue"`
-	ExecTime        string `json:"execTime"`
-	FeeCurrency     string `json:"feeCurrency"`
-	IsMaker         bool   `json:"isMaker"`
-	FeeRate         string `json:"feeRate"`
-	TradeIv         string `json:"tradeIv"`
-	MarkIv          string `json:"markIv"`
-	MarkPrice       string `json:"markPrice"`
-	IndexPrice      string `json:"indexPrice"`
-	UnderlyingPrice string `json:"underlyingPrice"`
-	BlockTradeId    string `json:"blockTradeId"`
-	ClosedSize      string `json:"closedSize"`
-	Seq             int64  `json:"seq"`
+// Execution is a fill of an order as returned by the execution list endpoint. The implied
+// volatility and underlying price fields are only set for options.
+type Execution struct {
+	Symbol        string         `json:"symbol"`
+	OrderID       string         `json:"orderId"`
+	OrderLinkID   string         `json:"orderLinkId"`
+	Side          string         `json:"side"`
+	OrderPrice    client.Decimal `json:"orderPrice"`
+	OrderQty      client.Decimal `json:"orderQty"`
+	LeavesQty     client.Decimal `json:"leavesQty"`
+	CreateType    string         `json:"createType"`
+	OrderType     string         `json:"orderType"`
+	StopOrderType string         `json:"stopOrderType"`
+	ExecFee       client.Decimal `json:"execFee"`
+	ExecID        string         `json:"execId"`
+	ExecPrice     client.Decimal `json:"execPrice"`
+	ExecQty       client.Decimal `json:"execQty"`
+	// ExecType is Trade, AdlTrade, Funding, BustTrade, Delivery, Settle, BlockTrade or
+	// MovePosition.
+	ExecType  string         `json:"execType"`
+	ExecValue client.Decimal `json:"execValue"`
+	// ExecTime is Unix milliseconds.
+	ExecTime        client.Int     `json:"execTime"`
+	FeeCurrency     string         `json:"feeCurrency"`
+	IsMaker         bool           `json:"isMaker"`
+	FeeRate         client.Decimal `json:"feeRate"`
+	TradeIv         client.Decimal `json:"tradeIv"`
+	MarkIv          client.Decimal `json:"markIv"`
+	MarkPrice       client.Decimal `json:"markPrice"`
+	IndexPrice      client.Decimal `json:"indexPrice"`
+	UnderlyingPrice client.Decimal `json:"underlyingPrice"`
+	BlockTradeID    string         `json:"blockTradeId"`
+	ClosedSize      client.Decimal `json:"closedSize"`
+	Seq             int64          `json:"seq"`
 }
 
+// ExecutedAt returns the time of the fill.
+func (e Execution) ExecutedAt() time.Time {
+	return time.UnixMilli(int64(e.ExecTime))
+}
+
+// Details is the former name of Execution.
+//
+// Deprecated: use Execution.
+type Details = Execution
+
 type BatchPlaceOrderRequest struct {
 	Category string         `json:"category"`
 	Request  []OrderRequest `json:"request"`
@@ -307,6 +363,63 @@ type BatchPlaceOrderResponse struct {
 	} `json:"retExtInfo"`
 	Time int64 `json:"time"`
 }
+
+// BatchOrderResult is the outcome of one order of a batch. Code is 0 when the order was
+// placed.
+type BatchOrderResult struct {
+	Category    string
+	Symbol      string
+	OrderID     string
+	OrderLinkID string
+	// CreateAt is the creation time in Unix milliseconds, empty for a rejected order.
+	CreateAt string
+	Code     int
+	Msg      string
+	// Unknown is set when retExtInfo carries no entry for the order, so that whether it was
+	// placed is not known.
+	Unknown bool
+}
+
+// OK reports whether the order was placed. It is false when the outcome is Unknown.
+func (r BatchOrderResult) OK() bool {
+	return !r.Unknown && r.Code == 0
+}
+
+// Err returns nil for a placed order, ErrUnknownResult when the outcome is Unknown and an
+// *client.APIError carrying the order's code otherwise, so that its predicates such as
+// IsInsufficientBalance apply.
+func (r BatchOrderResult) Err() error {
+	switch {
+	case r.Unknown:
+		return ErrUnknownResult
+	case r.OK():
+		return nil
+	}
+	return &client.APIError{RetCode: r.Code, RetMsg: r.Msg, Method: client.POST, Endpoint: "/v5/order/create-batch"}
+}
+
+// Results pairs the orders of the response with their codes, in the order they were sent.
+// Orders without a retExtInfo entry are marked Unknown rather than reported as placed.
+func (r *BatchPlaceOrderResponse) Results() []BatchOrderResult {
+	results := make([]BatchOrderResult, len(r.Result.List))
+	for i, order := range r.Result.List {
+		results[i] = BatchOrderResult{
+			Category:    order.Category,
+			Symbol:      order.Symbol,
+			OrderID:     order.OrderID,
+			OrderLinkID: order.OrderLinkID,
+			CreateAt:    order.CreateAt,
+		}
+		if i < len(r.RetExtInfo.List) {
+			results[i].Code = r.RetExtInfo.List[i].Code
+			results[i].Msg = r.RetExtInfo.List[i].Msg
+		} else {
+			results[i].Unknown = true
+		}
+	}
+	return results
+}
+
 type BatchAmendOrderRequest struct {
 	Category string              `json:"category"`
 	Request  []AmendOrderRequest `json:"request"`
@@ -377,7 +490,8 @@ type BorrowQuotaResult struct {
 
 // SetDisconnectCancelAllRequest represents the request payload for setting DCP.
 type SetDisconnectCancelAllRequest struct {
-	TimeWindow int `json:"timeWindow"`
+	Product    *string `json:"product,omitempty"`
+	TimeWindow int     `json:"timeWindow"`
 }
 
 // APIResponse represents a generic response from the Bybit API.
@@ -1,25 +1,30 @@
 package trade
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
 )
 
 type Trade interface {
-	PlaceOrder(req *PlaceOrderRequest) (*PlaceOrderResponse, error)
-	AmendOrder(req *AmendOrderRequest) (*AmendOrderResponse, error)
-	CancelOrder(req *CancelOrderRequest) (*CancelOrderResponse, error)
-	GetOpenOrders(req *GetOpenOrdersRequest) (*GetOpenOrdersResponse, error)
-	CancelAllOrders(req *CancelAllOrdersRequest) (*CancelAllOrdersResponse, error)
-	GetOrderHistory(req *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error)
-	GetTradeHistory(req *GetTradeHistoryRequest) (*GetTradeHistoryResponse, error)
-	BatchPlaceOrder(req *BatchPlaceOrderRequest) (*BatchPlaceOrderResponse, error)
-	GetBorrowQuotaSpot(symbol, side string) (*BorrowQuotaResponse, error)
+	PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error)
+	AmendOrder(ctx context.Context, req *AmendOrderRequest) (*AmendOrderResponse, error)
+	CancelOrder(ctx context.Context, req *CancelOrderRequest) (*CancelOrderResponse, error)
+	GetOpenOrders(ctx context.Context, req *GetOpenOrdersRequest) (*GetOpenOrdersResponse, error)
+	CancelAllOrders(ctx context.Context, req *CancelAllOrdersRequest) (*CancelAllOrdersResponse, error)
+	GetOrderHistory(ctx context.Context, req *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error)
+	GetTradeHistory(ctx context.Context, req *GetTradeHistoryRequest) (*GetTradeHistoryResponse, error)
+	GetExecutionList(ctx context.Context, req *GetTradeHistoryRequest) (*GetTradeHistoryResponse, error)
+	BatchPlaceOrder(ctx context.Context, req *BatchPlaceOrderRequest) (*BatchPlaceOrderResponse, error)
+	BatchPlaceOrders(ctx context.Context, orders []PlaceOrderRequest) ([]BatchOrderResult, error)
+	GetBorrowQuotaSpot(ctx context.Context, symbol, side string) (*BorrowQuotaResponse, error)
+	SetDisconnectCancelAll(ctx context.Context, req *SetDisconnectCancelAllRequest) (*APIResponse, error)
 }
 
 // Helper function to generate cURL command from request parameters
@@ -40,9 +45,12 @@ func New(c *client.Client) Trade {
 	return &tradeImpl{client: c}
 }
 
-func (t *tradeImpl) PlaceOrder(req *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+// PlaceOrder creates a spot, contract or option order and returns the ids Bybit assigned to
+// it. The order is accepted asynchronously: confirm its state with GetOpenOrders or the order
+// WebSocket stream. A rejected order yields a *client.APIError carrying Bybit's retCode.
+func (t *tradeImpl) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error) {
 	params := ConvertPlaceOrderRequestToParams(req)
-	res, err := t.client.Post("/v5/order/create", params)
+	res, err := t.client.PostContext(ctx, "/v5/order/create", params)
 	if err != nil {
 		return nil, err
 	}
@@ -52,34 +60,38 @@ func (t *tradeImpl) PlaceOrder(req *PlaceOrderRequest) (*PlaceOrderResponse, err
 	if err != nil {
 		return nil, err
 	}
-	if placeOrderResponse.RetCode != 0 {
-		return &placeOrderResponse, fmt.Errorf("API returned error: %s", placeOrderResponse.RetMsg)
-	}
 	return &placeOrderResponse, nil
 }
 
+// ConvertPlaceOrderRequestToParams builds the body of an order creation. Booleans and
+// integers keep their JSON types, which Bybit requires, and unset fields are left out.
 func ConvertPlaceOrderRequestToParams(req *PlaceOrderRequest) client.Params {
 	params := client.Params{
-		"category":    req.Category,
-		"symbol":      req.Symbol,
-		"side":        req.Side,
-		"orderType":   req.OrderType,
-		"qty":         req.Qty,
-		"orderLinkId": req.OrderLinkID,
+		"category":  req.Category,
+		"symbol":    req.Symbol,
+		"side":      req.Side,
+		"orderType": req.OrderType,
+		"qty":       req.Qty,
 	}
 
+	if req.OrderLinkID != "" {
+		params["orderLinkId"] = req.OrderLinkID
+	}
+	if req.MarketUnit != nil {
+		params["marketUnit"] = *req.MarketUnit
+	}
 	if req.Price != "" {
 		params["price"] = req.Price
 	}
 
 	if req.IsLeverage != 0 {
-		params["isLeverage"] = strconv.Itoa(req.IsLeverage)
+		params["isLeverage"] = req.IsLeverage
 	}
 	if req.TriggerPrice != nil {
 		params["triggerPrice"] = *req.TriggerPrice
 	}
 	if req.TriggerDirection != nil {
-		params["triggerDirection"] = strconv.Itoa(*req.TriggerDirection)
+		params["triggerDirection"] = *req.TriggerDirection
 	}
 	if req.TriggerBy != nil {
 		params["triggerBy"] = *req.TriggerBy
@@ -94,7 +106,7 @@ func ConvertPlaceOrderRequestToParams(req *PlaceOrderRequest) client.Params {
 		params["timeInForce"] = req.TimeInForce
 	}
 	if req.PositionIdx != nil {
-		params["positionIdx"] = strconv.Itoa(*req.PositionIdx)
+		params["positionIdx"] = *req.PositionIdx
 	}
 	if req.TakeProfit != nil {
 		params["takeProfit"] = *req.TakeProfit
@@ -109,16 +121,16 @@ func ConvertPlaceOrderRequestToParams(req *PlaceOrderRequest) client.Params {
 		params["slTriggerBy"] = *req.SlTriggerBy
 	}
 	if req.ReduceOnly != nil {
-		params["reduceOnly"] = strconv.FormatBool(*req.ReduceOnly)
+		params["reduceOnly"] = *req.ReduceOnly
 	}
 	if req.CloseOnTrigger != nil {
-		params["closeOnTrigger"] = strconv.FormatBool(*req.CloseOnTrigger)
+		params["closeOnTrigger"] = *req.CloseOnTrigger
 	}
 	if req.SmpType != nil {
 		params["smpType"] = *req.SmpType
 	}
 	if req.Mmp != nil {
-		params["mmp"] = strconv.FormatBool(*req.Mmp)
+		params["mmp"] = *req.Mmp
 	}
 	if req.TpslMode != nil {
 		params["tpslMode"] = *req.TpslMode
@@ -138,18 +150,22 @@ func ConvertPlaceOrderRequestToParams(req *PlaceOrderRequest) client.Params {
 
 	return params
 }
-func (t *tradeImpl) AmendOrder(req *AmendOrderRequest) (*AmendOrderResponse, error) {
+
+// AmendOrder changes the price, quantity, trigger price or TP/SL of an open order, found by
+// OrderID or, when OrderID is not set, by OrderLinkID. An order that does not exist, is
+// already filled or canceled, or is too late to amend yields an error matching
+// ErrOrderNotFound.
+func (t *tradeImpl) AmendOrder(ctx context.Context, req *AmendOrderRequest) (*AmendOrderResponse, error) {
+	if (req.OrderID == nil || *req.OrderID == "") && (req.OrderLinkID == nil || *req.OrderLinkID == "") {
+		return nil, errors.New("amend order: orderId or orderLinkId is required")
+	}
 	params := ConvertAmendOrderRequestToParams(req)
-	res, err := t.client.Post("/v5/order/amend", params)
+	res, err := t.client.PostContext(ctx, "/v5/order/amend", params)
 	if err != nil {
-		return nil, err
-	}
-	data, err := json.Marshal(res)
-	if err != nil {
-		return nil, err
+		return nil, orderError(err)
 	}
 	var response AmendOrderResponse
-	err = json.Unmarshal(data, &response)
+	err = res.Unmarshal(&response)
 	if err != nil {
 		return nil, err
 	}
@@ -160,10 +176,10 @@ func (t *tradeImpl) AmendOrder(req *AmendOrderRequest) (*AmendOrderResponse, err
 
 	return &response, nil
 }
-func (t *tradeImpl) CancelOrder(req *CancelOrderRequest) (*CancelOrderResponse, error) {
+func (t *tradeImpl) CancelOrder(ctx context.Context, req *CancelOrderRequest) (*CancelOrderResponse, error) {
 	params := ConvertCancelOrderRequestToParams(req)
 
-	resBytes, err := t.client.Post("/v5/order/cancel", params)
+	resBytes, err := t.client.PostContext(ctx, "/v5/order/cancel", params)
 	if err != nil {
 		return nil, err
 	}
@@ -183,11 +199,14 @@ func (t *tradeImpl) CancelOrder(req *CancelOrderRequest) (*CancelOrderResponse,
 
 	return &response, nil
 }
-func (t *tradeImpl) GetOpenOrders(req *GetOpenOrdersRequest) (*GetOpenOrdersResponse, error) {
+
+// GetOpenOrders returns a page of the open orders matching req, and of the orders closed in
+// the last 10 minutes unless OpenOnly is set. Use OpenOrders to walk every page.
+func (t *tradeImpl) GetOpenOrders(ctx context.Context, req *GetOpenOrdersRequest) (*GetOpenOrdersResponse, error) {
 	queryParams := ConvertGetOpenOrdersRequestToParams(req)
 
 	// Assuming the client.Get method constructs the query string from the provided params and sends a GET request.
-	resBytes, err := t.client.Get("/v5/order/realtime", queryParams)
+	resBytes, err := t.client.GetContext(ctx, "/v5/order/realtime", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -202,10 +221,10 @@ func (t *tradeImpl) GetOpenOrders(req *GetOpenOrdersRequest) (*GetOpenOrdersResp
 
 	return &response, nil
 }
-func (t *tradeImpl) CancelAllOrders(req *CancelAllOrdersRequest) (*CancelAllOrdersResponse, error) {
+func (t *tradeImpl) CancelAllOrders(ctx context.Context, req *CancelAllOrdersRequest) (*CancelAllOrdersResponse, error) {
 	params := ConvertCancelAllOrdersRequestToParams(req)
 
-	resBytes, err := t.client.Post("/v5/order/cancel-all", params)
+	resBytes, err := t.client.PostContext(ctx, "/v5/order/cancel-all", params)
 	if err != nil {
 		return nil, err
 	}
@@ -226,10 +245,19 @@ func (t *tradeImpl) CancelAllOrders(req *CancelAllOrdersRequest) (*CancelAllOrde
 	return &response, nil
 }
 
-func (t *tradeImpl) GetOrderHistory(req *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error) {
+// GetOrderHistory returns a page of the orders matching req, newest first. StartTime and
+// EndTime, in Unix milliseconds, may span at most 7 days; without them Bybit returns the
+// last 7 days. Use OrderHistory to walk every page and OrderHistoryBetween for longer
+// ranges.
+func (t *tradeImpl) GetOrderHistory(ctx context.Context, req *GetOrderHistoryRequest) (*GetOrderHistoryResponse, error) {
+	if req.StartTime != nil && req.EndTime != nil {
+		if span := time.Duration(*req.EndTime-*req.StartTime) * time.Millisecond; span < 0 || span > MaxOrderHistorySpan {
+			return nil, fmt.Errorf("order history: the range from startTime to endTime must be between 0 and %v, got %v", MaxOrderHistorySpan, span)
+		}
+	}
 	queryParams := ConvertGetOrderHistoryRequestToParams(req)
 
-	response, err := t.client.Get("/v5/order/history", queryParams)
+	response, err := t.client.GetContext(ctx, "/v5/order/history", queryParams)
 
 	if err != nil {
 		return nil, err
@@ -246,11 +274,13 @@ func (t *tradeImpl) GetOrderHistory(req *GetOrderHistoryRequest) (*GetOrderHisto
 	return &orderHistoryResponse, nil
 }
 
-func (t *tradeImpl) GetTradeHistory(req *GetTradeHistoryRequest) (*GetTradeHistoryResponse, error) {
+// GetExecutionList returns a page of the fills matching req, newest first. StartTime and
+// EndTime, in Unix milliseconds, may span at most 7 days; without them Bybit returns the
+// last 7 days. Use Executions to walk every page.
+func (t *tradeImpl) GetExecutionList(ctx context.Context, req *GetTradeHistoryRequest) (*GetTradeHistoryResponse, error) {
 	queryParams := ConvertGetTradeHistoryRequestToParams(req)
 
-	// Assuming the client.Get method constructs the query string from the provided params and sends a GET request.
-	resBytes, err := t.client.Get("/v5/execution/list", queryParams)
+	resBytes, err := t.client.GetContext(ctx, "/v5/execution/list", queryParams)
 	if err != nil {
 		return nil, err
 	}
@@ -266,33 +296,75 @@ func (t *tradeImpl) GetTradeHistory(req *GetTradeHistoryRequest) (*GetTradeHisto
 
 	return &response, nil
 }
-func (t *tradeImpl) BatchPlaceOrder(req *BatchPlaceOrderRequest) (*BatchPlaceOrderResponse, error) {
-	params := ConvertBatchPlaceOrderRequestToParams(req)
-	resBytes, err := t.client.Post("/v5/order/create-batch", params)
+
+// GetTradeHistory returns a page of the fills matching req.
+//
+// Deprecated: use GetExecutionList.
+func (t *tradeImpl) GetTradeHistory(ctx context.Context, req *GetTradeHistoryRequest) (*GetTradeHistoryResponse, error) {
+	return t.GetExecutionList(ctx, req)
+}
+
+// BatchPlaceOrder creates orders of one category in a single request: up to 10 spot orders
+// or 20 linear, inverse or option orders. See BatchPlaceOrders for a variant taking
+// PlaceOrderRequests.
+func (t *tradeImpl) BatchPlaceOrder(ctx context.Context, req *BatchPlaceOrderRequest) (*BatchPlaceOrderResponse, error) {
+	return t.batchPlace(ctx, ConvertBatchPlaceOrderRequestToParams(req))
+}
+
+// maxBatchOrders is the number of orders a batch may hold per category.
+var maxBatchOrders = map[string]int{"spot": 10, "linear": 20, "inverse": 20, "option": 20}
+
+// BatchPlaceOrders creates orders of one category, spot, linear, inverse or option, in a
+// single request: up to 10 spot orders or 20 orders of the other categories. Bybit accepts
+// or rejects each order on its own, so a nil error only means that the batch was
+// processed; the result of each order, in the order of orders, tells which ones were
+// placed.
+func (t *tradeImpl) BatchPlaceOrders(ctx context.Context, orders []PlaceOrderRequest) ([]BatchOrderResult, error) {
+	if len(orders) == 0 {
+		return nil, errors.New("batch place orders: no orders")
+	}
+	category := orders[0].Category
+	limit, ok := maxBatchOrders[category]
+	if !ok {
+		return nil, fmt.Errorf("batch place orders: unsupported category %q", category)
+	}
+	if len(orders) > limit {
+		return nil, fmt.Errorf("batch place orders: %d %s orders exceed the limit of %d", len(orders), category, limit)
+	}
+	request := make([]client.Params, len(orders))
+	for i := range orders {
+		if orders[i].Category != category {
+			return nil, fmt.Errorf("batch place orders: order %d is %s, not %s", i, orders[i].Category, category)
+		}
+		request[i] = ConvertPlaceOrderRequestToParams(&orders[i])
+		delete(request[i], "category")
+	}
+	res, err := t.batchPlace(ctx, client.Params{"category": category, "request": request})
 	if err != nil {
 		return nil, err
 	}
-	data, err := json.Marshal(resBytes)
+	return res.Results(), nil
+}
+
+func (t *tradeImpl) batchPlace(ctx context.Context, params client.Params) (*BatchPlaceOrderResponse, error) {
+	res, err := t.client.PostContext(ctx, "/v5/order/create-batch", params)
 	if err != nil {
 		return nil, err
 	}
 	var response BatchPlaceOrderResponse
-	err = json.Unmarshal(data, &response)
-	if err != nil {
+	if err := res.Unmarshal(&response); err != nil {
 		return nil, err
 	}
-
 	if response.RetCode != 0 {
 		return &response, fmt.Errorf("API returned error: %s", response.RetMsg)
 	}
-
 	return &response, nil
 }
 
-func (t *tradeImpl) BatchAmendOrder(req *BatchAmendOrderRequest) (*BatchAmendOrderResponse, error) {
+func (t *tradeImpl) BatchAmendOrder(ctx context.Context, req *BatchAmendOrderRequest) (*BatchAmendOrderResponse, error) {
 	params := ConvertBatchAmendOrderRequestToParams(req)
 
-	resBytes, err := t.client.Post("/v5/order/amend-batch", params)
+	resBytes, err := t.client.PostContext(ctx, "/v5/order/amend-batch", params)
 	if err != nil {
 		return nil, err
 	}
@@ -312,10 +384,10 @@ func (t *tradeImpl) BatchAmendOrder(req *BatchAmendOrderRequest) (*BatchAmendOrd
 
 	return &response, nil
 }
-func (t *tradeImpl) BatchCancelOrder(req *BatchCancelOrderRequest) (*BatchCancelOrderResponse, error) {
+func (t *tradeImpl) BatchCancelOrder(ctx context.Context, req *BatchCancelOrderRequest) (*BatchCancelOrderResponse, error) {
 	params := ConvertBatchCancelOrderRequestToParams(req)
 
-	resBytes, err := t.client.Post("/v5/order/cancel-batch", params)
+	resBytes, err := t.client.PostContext(ctx, "/v5/order/cancel-batch", params)
 	if err != nil {
 		return nil, err
 	}
@@ -335,13 +407,13 @@ func (t *tradeImpl) BatchCancelOrder(req *BatchCancelOrderRequest) (*BatchCancel
 
 	return &response, nil
 }
-func (t *tradeImpl) GetBorrowQuotaSpot(symbol, side string) (*BorrowQuotaResponse, error) {
+func (t *tradeImpl) GetBorrowQuotaSpot(ctx context.Context, symbol, side string) (*BorrowQuotaResponse, error) {
 	params := client.Params{
 		"category": "spot",
 		"symbol":   symbol,
 		"side":     side,
 	}
-	resBytes, err := t.client.Get("/v5/order/spot-borrow-check", params)
+	resBytes, err := t.client.GetContext(ctx, "/v5/order/spot-borrow-check", params)
 	if err != nil {
 		return nil, err
 	}
@@ -363,21 +435,20 @@ func (t *tradeImpl) GetBorrowQuotaSpot(symbol, side string) (*BorrowQuotaRespons
 
 	return &response, nil
 }
-func (t *tradeImpl) SetDisconnectCancelAll(req *SetDisconnectCancelAllRequest) (*APIResponse, error) {
+func (t *tradeImpl) SetDisconnectCancelAll(ctx context.Context, req *SetDisconnectCancelAllRequest) (*APIResponse, error) {
 	dcpRequest := NewDCPParams(req.TimeWindow)
+	if req.Product != nil {
+		dcpRequest["product"] = *req.Product
+	}
 
 	// Send POST request to the Bybit API
-	responseBody, err := t.client.Post("/v5/order/disconnected-cancel-all", dcpRequest)
+	responseBody, err := t.client.PostContext(ctx, "/v5/order/disconnected-cancel-all", dcpRequest)
 	if err != nil {
 		return nil, fmt.Errorf("error sending request to API: %w", err)
 	}
-	data, err := json.Marshal(responseBody)
-	if err != nil {
-		return nil, err
-	}
 	// Parse the JSON response
 	var response APIResponse
-	err = json.Unmarshal(data, &response)
+	err = responseBody.Unmarshal(&response)
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshalling response: %w", err)
 	}
@@ -0,0 +1,298 @@
+package trade
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+func TestPlaceOrder(t *testing.T) {
+	mock := bybittest.NewMock()
+	reduceOnly, positionIdx, takeProfit := true, 1, "31000"
+	res, err := New(mock.Client()).PlaceOrder(context.Background(), &PlaceOrderRequest{
+		Category:    "linear",
+		Symbol:      "BTCUSDT",
+		Side:        "Sell",
+		OrderType:   "Limit",
+		Qty:         "0.01",
+		Price:       "30000",
+		TimeInForce: "GTC",
+		ReduceOnly:  &reduceOnly,
+		PositionIdx: &positionIdx,
+		TakeProfit:  &takeProfit,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Result.OrderID != "1321003749386327552" || res.Result.OrderLinkID != "spot-test-postonly" {
+		t.Errorf("unexpected result %+v", res.Result)
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 1 || requests[0].Path != "/v5/order/create" {
+		t.Fatalf("unexpected requests %+v", requests)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(requests[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["reduceOnly"] != true || body["positionIdx"] != float64(1) || body["takeProfit"] != "31000" || body["qty"] != "0.01" {
+		t.Errorf("unexpected body %s", requests[0].Body)
+	}
+	for _, unset := range []string{"orderLinkId", "isLeverage", "closeOnTrigger", "marketUnit"} {
+		if _, ok := body[unset]; ok {
+			t.Errorf("expected %s to be left out of %s", unset, requests[0].Body)
+		}
+	}
+
+	mock.HandleError(http.MethodPost, "/v5/order/create", 10001, "params error: qty invalid")
+	res, err = New(mock.Client()).PlaceOrder(context.Background(), &PlaceOrderRequest{Category: "linear", Symbol: "BTCUSDT", Side: "Buy", OrderType: "Market", Qty: "0"})
+	var apiErr *client.APIError
+	if res != nil || !errors.As(err, &apiErr) || apiErr.RetCode != 10001 {
+		t.Errorf("expected the API error of the rejected order, got %v, %v", res, err)
+	}
+}
+
+func TestBatchPlaceOrders(t *testing.T) {
+	mock := bybittest.NewMock()
+	tr := New(mock.Client())
+	reduceOnly := true
+	results, err := tr.BatchPlaceOrders(context.Background(), []PlaceOrderRequest{
+		{Category: "linear", Symbol: "BTCUSDT", Side: "Buy", OrderType: "Limit", Qty: "0.01", Price: "30000", OrderLinkID: "linear-batch-1"},
+		{Category: "linear", Symbol: "ETHUSDT", Side: "Sell", OrderType: "Market", Qty: "1", OrderLinkID: "linear-batch-2", ReduceOnly: &reduceOnly},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %+v", results)
+	}
+	if !results[0].OK() || results[0].Err() != nil || results[0].OrderID != "b2e3b5a4-2b0b-4b6a-9b5d-1f0c3a8e7d10" {
+		t.Errorf("expected the first order to be placed, got %+v", results[0])
+	}
+	var apiErr *client.APIError
+	if results[1].OK() || !errors.As(results[1].Err(), &apiErr) || !apiErr.IsInsufficientBalance() || results[1].OrderLinkID != "linear-batch-2" {
+		t.Errorf("expected the second order to be rejected, got %+v", results[1])
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 1 || requests[0].Path != "/v5/order/create-batch" {
+		t.Fatalf("unexpected requests %+v", requests)
+	}
+	var body struct {
+		Category string           `json:"category"`
+		Request  []map[string]any `json:"request"`
+	}
+	if err := json.Unmarshal(requests[0].Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Category != "linear" || len(body.Request) != 2 {
+		t.Fatalf("unexpected body %s", requests[0].Body)
+	}
+	if _, ok := body.Request[0]["category"]; ok {
+		t.Errorf("expected category to be left out of the orders in %s", requests[0].Body)
+	}
+	if body.Request[1]["reduceOnly"] != true || body.Request[1]["symbol"] != "ETHUSDT" {
+		t.Errorf("unexpected body %s", requests[0].Body)
+	}
+
+	for name, orders := range map[string][]PlaceOrderRequest{
+		"empty":         nil,
+		"mixed":         {{Category: "linear", Symbol: "BTCUSDT"}, {Category: "spot", Symbol: "BTCUSDT"}},
+		"unsupported":   {{Category: "spread", Symbol: "BTCUSDT"}},
+		"too many spot": make([]PlaceOrderRequest, 11),
+	} {
+		if name == "too many spot" {
+			for i := range orders {
+				orders[i] = PlaceOrderRequest{Category: "spot", Symbol: "BTCUSDT"}
+			}
+		}
+		if _, err := tr.BatchPlaceOrders(context.Background(), orders); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if len(mock.Requests()) != 1 {
+		t.Error("expected invalid batches not to be sent")
+	}
+
+	mock.Handle(http.MethodPost, "/v5/order/create-batch", http.StatusOK, `{"retCode":0,"retMsg":"OK","result":{"list":[`+
+		`{"category":"linear","symbol":"BTCUSDT","orderId":"1","orderLinkId":"a","createAt":"1"},`+
+		`{"category":"linear","symbol":"ETHUSDT","orderId":"","orderLinkId":"b","createAt":""}]},`+
+		`"retExtInfo":{"list":[{"code":0,"msg":"OK"}]},"time":1}`)
+	results, err = tr.BatchPlaceOrders(context.Background(), []PlaceOrderRequest{
+		{Category: "linear", Symbol: "BTCUSDT", OrderLinkID: "a"},
+		{Category: "linear", Symbol: "ETHUSDT", OrderLinkID: "b"},
+	})
+	if err != nil || len(results) != 2 {
+		t.Fatalf("unexpected results %+v, %v", results, err)
+	}
+	if !results[0].OK() || results[0].Unknown {
+		t.Errorf("expected the reported order to be placed, got %+v", results[0])
+	}
+	if results[1].OK() || !results[1].Unknown || !errors.Is(results[1].Err(), ErrUnknownResult) {
+		t.Errorf("expected the unreported order to be unknown, got %+v", results[1])
+	}
+}
+
+func TestAmendOrder(t *testing.T) {
+	mock := bybittest.NewMock()
+	tr := New(mock.Client())
+	orderLinkID, price := "linear-004", "30500"
+	res, err := tr.AmendOrder(context.Background(), &AmendOrderRequest{Category: "linear", Symbol: "BTCUSDT", OrderLinkID: &orderLinkID, Price: &price})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Result.OrderID != "c6f055d9-7f21-4079-913d-e6523a9cfffa" || res.Result.OrderLinkID != "linear-004" {
+		t.Errorf("unexpected result %+v", res.Result)
+	}
+	if requests := mock.Requests(); len(requests) != 1 || requests[0].Path != "/v5/order/amend" {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+
+	if _, err := tr.AmendOrder(context.Background(), &AmendOrderRequest{Category: "linear", Symbol: "BTCUSDT", Price: &price}); err == nil {
+		t.Error("expected an error without orderId or orderLinkId")
+	}
+
+	mock.HandleError(http.MethodPost, "/v5/order/amend", client.RetCodeOrderNotFound, "order not exists or too late to amend")
+	_, err = tr.AmendOrder(context.Background(), &AmendOrderRequest{Category: "linear", Symbol: "BTCUSDT", OrderLinkID: &orderLinkID, Price: &price})
+	var apiErr *client.APIError
+	if !errors.Is(err, ErrOrderNotFound) || !errors.As(err, &apiErr) || apiErr.RetCode != client.RetCodeOrderNotFound {
+		t.Errorf("expected ErrOrderNotFound wrapping the API error, got %v", err)
+	}
+
+	mock.HandleError(http.MethodPost, "/v5/order/amend", client.RetCodeRateLimited, "Too many visits!")
+	if _, err := tr.AmendOrder(context.Background(), &AmendOrderRequest{Category: "linear", Symbol: "BTCUSDT", OrderLinkID: &orderLinkID, Price: &price}); errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("expected a rate limit not to match ErrOrderNotFound, got %v", err)
+	}
+}
+
+func TestGetOpenOrders(t *testing.T) {
+	mock := bybittest.NewMock()
+	symbol, limit := "ETHUSDT", 20
+	res, err := New(mock.Client()).GetOpenOrders(context.Background(), &GetOpenOrdersRequest{Category: "linear", Symbol: &symbol, Limit: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Result.List) != 1 || res.Result.NextPageCursor == "" {
+		t.Fatalf("unexpected result %+v", res.Result)
+	}
+	order := res.Result.List[0]
+	if !order.Price.Equal(client.MustParseDecimal("1600")) || !order.LeavesValue.Equal(client.MustParseDecimal("160")) {
+		t.Errorf("unexpected price %s or leaves value %s", order.Price, order.LeavesValue)
+	}
+	if !order.TakeProfit.Equal(client.MustParseDecimal("2500")) || !order.StopLoss.Equal(client.MustParseDecimal("1500")) || !order.TriggerPrice.IsZero() || !order.OrderIv.IsZero() {
+		t.Errorf("unexpected TP/SL %+v", order)
+	}
+	if !order.IsOpen() || order.CreatedAt().UnixMilli() != 1684738540559 || order.UpdatedAt().UnixMilli() != 1684738540561 {
+		t.Errorf("unexpected status or times %+v", order)
+	}
+	if requests := mock.Requests(); len(requests) != 1 || requests[0].Query.Get("symbol") != "ETHUSDT" || requests[0].Query.Get("limit") != "20" {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+}
+
+func TestGetOrderHistory(t *testing.T) {
+	mock := bybittest.NewMock()
+	status, start := "Filled", time.Now().Add(-24*time.Hour).UnixMilli()
+	end := start + 3600000
+	tr := New(mock.Client())
+	res, err := tr.GetOrderHistory(context.Background(), &GetOrderHistoryRequest{Category: "linear", OrderStatus: &status, StartTime: &start, EndTime: &end})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Result.List) != 1 || res.Result.List[0].IsOpen() || !res.Result.List[0].CumExecFee.Equal(client.MustParseDecimal("0.096")) {
+		t.Errorf("unexpected orders %+v", res.Result.List)
+	}
+	requests := mock.Requests()
+	if len(requests) != 1 || requests[0].Query.Get("orderStatus") != "Filled" || requests[0].Query.Get("startTime") != strconv.FormatInt(start, 10) {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+
+	end = start + MaxOrderHistorySpan.Milliseconds() + 1
+	if _, err := tr.GetOrderHistory(context.Background(), &GetOrderHistoryRequest{Category: "linear", StartTime: &start, EndTime: &end}); err == nil {
+		t.Error("expected an error for a range longer than 7 days")
+	}
+	if len(mock.Requests()) != 1 {
+		t.Error("expected the invalid range not to be sent")
+	}
+}
+
+func TestOrderHistoryBetween(t *testing.T) {
+	var windows [][2]int64
+	mock := bybittest.NewMock()
+	mock.HandleFunc(http.MethodGet, "/v5/order/history", func(req bybittest.Request) string {
+		start, _ := strconv.ParseInt(req.Query.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(req.Query.Get("endTime"), 10, 64)
+		windows = append(windows, [2]int64{start, end})
+		return fmt.Sprintf(`{"retCode":0,"retMsg":"OK","result":{"category":"linear","list":[{"orderId":"%d","createdTime":"%d"}],"nextPageCursor":""},"time":1}`, len(windows), end)
+	})
+	c := mock.Client()
+
+	from := time.UnixMilli(1_700_000_000_000)
+	to := from.Add(20 * 24 * time.Hour)
+	var ids []string
+	err := OrderHistoryBetween(context.Background(), New(c), GetOrderHistoryRequest{Category: "linear"}, from, to, func(order Order) error {
+		ids = append(ids, order.OrderID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ids, ",") != "1,2,3" || len(windows) != 3 {
+		t.Fatalf("expected 3 windows, got %v for orders %v", windows, ids)
+	}
+	if windows[0][1] != to.UnixMilli() || windows[2][0] != from.UnixMilli() {
+		t.Errorf("expected the windows to cover %v to %v, got %v", from, to, windows)
+	}
+	for i, window := range windows {
+		if span := time.Duration(window[1]-window[0]) * time.Millisecond; span > MaxOrderHistorySpan {
+			t.Errorf("window %d spans %v", i, span)
+		}
+		if i > 0 && window[1] != windows[i-1][0]-1 {
+			t.Errorf("window %d does not end right before window %d", i, i-1)
+		}
+	}
+
+	stop := errors.New("stop")
+	if err := OrderHistoryBetween(context.Background(), New(c), GetOrderHistoryRequest{Category: "linear"}, from, to, func(Order) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("expected the callback error, got %v", err)
+	}
+}
+
+func TestGetExecutionList(t *testing.T) {
+	mock := bybittest.NewMock()
+	orderID := "fd4300ae-7847-404e-b947-b46980a4d140"
+	fills, err := Executions(New(mock.Client()), GetTradeHistoryRequest{Category: "linear", OrderID: &orderID}).All(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != 1 {
+		t.Fatalf("expected 1 fill, got %d", len(fills))
+	}
+	fill := fills[0]
+	if !fill.ExecPrice.Equal(client.MustParseDecimal("1600")) || !fill.ExecQty.Equal(client.MustParseDecimal("0.1")) || !fill.ExecFee.Equal(client.MustParseDecimal("0.096")) {
+		t.Errorf("unexpected fill %+v", fill)
+	}
+	if !fill.IsMaker || !fill.FeeRate.Equal(client.MustParseDecimal("0.0006")) || !fill.TradeIv.IsZero() || fill.ExecutedAt().UnixMilli() != 1684738541200 {
+		t.Errorf("unexpected fee or time %+v", fill)
+	}
+	if requests := mock.Requests(); len(requests) != 1 || requests[0].Path != "/v5/execution/list" || requests[0].Query.Get("orderId") != orderID {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+
+	// The former names are aliases, so values convert without a conversion.
+	var details Details = fill
+	var orderDetails OrderDetails = Order{OrderID: orderID}
+	if details.BlockTradeID != "" || orderDetails.OrderID != orderID {
+		t.Errorf("unexpected aliases %+v %+v", details, orderDetails)
+	}
+}
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+type APIKey struct {
+	client *client.Client
+}
+
+func NewAPIKey(client *client.Client) *APIKey {
+	return &APIKey{client: client}
+}
+
+// Get queries the permissions, IP binding and expiry of the API key the client signs with.
+func (k *APIKey) Get(ctx context.Context) (*APIKeyResponse, error) {
+	res, err := k.client.GetContext(ctx, "/v5/user/query-api", nil)
+	if err != nil {
+		return nil, err
+	}
+	var response APIKeyResponse
+	if err := res.Unmarshal(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CheckPermissions fails when the API key lacks a required permission or expires within
+// minValidity, so that a bot can refuse to start instead of failing on its first order:
+//
+//	err := u.APIKey().CheckPermissions(ctx, 7*24*time.Hour, user.PermissionOrder, user.PermissionAccountTransfer)
+//
+// A key that fails the check yields a *PermissionError.
+func (k *APIKey) CheckPermissions(ctx context.Context, minValidity time.Duration, required ...Permission) error {
+	res, err := k.Get(ctx)
+	if err != nil {
+		return err
+	}
+	return res.Result.CheckPermissions(minValidity, required...)
+}
@@ -0,0 +1,78 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Permission is a right of an API key, named by its group and name in the permissions of
+// /v5/user/query-api.
+type Permission struct {
+	Group string
+	Name  string
+}
+
+func (p Permission) String() string {
+	return p.Group + "." + p.Name
+}
+
+var (
+	PermissionOrder             = Permission{Group: "ContractTrade", Name: "Order"}
+	PermissionPosition          = Permission{Group: "ContractTrade", Name: "Position"}
+	PermissionSpotTrade         = Permission{Group: "Spot", Name: "SpotTrade"}
+	PermissionOptionsTrade      = Permission{Group: "Options", Name: "OptionsTrade"}
+	PermissionDerivativesTrade  = Permission{Group: "Derivatives", Name: "DerivativesTrade"}
+	PermissionAccountTransfer   = Permission{Group: "Wallet", Name: "AccountTransfer"}
+	PermissionSubMemberTransfer = Permission{Group: "Wallet", Name: "SubMemberTransfer"}
+	PermissionWithdraw          = Permission{Group: "Wallet", Name: "Withdraw"}
+	PermissionExchangeHistory   = Permission{Group: "Exchange", Name: "ExchangeHistory"}
+	PermissionCopyTrading       = Permission{Group: "CopyTrading", Name: "CopyTrading"}
+)
+
+// PermissionError is returned by CheckPermissions when the API key does not meet the
+// requirements.
+type PermissionError struct {
+	// Missing lists the required permissions the key lacks; a read-only key lacks all.
+	Missing  []Permission
+	ReadOnly bool
+	// ExpiresAt is set when the key expires before the required validity.
+	ExpiresAt time.Time
+}
+
+func (e *PermissionError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		missing := make([]string, len(e.Missing))
+		for i, permission := range e.Missing {
+			missing[i] = permission.String()
+		}
+		problems = append(problems, "missing permissions "+strings.Join(missing, ", "))
+	}
+	if e.ReadOnly {
+		problems = append(problems, "read-only")
+	}
+	if !e.ExpiresAt.IsZero() {
+		problems = append(problems, fmt.Sprintf("expires at %s", e.ExpiresAt.Format(time.RFC3339)))
+	}
+	return "api key " + strings.Join(problems, "; ")
+}
+
+// CheckPermissions returns a *PermissionError when the key lacks a required permission, is
+// read-only while permissions are required, or expires within minValidity.
+func (k *APIKeyInfo) CheckPermissions(minValidity time.Duration, required ...Permission) error {
+	var err PermissionError
+	for _, permission := range required {
+		if k.ReadOnly == 1 || !k.HasPermission(permission) {
+			err.Missing = append(err.Missing, permission)
+		}
+	}
+	err.ReadOnly = k.ReadOnly == 1 && len(required) > 0
+	if expiresAt, ok := k.ExpiresAt(); ok && time.Until(expiresAt) < minValidity {
+		err.ExpiresAt = expiresAt
+	}
+	if len(err.Missing) == 0 && err.ExpiresAt.IsZero() {
+		return nil
+	}
+	return &err
+}
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+)
+
+type BaseResponse struct {
+	RetCode    int            `json:"retCode"`
+	RetMsg     string         `json:"retMsg"`
+	Time       int64          `json:"time"`
+	RetExtInfo map[string]any `json:"retExtInfo"`
+}
+
+// APIKeyResponse is the response of the /v5/user/query-api endpoint.
+type APIKeyResponse struct {
+	BaseResponse
+	Result APIKeyInfo `json:"result"`
+}
+
+// APIKeyInfo describes the API key the client signs with.
+type APIKeyInfo struct {
+	ID     string `json:"id"`
+	Note   string `json:"note"`
+	APIKey string `json:"apiKey"`
+	// ReadOnly is 1 for read-only keys and 0 for read-write keys.
+	ReadOnly int `json:"readOnly"`
+	// Permissions maps each permission group, such as ContractTrade, to the permissions
+	// the key holds in it.
+	Permissions map[string][]string `json:"permissions"`
+	IPs         []string            `json:"ips"`
+	// Type is 1 for personal and 2 for third-party application keys.
+	Type int `json:"type"`
+	// DeadlineDay is the number of days until the key expires.
+	DeadlineDay int `json:"deadlineDay"`
+	// ExpiredAt is in RFC 3339 format, and empty for keys that do not expire, which are
+	// the keys bound to IPs.
+	ExpiredAt     string     `json:"expiredAt"`
+	CreatedAt     string     `json:"createdAt"`
+	Unified       int        `json:"unified"`
+	UTA           int        `json:"uta"`
+	UserID        int64      `json:"userID"`
+	InviterID     int64      `json:"inviterID"`
+	VipLevel      string     `json:"vipLevel"`
+	MktMakerLevel client.Int `json:"mktMakerLevel"`
+	AffiliateID   int64      `json:"affiliateID"`
+	RSAPublicKey  string     `json:"rsaPublicKey"`
+	IsMaster      bool       `json:"isMaster"`
+	ParentUID     client.Int `json:"parentUid"`
+	KYCLevel      string     `json:"kycLevel"`
+	KYCRegion     string     `json:"kycRegion"`
+}
+
+// ExpiresAt returns when the key expires, reporting false for keys that do not expire.
+func (k *APIKeyInfo) ExpiresAt() (time.Time, bool) {
+	expiresAt, err := time.Parse(time.RFC3339, k.ExpiredAt)
+	if err != nil || expiresAt.IsZero() {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
+
+// HasPermission reports whether the key holds permission.
+func (k *APIKeyInfo) HasPermission(permission Permission) bool {
+	for _, name := range k.Permissions[permission.Group] {
+		if name == permission.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package user
+
+import "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+
+type User interface {
+	APIKey() *APIKey
+}
+
+type user struct {
+	client *client.Client
+}
+
+func (u *user) APIKey() *APIKey {
+	return NewAPIKey(u.client)
+}
+
+func New(client_ *client.Client) User {
+	return &user{client: client_}
+}
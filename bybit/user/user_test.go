@@ -0,0 +1,61 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/bybittest"
+)
+
+func TestAPIKey_Get(t *testing.T) {
+	mock := bybittest.NewMock()
+	res, err := New(mock.Client()).APIKey().Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := res.Result
+	if info.ID != "13770661" || info.DeadlineDay != 66 || !info.IsMaster || info.UserID != 24617703 {
+		t.Errorf("unexpected key info %+v", info)
+	}
+	if !info.HasPermission(PermissionOrder) || !info.HasPermission(PermissionAccountTransfer) || info.HasPermission(PermissionWithdraw) {
+		t.Errorf("unexpected permissions %v", info.Permissions)
+	}
+	if expiresAt, ok := info.ExpiresAt(); !ok || !expiresAt.Equal(time.Date(2023, 12, 22, 7, 20, 25, 0, time.UTC)) {
+		t.Errorf("unexpected expiry %v", expiresAt)
+	}
+	if requests := mock.Requests(); len(requests) != 1 || requests[0].Path != "/v5/user/query-api" {
+		t.Errorf("unexpected requests %+v", requests)
+	}
+}
+
+func TestAPIKeyInfo_CheckPermissions(t *testing.T) {
+	info := APIKeyInfo{
+		Permissions: map[string][]string{"ContractTrade": {"Order", "Position"}, "Wallet": {}},
+		ExpiredAt:   time.Now().Add(72 * time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	if err := info.CheckPermissions(24*time.Hour, PermissionOrder, PermissionPosition); err != nil {
+		t.Errorf("expected the key to pass, got %v", err)
+	}
+
+	var permErr *PermissionError
+	err := info.CheckPermissions(7*24*time.Hour, PermissionOrder, PermissionAccountTransfer)
+	if !errors.As(err, &permErr) {
+		t.Fatalf("expected a permission error, got %v", err)
+	}
+	if len(permErr.Missing) != 1 || permErr.Missing[0] != PermissionAccountTransfer || permErr.ExpiresAt.IsZero() {
+		t.Errorf("unexpected error %+v", permErr)
+	}
+
+	info.ReadOnly = 1
+	info.ExpiredAt = ""
+	if err := info.CheckPermissions(7 * 24 * time.Hour); err != nil {
+		t.Errorf("expected a read-only key without expiry to pass without requirements, got %v", err)
+	}
+	err = info.CheckPermissions(0, PermissionOrder)
+	if !errors.As(err, &permErr) || !permErr.ReadOnly || len(permErr.Missing) != 1 {
+		t.Errorf("expected a read-only error, got %v", err)
+	}
+}
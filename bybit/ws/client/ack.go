@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AckTimeout bounds how long a subscribe, unsubscribe or auth request waits for its
+// acknowledgement when the caller's context has no deadline.
+const AckTimeout = 10 * time.Second
+
+// Ack is the server's response to a request sent with a req_id: a subscribe, unsubscribe or
+// auth acknowledgement, or the result of an order entry call on the trade channel.
+type Ack struct {
+	Success bool   `json:"success"`
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"ret_msg"`
+	ConnID  string `json:"conn_id"`
+	ReqID   string `json:"req_id"`
+	Op      string `json:"op"`
+	// Raw is the complete response, for callers that decode further fields.
+	Raw []byte `json:"-"`
+}
+
+// OpError is returned when the server rejects a subscribe or unsubscribe request.
+type OpError struct {
+	Op     string
+	Args   []string
+	RetMsg string
+}
+
+func (e *OpError) Error() string {
+	return fmt.Sprintf("%s %v rejected: %s", e.Op, e.Args, e.RetMsg)
+}
+
+// request is the wire format of an op that expects an acknowledgement.
+type request struct {
+	ReqID string `json:"req_id"`
+	Op    string `json:"op"`
+	Args  any    `json:"args"`
+}
+
+// pendingRequest is a request waiting for its acknowledgement.
+type pendingRequest struct {
+	op  string
+	ack chan Ack
+}
+
+// ackRegistry correlates acknowledgements with the requests waiting for them.
+type ackRegistry struct {
+	mu      sync.Mutex
+	seq     uint64
+	pending map[string]pendingRequest
+	order   []string
+}
+
+// register allocates a req_id for an op request and returns the channel its
+// acknowledgement is delivered on.
+func (r *ackRegistry) register(prefix, op string) (string, chan Ack) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == nil {
+		r.pending = make(map[string]pendingRequest)
+	}
+	r.seq++
+	reqID := prefix + "-" + strconv.FormatUint(r.seq, 10)
+	ack := make(chan Ack, 1)
+	r.pending[reqID] = pendingRequest{op: op, ack: ack}
+	r.order = append(r.order, reqID)
+	return reqID, ack
+}
+
+// cancel forgets a request.
+func (r *ackRegistry) cancel(reqID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.forget(reqID)
+}
+
+// resolve delivers ack to the request it answers and reports whether one was found. An
+// acknowledgement without req_id answers the oldest pending request for the same op.
+func (r *ackRegistry) resolve(ack Ack) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reqID := ack.ReqID
+	if reqID == "" {
+		for _, id := range r.order {
+			if r.pending[id].op == ack.Op {
+				reqID = id
+				break
+			}
+		}
+	}
+	pending, ok := r.pending[reqID]
+	if !ok {
+		return false
+	}
+	pending.ack <- ack
+	r.forget(reqID)
+	return true
+}
+
+// forget removes reqID. The caller must hold r.mu.
+func (r *ackRegistry) forget(reqID string) {
+	delete(r.pending, reqID)
+	for i, id := range r.order {
+		if id == reqID {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// request sends an op with a fresh req_id and waits for the matching acknowledgement, at
+// most AckTimeout unless ctx carries a deadline.
+func (c *Client) request(ctx context.Context, op string, args any) (Ack, error) {
+	return c.Call(ctx, op, func(reqID string) any {
+		return request{ReqID: reqID, Op: op, Args: args}
+	})
+}
+
+// Call sends the message built by payload for a fresh request id and waits for the response
+// carrying that id, at most AckTimeout unless ctx carries a deadline. It is the building
+// block of request/response APIs such as the order entry API on the trade channel.
+func (c *Client) Call(ctx context.Context, op string, payload func(reqID string) any) (ack Ack, err error) {
+	c.init()
+	ctx, end := c.startSpan(ctx, op)
+	defer func() {
+		if err == nil && !ack.Success {
+			// Report the rejection on the span; the caller still receives the Ack
+			end(fmt.Errorf("%s rejected with retCode %d: %s", op, ack.RetCode, ack.RetMsg))
+			return
+		}
+		end(err)
+	}()
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, AckTimeout)
+		defer cancel()
+	}
+
+	reqID, ackCh := c.acks.register(c.reqID, op)
+	defer c.acks.cancel(reqID)
+
+	msg, err := json.Marshal(payload(reqID))
+	if err != nil {
+		return Ack{}, err
+	}
+	if err := c.send(ctx, msg); err != nil {
+		return Ack{}, err
+	}
+
+	select {
+	case ack := <-ackCh:
+		return ack, nil
+	case <-ctx.Done():
+		return Ack{}, fmt.Errorf("waiting for %s response: %v", op, ctx.Err())
+	case <-c.done:
+		return Ack{}, ErrClientClosed
+	}
+}
+
+// handleAck passes a response to the request waiting for it and reports whether the
+// message was consumed. Responses on the trade channel carry retCode, retMsg, reqId and
+// connId instead of success, ret_msg, req_id and conn_id.
+func (c *Client) handleAck(env envelope, message []byte) bool {
+	ack := Ack{
+		RetMsg: env.RetMsg,
+		ConnID: env.ConnID,
+		ReqID:  env.ReqID,
+		Op:     env.Op,
+		Raw:    message,
+	}
+	switch {
+	case env.Success != nil:
+		ack.Success = *env.Success
+	case env.RetCode != nil:
+		ack.RetCode = *env.RetCode
+		ack.Success = ack.RetCode == 0
+		ack.RetMsg, ack.ReqID, ack.ConnID = env.TradeRetMsg, env.TradeReqID, env.TradeConnID
+	default:
+		return false
+	}
+	return c.acks.resolve(ack)
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AuthTimeout bounds how long Authenticate waits for the server's acknowledgement when the
+// caller's context has no deadline.
+const AuthTimeout = 10 * time.Second
+
+// ErrAuthFailed is returned when the server rejects an authentication request.
+type ErrAuthFailed struct {
+	RetMsg string
+}
+
+func (e *ErrAuthFailed) Error() string {
+	return fmt.Sprintf("websocket authentication failed: %s", e.RetMsg)
+}
+
+// Authenticate sends an authentication request to the WebSocket server and waits up to
+// AuthTimeout for the acknowledgement. A rejected request returns *ErrAuthFailed.
+func (c *Client) Authenticate(apiKey, expires, signature string) error {
+	return c.AuthenticateContext(context.Background(), apiKey, expires, signature)
+}
+
+// AuthenticateContext is like Authenticate but waits for the acknowledgement until ctx is
+// done instead of AuthTimeout when ctx has a deadline.
+func (c *Client) AuthenticateContext(ctx context.Context, apiKey, expires, signature string) error {
+	if c.Channel != Private && c.Channel != Trade {
+		return errors.New("cannot authenticate on a public channel")
+	}
+	c.init()
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, AuthTimeout)
+		defer cancel()
+	}
+
+	c.logger.Debug("Authenticating with apiKey %s, expires %s", apiKey, expires)
+	ack, err := c.request(ctx, AuthOperation, []any{apiKey, expires, signature})
+	if err != nil {
+		c.handleConnectionError(err)
+		return err
+	}
+	if !ack.Success {
+		return &ErrAuthFailed{RetMsg: ack.RetMsg}
+	}
+	c.authenticated.Store(true)
+	c.setState(StateAuthenticated)
+	return nil
+}
+
+// EnsureAuthenticated authenticates a private client with its own credentials unless it is
+// already authenticated. Private stream services call it before subscribing, so several of
+// them can share one connection.
+func (c *Client) EnsureAuthenticated(ctx context.Context) error {
+	if c.authenticated.Load() {
+		return nil
+	}
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if c.authenticated.Load() {
+		return nil
+	}
+	expires, signature, err := c.sign()
+	if err != nil {
+		return err
+	}
+	return c.AuthenticateContext(ctx, c.APIKey, expires, signature)
+}
+
+// Signer signs the authentication payload. The signers of the REST client, including its
+// RSA signer, satisfy it.
+type Signer interface {
+	Sign(payload string) (string, error)
+}
+
+// sign returns an expiry one second ahead and the matching signature of the client's
+// credentials, made by Signer when set and with APISecret otherwise.
+func (c *Client) sign() (expires, signature string, err error) {
+	expires = fmt.Sprintf("%d", time.Now().UnixMilli()+1000)
+	payload := fmt.Sprintf("GET/realtime%s", expires)
+	if c.Signer == nil {
+		return expires, GenerateWsSignature(c.APISecret, payload), nil
+	}
+	if signature, err = c.Signer.Sign(payload); err != nil {
+		return "", "", fmt.Errorf("failed to sign authentication request: %v", err)
+	}
+	return expires, signature, nil
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ReconnectPolicy controls how the client retries after the connection is lost.
+// The delay before attempt n (starting at zero) is InitialDelay * Multiplier^n, capped at
+// MaxDelay and randomised by ±Jitter (a fraction between 0 and 1) so that many clients do
+// not reconnect in lockstep.
+type ReconnectPolicy struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	Multiplier   float64
+	Jitter       float64
+	// MaxAttempts is the number of attempts before giving up. Zero means retry forever.
+	MaxAttempts int
+}
+
+// DefaultReconnectPolicy returns the policy used when none is configured: start at one
+// second, double up to one minute, ±20% jitter and never give up.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		InitialDelay: time.Second,
+		MaxDelay:     time.Minute,
+		Multiplier:   2,
+		Jitter:       0.2,
+	}
+}
+
+// Delay returns the wait before the given zero-based attempt.
+func (p ReconnectPolicy) Delay(attempt int) time.Duration {
+	delay := float64(p.InitialDelay)
+	if p.Multiplier > 1 {
+		delay *= math.Pow(p.Multiplier, float64(attempt))
+	}
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		delay *= 1 - jitter + 2*jitter*rand.Float64() //nolint:gosec // jitter does not need a secure source
+	}
+	return time.Duration(delay)
+}
+
+// allows reports whether another attempt is permitted after attempt attempts have failed.
+func (p ReconnectPolicy) allows(attempt int) bool {
+	return p.MaxAttempts <= 0 || attempt < p.MaxAttempts
+}
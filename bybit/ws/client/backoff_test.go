@@ -0,0 +1,31 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReconnectPolicy_Delay verifies exponential growth, the MaxDelay cap and jitter bounds.
+func TestReconnectPolicy_Delay(t *testing.T) {
+	p := ReconnectPolicy{InitialDelay: time.Second, MaxDelay: 10 * time.Second, Multiplier: 2}
+	assert.Equal(t, time.Second, p.Delay(0))
+	assert.Equal(t, 4*time.Second, p.Delay(2))
+	assert.Equal(t, 10*time.Second, p.Delay(10))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := p.Delay(1)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.LessOrEqual(t, d, 3*time.Second)
+	}
+}
+
+// TestReconnectPolicy_Allows verifies the attempt limit, including the infinite default.
+func TestReconnectPolicy_Allows(t *testing.T) {
+	assert.True(t, DefaultReconnectPolicy().allows(1000))
+	limited := ReconnectPolicy{MaxAttempts: 2}
+	assert.True(t, limited.allows(1))
+	assert.False(t, limited.allows(2))
+}
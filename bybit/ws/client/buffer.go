@@ -0,0 +1,104 @@
+package client
+
+import "sync"
+
+// OverflowPolicy decides what a Buffer does with a value pushed while it is full.
+type OverflowPolicy int
+
+const (
+	// DropNewest discards the pushed value. It is the zero value.
+	DropNewest OverflowPolicy = iota
+	// DropOldest discards the oldest queued value to make room.
+	DropOldest
+	// Block waits until the consumer makes room. A slow consumer stalls the read pump and
+	// with it every other stream of the connection.
+	Block
+	// CoalesceLatest keeps only the most recent value, so the consumer always reads the
+	// latest state. The buffer size is ignored.
+	CoalesceLatest
+)
+
+// BufferConfig configures a Buffer.
+type BufferConfig struct {
+	// Size is the channel capacity; values below 1 are treated as 1.
+	Size   int
+	Policy OverflowPolicy
+}
+
+// DefaultBufferConfig is used for the raw message channels of the stream services unless
+// WithMessageBuffer overrides it.
+var DefaultBufferConfig = BufferConfig{Size: 100, Policy: DropNewest}
+
+// Buffer is a channel fed by a stream handler that applies an OverflowPolicy when its
+// consumer falls behind.
+type Buffer[T any] struct {
+	ch     chan T
+	policy OverflowPolicy
+	done   chan struct{}
+	once   sync.Once
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewBuffer creates a Buffer configured by cfg.
+func NewBuffer[T any](cfg BufferConfig) *Buffer[T] {
+	size := max(cfg.Size, 1)
+	if cfg.Policy == CoalesceLatest {
+		size = 1
+	}
+	return &Buffer[T]{
+		ch:     make(chan T, size),
+		policy: cfg.Policy,
+		done:   make(chan struct{}),
+	}
+}
+
+// C returns the channel the consumer reads from. It is closed by Close.
+func (b *Buffer[T]) C() <-chan T {
+	return b.ch
+}
+
+// Push queues v according to the buffer's policy. It is a no-op after Close.
+func (b *Buffer[T]) Push(v T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+
+	switch b.policy {
+	case Block:
+		select {
+		case b.ch <- v:
+		case <-b.done:
+		}
+	case DropOldest, CoalesceLatest:
+		for {
+			select {
+			case b.ch <- v:
+				return
+			default:
+			}
+			select {
+			case <-b.ch:
+			default:
+			}
+		}
+	default:
+		select {
+		case b.ch <- v:
+		default:
+		}
+	}
+}
+
+// Close closes the channel, releasing a Push blocked under the Block policy.
+func (b *Buffer[T]) Close() {
+	b.once.Do(func() {
+		close(b.done)
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.closed = true
+		close(b.ch)
+	})
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func drain[T any](b *Buffer[T]) []T {
+	var values []T
+	for {
+		select {
+		case v, ok := <-b.C():
+			if !ok {
+				return values
+			}
+			values = append(values, v)
+		default:
+			return values
+		}
+	}
+}
+
+func TestBuffer_Policies(t *testing.T) {
+	push := func(cfg BufferConfig) []int {
+		b := NewBuffer[int](cfg)
+		for i := 1; i <= 4; i++ {
+			b.Push(i)
+		}
+		return drain(b)
+	}
+
+	assert.Equal(t, []int{1, 2}, push(BufferConfig{Size: 2, Policy: DropNewest}))
+	assert.Equal(t, []int{3, 4}, push(BufferConfig{Size: 2, Policy: DropOldest}))
+	assert.Equal(t, []int{4}, push(BufferConfig{Size: 8, Policy: CoalesceLatest}))
+}
+
+func TestBuffer_BlockReleasedByClose(t *testing.T) {
+	b := NewBuffer[int](BufferConfig{Size: 1, Policy: Block})
+	b.Push(1)
+
+	pushed := make(chan struct{})
+	go func() {
+		b.Push(2)
+		close(pushed)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("Push did not block on a full buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	b.Close()
+	select {
+	case <-pushed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not release the blocked Push")
+	}
+	assert.Equal(t, []int{1}, drain(b))
+	b.Push(3)
+}
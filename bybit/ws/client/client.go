@@ -1,30 +1,40 @@
 package client
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
-	"os"
+	"net/http"
+	"net/url"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 )
 
 const (
-	DefaultScheme       = "wss"
-	PingInterval        = 20 * time.Second
-	PingOperation       = "ping"
-	AuthOperation       = "auth"
+	DefaultScheme = "wss"
+	PingInterval  = 20 * time.Second
+	PingOperation = "ping"
+	AuthOperation = "auth"
+	Public        = "public"
+	Private       = "private"
+	// Trade is the channel of the order entry API (order.create, order.amend, order.cancel).
+	Trade = "trade"
+)
+
+// Deprecated: configure Client.ReconnectPolicy instead.
+const (
 	ReconnectionRetries = 3
 	ReconnectionDelay   = 10 * time.Second
-	Public              = "public"
-	Private             = "private"
 )
 
 var (
@@ -39,18 +49,25 @@ type PingMsg struct {
 	ReqID string `json:"req_id,omitempty"`
 }
 
-// ChannelType defines the types of channels (public/private) that the WebSocket client can connect to.
+// ChannelType defines the types of channels (public/private/trade) that the WebSocket client can connect to.
 type ChannelType string
 
 // Client is the main WebSocket client struct, managing the connection and its state.
 type Client struct {
-	closeOnce         sync.Once
-	connOnce          sync.Once
-	isClosed          bool
-	logger            *log.Logger
-	IsTestNet         bool
-	APIKey            string
-	APISecret         string
+	closeOnce sync.Once
+	loopsOnce sync.Once
+	isClosed  bool
+	logger    Logger
+	IsTestNet bool
+	// Environment selects demo trading when set to Demo; IsTestNet selects the testnet.
+	Environment Environment
+	// Domain is the regional site connected to, bybit.com when empty.
+	Domain    string
+	APIKey    string
+	APISecret string
+	// Signer, when set, signs the authentication request instead of APISecret, for example
+	// with an RSA private key.
+	Signer            Signer
 	Channel           ChannelType
 	Path              string
 	Connected         chan struct{}
@@ -58,71 +75,230 @@ type Client struct {
 	OnConnectionError func(err error)
 	Category          string
 	MaxActiveTime     string
-	wsURL             string // WebSocket URL for dependency injection in tests
-
-	Conn     *websocket.Conn
-	connLock sync.Mutex
+	// OnStateChange is called synchronously on every state transition, possibly while the
+	// client holds internal locks, so it must return quickly and must not call Connect,
+	// Send or Close.
+	OnStateChange func(from, to State)
+	// OnRawMessage, when set, is called with every frame read from or written to the
+	// connection, including pings. It runs on the reader or writer goroutine and must not
+	// modify message.
+	OnRawMessage func(direction Direction, message []byte)
+	// OnRTT, when set, is called with the round-trip time of every answered ping.
+	OnRTT func(rtt time.Duration)
+	// OnHandlerError, when set, is called on the read goroutine with a *HandlerPanicError
+	// whenever a topic handler panics. The panic is recovered and the stream keeps running.
+	OnHandlerError func(err error)
+	// OnStale, when set, is called with the topic and its silence whenever a topic watched
+	// with Watch stops delivering messages.
+	OnStale func(topic string, silence time.Duration)
+	// Metrics, when set, is notified of every topic message and reconnection attempt, see
+	// the metrics package.
+	Metrics MetricsObserver
+	// Tracer, when set, traces connecting, reconnecting, shutting down and acknowledged
+	// requests, see the tracing package.
+	Tracer Tracer
+	// ReconnectPolicy controls the backoff between reconnection attempts. The zero value
+	// selects DefaultReconnectPolicy.
+	ReconnectPolicy ReconnectPolicy
+	// PongTimeout is how long to wait for a pong after a ping before reconnecting. Zero
+	// selects DefaultPongTimeout.
+	PongTimeout time.Duration
+	// BrokerID, when set, is sent as the Referer header of order entry calls on the trade
+	// channel so that Bybit attributes their commission to the broker.
+	BrokerID     string
+	wsURL        string        // overrides the endpoint derived from the configuration
+	pingInterval time.Duration // overrides PingInterval
+	dialer       *websocket.Dialer
+	proxy        func(*http.Request) (*url.URL, error)
+	tlsConfig    *tls.Config
+	header       http.Header // extra handshake headers
+	reqID        string      // req_id sent with pings
+	maxArgs      int         // topics per subscribe request, see argsPerRequest
+	buffer       *BufferConfig
+
+	Conn      *websocket.Conn
+	connLock  sync.Mutex
+	connReady chan struct{}
+	initOnce  sync.Once
+	done      chan struct{}
+	loops     sync.WaitGroup // writer, read pump and keep-alive goroutines
+	// readerDone is closed when the read pump returns.
+	readerDone chan struct{}
+	outbound   chan outboundMessage
+	inbox      chan []byte
+
+	dispatcher dispatcher
+	watchdog   watchdog
+
+	started      atomic.Bool
+	reconnecting atomic.Bool
+	lastPing     atomic.Int64 // unix nanoseconds of the last ping written
+	lastPong     atomic.Int64 // unix nanoseconds of the last pong received
+	rtt          rttStats
+
+	state         atomic.Int32
+	acks          ackRegistry
+	authMu        sync.Mutex
+	authenticated atomic.Bool
+
+	subscriptions *SubscriptionManager
 }
 
 // NewPublicClient initializes a new public WSClient instance.
 func NewPublicClient(isTestNet bool, category string) (*Client, error) {
-	client := &Client{
-		logger:    log.New(os.Stdout, "[WebSocketClient] ", log.LstdFlags),
-		IsTestNet: isTestNet,
-		Channel:   Public,
-		Connected: make(chan struct{}),
-		Category:  category,
-	}
-	DefaultReqID = randomString(eightNumber)
-	return client, nil
+	return NewClient(WithTestnet(isTestNet), WithCategory(category))
 }
 
 // NewPrivateClient initializes a new private WSClient instance.
 func NewPrivateClient(apiKey, apiSecret string, isTestNet bool, maxActiveTime string, category string) (*Client, error) {
-	client := &Client{
-		logger:        log.New(os.Stdout, "[WebSocketClient] ", log.LstdFlags),
-		IsTestNet:     isTestNet,
-		APIKey:        apiKey,
-		APISecret:     apiSecret,
-		Channel:       Private,
-		Connected:     make(chan struct{}),
-		MaxActiveTime: maxActiveTime,
-		Category:      category,
-	}
-	DefaultReqID = randomString(eightNumber)
-	return client, nil
+	return NewClient(
+		WithCredentials(apiKey, apiSecret),
+		WithTestnet(isTestNet),
+		WithMaxActiveTime(maxActiveTime),
+		WithCategory(category),
+	)
+}
+
+// NewTradeClient initializes a client for the order entry API on the trade channel.
+func NewTradeClient(apiKey, apiSecret string, isTestNet bool) (*Client, error) {
+	return NewClient(
+		WithCredentials(apiKey, apiSecret),
+		WithChannel(Trade),
+		WithTestnet(isTestNet),
+	)
 }
 
 // Connect establishes a WebSocket connection to the server based on the configuration.
 func (c *Client) Connect() error {
-	var err error
-	c.connOnce.Do(func() {
-		c.connLock.Lock()
-		defer c.connLock.Unlock()
-
-		if c.isClosed {
-			err = errors.New("connection already closed")
-			c.handleConnectionError(err)
-			return
-		}
+	return c.ConnectContext(context.Background())
+}
 
-		url := c.buildURL()
-		c.Conn, _, err = websocket.DefaultDialer.Dial(url, nil)
-		if err != nil {
-			c.handleConnectionError(fmt.Errorf("failed to dial %s: %v", url, err))
-			c.Conn = nil
-			return
-		}
+// ConnectContext establishes a WebSocket connection to the server based on the configuration.
+// The dial is aborted when ctx is cancelled or its deadline expires. Calling it on a client
+// that is already connected is a no-op.
+func (c *Client) ConnectContext(ctx context.Context) (err error) {
+	c.init()
+	ctx, end := c.startSpan(ctx, "connect")
+	defer func() { end(err) }()
+
+	if err := c.dial(ctx); err != nil {
+		return err
+	}
+
+	c.loopsOnce.Do(c.startLoops)
+	return nil
+}
+
+// startLoops starts the writer, read pump and keep-alive goroutines.
+func (c *Client) startLoops() {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+	if c.isClosed {
+		return
+	}
+
+	c.started.Store(true)
+	c.loops.Add(3)
+	go func() {
+		defer c.loops.Done()
+		c.writer()
+	}()
+	go func() {
+		defer c.loops.Done()
+		defer close(c.readerDone)
+		c.readPump()
+	}()
+	go func() {
+		defer c.loops.Done()
+		c.keepAlive()
+	}()
+}
+
+// dial opens a new connection unless one is already established.
+func (c *Client) dial(ctx context.Context) error {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+
+	if c.isClosed {
+		err := errors.New("connection already closed")
+		c.handleConnectionError(err)
+		return err
+	}
+	if c.Conn != nil {
+		return nil
+	}
 
-		c.logger.Printf("Connected to %s", url)
-		if c.OnConnected != nil {
-			c.OnConnected()
+	reconnecting := c.reconnecting.Load()
+	if !reconnecting {
+		c.setState(StateConnecting)
+	}
+	url := c.buildURL()
+	conn, _, err := c.websocketDialer().DialContext(ctx, url, c.header)
+	if err != nil {
+		if !reconnecting {
+			c.setState(StateDisconnected)
 		}
-		closeOnce(c.Connected)
+		c.handleConnectionError(fmt.Errorf("failed to dial %s: %v", url, err))
+		return err
+	}
+	conn.SetPongHandler(func(string) error {
+		c.recordPong()
+		return nil
+	})
+	c.lastPong.Store(time.Now().UnixNano())
+	c.Conn = conn
+	close(c.connReady)
+	c.connReady = make(chan struct{})
+
+	c.logger.Info("Connected to %s", url)
+	c.setState(StateConnected)
+	if c.OnConnected != nil {
+		c.OnConnected()
+	}
+	closeOnce(c.Connected)
+	return nil
+}
 
-		go c.keepAlive()
+// websocketDialer returns the dialer configured with WithDialer, WithProxy and WithTLSConfig.
+func (c *Client) websocketDialer() *websocket.Dialer {
+	dialer := c.dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	if c.proxy == nil && c.tlsConfig == nil {
+		return dialer
+	}
+
+	configured := *dialer
+	if c.proxy != nil {
+		configured.Proxy = c.proxy
+	}
+	if c.tlsConfig != nil {
+		configured.TLSClientConfig = c.tlsConfig
+	}
+	return &configured
+}
+
+// init lazily allocates the internal channels and logger so that a zero-value Client,
+// such as one created with new(Client), is safe to use.
+func (c *Client) init() {
+	c.initOnce.Do(func() {
+		if c.logger == nil {
+			c.logger = defaultLogger()
+		}
+		if c.reqID == "" {
+			c.reqID = randomString(eightNumber)
+		}
+		if c.Connected == nil {
+			c.Connected = make(chan struct{})
+		}
+		c.done = make(chan struct{})
+		c.outbound = make(chan outboundMessage, OutboundQueueSize)
+		c.inbox = make(chan []byte, InboxSize)
+		c.connReady = make(chan struct{})
+		c.readerDone = make(chan struct{})
+		c.subscriptions = newSubscriptionManager(c)
 	})
-	return err
 }
 
 // buildURL constructs the WebSocket URL based on client configuration.
@@ -131,12 +307,7 @@ func (c *Client) buildURL() string {
 		return c.wsURL
 	}
 
-	var baseURL string
-	if c.IsTestNet {
-		baseURL = "stream-testnet.bybit.com"
-	} else {
-		baseURL = "stream.bybit.com"
-	}
+	baseURL := c.host()
 
 	switch c.Channel {
 	case Public:
@@ -153,19 +324,57 @@ func (c *Client) buildURL() string {
 			return fmt.Sprintf("%s://%s/v5/public/linear", DefaultScheme, baseURL) // default to linear (USDT/USDC)
 		}
 	case Private:
+		if c.MaxActiveTime != "" {
+			return fmt.Sprintf("%s://%s/v5/private?max_active_time=%s", DefaultScheme, baseURL, c.MaxActiveTime)
+		}
 		return fmt.Sprintf("%s://%s/v5/private", DefaultScheme, baseURL)
+	case Trade:
+		return fmt.Sprintf("%s://%s/v5/trade", DefaultScheme, baseURL)
 	default:
 		return fmt.Sprintf("%s://%s/v5/public/linear", DefaultScheme, baseURL) // default URL
 	}
 }
 
-// authenticateIfRequired authenticates the WebSocket client if the channel is private.
+// argsPerRequest returns the maximum number of topics per subscribe or unsubscribe request,
+// or zero when there is no limit.
+func (c *Client) argsPerRequest() int {
+	if c.maxArgs > 0 {
+		return c.maxArgs
+	}
+	if c.Channel == Public && c.Category == "spot" {
+		return SpotMaxArgsPerRequest
+	}
+	return 0
+}
+
+// validateMaxActiveTime checks that maxActiveTime is empty or a whole number of seconds
+// ("30s") or minutes ("1m") between 30 seconds and 10 minutes, as Bybit requires.
+func validateMaxActiveTime(maxActiveTime string) error {
+	if maxActiveTime == "" {
+		return nil
+	}
+	unit := maxActiveTime[len(maxActiveTime)-1]
+	value, err := strconv.Atoi(maxActiveTime[:len(maxActiveTime)-1])
+	if err != nil || (unit != 's' && unit != 'm') {
+		return fmt.Errorf("invalid max active time %q: expected a value such as 30s or 5m", maxActiveTime)
+	}
+	d := time.Duration(value) * time.Second
+	if unit == 'm' {
+		d = time.Duration(value) * time.Minute
+	}
+	if d < 30*time.Second || d > 10*time.Minute {
+		return fmt.Errorf("invalid max active time %q: must be between 30s and 10m", maxActiveTime)
+	}
+	return nil
+}
+
+// authenticateIfRequired authenticates the WebSocket client if the channel is private or trade.
 func (c *Client) authenticateIfRequired() error {
-	if c.Channel == Private {
-		expires := fmt.Sprintf("%d", time.Now().UnixMilli()+1000)
-		signatureData := fmt.Sprintf("GET/realtime%s", expires)
-		signed := GenerateWsSignature(c.APISecret, signatureData)
-		c.logger.Printf("Authenticating with apiKey %s, expires %s, signed %s", c.APIKey, expires, signed)
+	if c.Channel == Private || c.Channel == Trade {
+		expires, signed, err := c.sign()
+		if err != nil {
+			return err
+		}
 		return c.Authenticate(c.APIKey, expires, signed)
 	}
 	return nil
@@ -181,82 +390,34 @@ func GenerateWsSignature(apiSecret, data string) string {
 	return hex.EncodeToString(h.Sum(nil))
 }
 
-// keepAlive sends a ping message to the WebSocket server every PingInterval and handles reconnection if the ping fails.
-func (c *Client) keepAlive() {
-	ticker := time.NewTicker(PingInterval)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		c.sendPingAndHandleReconnection()
-	}
-}
-
 // sendPingAndHandleReconnection sends a ping message to the WebSocket server and handles reconnection if the ping fails.
-func (c *Client) sendPingAndHandleReconnection() {
+// It reports whether the ping was written.
+func (c *Client) sendPingAndHandleReconnection() bool {
 	c.connLock.Lock()
-	defer c.connLock.Unlock()
-
-	if c.isClosed || c.Conn == nil {
-		return
+	inactive := c.isClosed || c.Conn == nil
+	c.connLock.Unlock()
+	if inactive {
+		return false
 	}
 
 	pingMsg := PingMsg{
-		ReqID: DefaultReqID,
+		ReqID: c.reqID,
 		Op:    PingOperation,
 	}
 	jsonData, err := json.Marshal(pingMsg)
 	if err != nil {
-		c.logger.Printf("Error marshaling ping message: %v", err)
-		return
+		c.logger.Error("Error marshaling ping message: %v", err)
+		return false
 	}
 
-	if err = c.Conn.WriteMessage(websocket.TextMessage, jsonData); err != nil {
-		c.logger.Printf("Error sending ping: %v", err)
+	c.lastPing.Store(time.Now().UnixNano())
+	if err = c.enqueue(context.Background(), jsonData); err != nil {
+		c.logger.Warn("Error sending ping: %v", err)
 		go c.handleReconnection()
-		return
+		return false
 	}
-	c.logger.Println("Ping sent")
-}
-
-// Authenticate sends an authentication request to the WebSocket server.
-func (c *Client) Authenticate(apiKey, expires, signature string) error {
-	c.connLock.Lock()
-	defer c.connLock.Unlock()
-
-	if c.Channel != Private {
-		return errors.New("cannot authenticate on a public channel")
-	}
-	c.logger.Printf("Authenticating with apiKey %s, expires %s, signed %s", apiKey, expires, signature)
-	authRequest := map[string]any{
-		"op":   AuthOperation,
-		"args": []any{apiKey, expires, signature},
-	}
-	jsonData, err := json.Marshal(authRequest)
-	if err != nil {
-		return err
-	}
-	if err := c.Conn.WriteMessage(websocket.TextMessage, jsonData); err != nil {
-		c.handleConnectionError(err)
-		return err
-	}
-	return nil
-}
-
-// Close gracefully closes the WebSocket connection.
-func (c *Client) Close() {
-	c.closeOnce.Do(func() {
-		c.connLock.Lock()
-		defer c.connLock.Unlock()
-
-		c.isClosed = true
-		c.logger.Println("Connection closed")
-		if c.Conn != nil {
-			if err := c.Conn.Close(); err != nil && c.OnConnectionError != nil {
-				c.OnConnectionError(err)
-			}
-			c.Conn = nil
-		}
-	})
+	c.logger.Debug("Ping sent")
+	return true
 }
 
 // randomString generates a random string of specified length.
@@ -268,27 +429,46 @@ func randomString(n int) string {
 
 // Send sends a message to the WebSocket server.
 func (c *Client) Send(message []byte) error {
+	return c.SendContext(context.Background(), message)
+}
+
+// SendContext sends a message to the WebSocket server. The message is queued for the
+// writer goroutine; if ctx carries a deadline it is applied to the write, and cancelling
+// ctx abandons the send. Subscribe and unsubscribe requests are recorded by the client's
+// SubscriptionManager.
+func (c *Client) SendContext(ctx context.Context, message []byte) error {
+	if err := c.send(ctx, message); err != nil {
+		return err
+	}
+	c.subscriptions.track(message)
+	return nil
+}
+
+// send connects if necessary and queues message for the writer goroutine.
+func (c *Client) send(ctx context.Context, message []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.init()
+
 	c.connLock.Lock()
-	defer c.connLock.Unlock()
+	closed, conn := c.isClosed, c.Conn
+	c.connLock.Unlock()
 
-	if c.isClosed {
+	if closed {
 		return errors.New("attempt to send message on closed connection")
 	}
 
-	if c.Conn == nil {
-		log.Println("Connection is nil, attempting to reconnect...")
-		if err := c.Connect(); err != nil {
-			log.Printf("Reconnection failed: %v", err)
+	if conn == nil {
+		c.logger.Info("Connection is nil, attempting to reconnect...")
+		if err := c.ConnectContext(ctx); err != nil {
+			c.logger.Error("Reconnection failed: %v", err)
 			return err
 		}
 	}
 
-	if c.Conn == nil {
-		return errors.New("connection is still nil after attempting to reconnect")
-	}
-
-	if err := c.Conn.WriteMessage(websocket.TextMessage, message); err != nil {
-		log.Printf("Error sending message: %v", err)
+	if err := c.enqueue(ctx, message); err != nil {
+		c.logger.Error("Error sending message: %v", err)
 		return err
 	}
 
@@ -297,53 +477,101 @@ func (c *Client) Send(message []byte) error {
 
 // Receive listens for a message from the WebSocket server and returns it.
 func (c *Client) Receive() ([]byte, error) {
-	c.connLock.Lock()
-	defer c.connLock.Unlock()
+	return c.ReceiveContext(context.Background())
+}
+
+// ReceiveContext returns the next message that was not routed to a topic handler registered
+// with Handle, such as pong and subscription responses. It blocks until a message arrives,
+// ctx is done or the client is closed.
+func (c *Client) ReceiveContext(ctx context.Context) ([]byte, error) {
+	c.init()
 
-	if c.Conn == nil {
+	if !c.started.Load() {
 		return nil, errors.New("attempt to receive message on nil connection")
 	}
 
-	_, message, err := c.Conn.ReadMessage()
-	if err != nil {
-		log.Printf("Error receiving message: %v", err)
-		go c.handleReconnection()
-		return nil, err
+	select {
+	case message := <-c.inbox:
+		return message, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.done:
+		return nil, ErrClientClosed
 	}
-
-	return message, nil
 }
 
-// handleReconnection attempts to reconnect to the WebSocket server.
+// handleReconnection drops the current connection and redials according to the client's
+// ReconnectPolicy. Only one reconnection loop runs at a time.
 func (c *Client) handleReconnection() {
-	c.connLock.Lock()
-	defer c.connLock.Unlock()
+	if !c.reconnecting.CompareAndSwap(false, true) {
+		return
+	}
+	defer c.reconnecting.Store(false)
 
+	c.connLock.Lock()
 	if c.isClosed {
+		c.connLock.Unlock()
 		return // No need to reconnect if the client is intentionally closed
 	}
-
-	c.logger.Println("Attempting to reconnect...")
+	c.logger.Info("Attempting to reconnect...")
+	c.setState(StateReconnecting)
 	if c.Conn != nil {
 		_ = c.Conn.Close()
 		c.Conn = nil
 	}
+	c.connLock.Unlock()
 
-	for i := 0; i < ReconnectionRetries; i++ {
-		time.Sleep(ReconnectionDelay)
-		if err := c.Connect(); err == nil {
-			c.logger.Printf("Reconnection attempt %d successful", i+1)
+	policy := c.reconnectPolicy()
+	for attempt := 0; policy.allows(attempt); attempt++ {
+		select {
+		case <-c.done:
+			return
+		case <-time.After(policy.Delay(attempt)):
+		}
+		ctx, end := c.startSpan(context.Background(), "reconnect", "attempt", strconv.Itoa(attempt+1))
+		err := c.dial(ctx)
+		end(err)
+		if c.Metrics != nil {
+			c.Metrics.ObserveReconnect(err)
+		}
+		if err == nil {
+			c.logger.Info("Reconnection attempt %d successful", attempt+1)
+			go c.restore()
 			return
 		}
-		c.logger.Printf("Reconnection attempt %d failed", i+1)
+		c.logger.Warn("Reconnection attempt %d failed", attempt+1)
+	}
+	c.setState(StateDisconnected)
+	c.handleConnectionError(fmt.Errorf("giving up after %d reconnection attempts", policy.MaxAttempts))
+}
+
+// restore re-authenticates a previously authenticated connection and replays its
+// subscriptions. It runs on its own goroutine because the auth acknowledgement is
+// delivered by the read pump, which may be the caller of handleReconnection.
+func (c *Client) restore() {
+	if c.authenticated.Load() {
+		if err := c.authenticateIfRequired(); err != nil {
+			c.logger.Error("Error re-authenticating after reconnect: %v", err)
+		}
+	}
+	if err := c.subscriptions.replay(context.Background()); err != nil {
+		c.logger.Error("Error resubscribing after reconnect: %v", err)
+	}
+}
+
+// reconnectPolicy returns the configured policy, falling back to DefaultReconnectPolicy.
+func (c *Client) reconnectPolicy() ReconnectPolicy {
+	if c.ReconnectPolicy == (ReconnectPolicy{}) {
+		return DefaultReconnectPolicy()
 	}
+	return c.ReconnectPolicy
 }
 
 func (c *Client) handleConnectionError(err error) {
 	if c.OnConnectionError != nil {
 		c.OnConnectionError(err)
 	}
-	c.logger.Printf("Connection error: %v", err)
+	c.logger.Error("Connection error: %v", err)
 }
 
 // closeOnce ensures the channel is only closed once
@@ -2,8 +2,21 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -107,7 +120,7 @@ func TestClient_Receive(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Wait to receive a successful authentication message
-	_, authMsg, err := client.Conn.ReadMessage()
+	authMsg, err := client.Receive()
 	assert.NoError(t, err)
 	var authResponse map[string]any
 	err = json.Unmarshal(authMsg, &authResponse)
@@ -150,3 +163,940 @@ func TestClient_Close(t *testing.T) {
 	client.Close()
 	assert.True(t, client.isClosed)
 }
+
+// newEchoServer starts a local WebSocket server that echoes every message it receives.
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			mt, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if ack := ackFor(msg, true, ""); ack != nil {
+				if err := conn.WriteMessage(websocket.TextMessage, ack); err != nil {
+					return
+				}
+			}
+			if err := conn.WriteMessage(mt, msg); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// ackFor returns the acknowledgement a Bybit server sends for a request carrying a req_id,
+// or nil for any other message.
+func ackFor(msg []byte, success bool, retMsg string) []byte {
+	var req request
+	if err := json.Unmarshal(msg, &req); err != nil || req.ReqID == "" {
+		return nil
+	}
+	ack, _ := json.Marshal(Ack{Success: success, RetMsg: retMsg, ConnID: "test", ReqID: req.ReqID, Op: req.Op})
+	return ack
+}
+
+// wsURLFor converts an httptest server URL into a WebSocket URL.
+func wsURLFor(srv *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// TestClient_ContextVariants verifies that SendContext and ReceiveContext work against a
+// local server and that ReceiveContext honours context cancellation.
+func TestClient_ContextVariants(t *testing.T) {
+	srv := newEchoServer(t)
+	client, err := NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, client.ConnectContext(ctx))
+
+	assert.NoError(t, client.SendContext(ctx, []byte(`{"op":"echo"}`)))
+	msg, err := client.ReceiveContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"op":"echo"}`, string(msg))
+
+	short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	_, err = client.ReceiveContext(short)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	assert.ErrorIs(t, client.SendContext(cancelled, []byte("x")), context.Canceled)
+}
+
+// TestClient_ConcurrentSend verifies that concurrent senders are serialised through the
+// writer goroutine. Run with -race to detect concurrent writes on the connection.
+func TestClient_ConcurrentSend(t *testing.T) {
+	srv := newEchoServer(t)
+	client, err := NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+	defer client.Close()
+	assert.NoError(t, client.Connect())
+
+	const senders = 20
+	var wg sync.WaitGroup
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.Send([]byte(`{"op":"echo"}`)))
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < senders; i++ {
+		msg, err := client.Receive()
+		assert.NoError(t, err)
+		assert.Equal(t, `{"op":"echo"}`, string(msg))
+	}
+}
+
+// TestClient_ResubscribeAfterReconnect verifies that topics subscribed on a dropped
+// connection are replayed once the client reconnects.
+func TestClient_ResubscribeAfterReconnect(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	received := make(chan string, 10)
+	var connections int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		first := atomic.AddInt32(&connections, 1) == 1
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- string(msg)
+			if first {
+				return // drop the first connection after the initial subscription
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+	client.ReconnectPolicy = ReconnectPolicy{InitialDelay: 10 * time.Millisecond, MaxAttempts: 5}
+	defer client.Close()
+	assert.NoError(t, client.Connect())
+
+	assert.NoError(t, client.Send([]byte(`{"op":"subscribe","args":["tickers.BTCUSDT"]}`)))
+	assert.Equal(t, `{"op":"subscribe","args":["tickers.BTCUSDT"]}`, <-received)
+
+	select {
+	case msg := <-received:
+		assert.JSONEq(t, `{"op":"subscribe","args":["tickers.BTCUSDT"]}`, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("topics were not replayed after reconnect")
+	}
+}
+
+// TestSubscriptionManager verifies deduplication, reference counting and batching.
+func TestSubscriptionManager(t *testing.T) {
+	srv := newEchoServer(t)
+	client, err := NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+	defer client.Close()
+	assert.NoError(t, client.Connect())
+
+	ctx := context.Background()
+	subs := client.Subscriptions()
+
+	assert.NoError(t, subs.Subscribe(ctx, "tickers.BTCUSDT", "tickers.ETHUSDT", "tickers.BTCUSDT"))
+	assert.Equal(t, opMessage{Op: SubscribeOperation, Args: []string{"tickers.BTCUSDT", "tickers.ETHUSDT"}}, receiveOp(t, client))
+
+	// Already active topics are not sent again.
+	assert.NoError(t, subs.Subscribe(ctx, "tickers.BTCUSDT", "tickers.SOLUSDT"))
+	assert.Equal(t, opMessage{Op: SubscribeOperation, Args: []string{"tickers.SOLUSDT"}}, receiveOp(t, client))
+	assert.Equal(t, []string{"tickers.BTCUSDT", "tickers.ETHUSDT", "tickers.SOLUSDT"}, subs.ListSubscriptions())
+
+	// BTCUSDT still has a second subscriber, so only ETHUSDT is unsubscribed.
+	assert.NoError(t, subs.Unsubscribe(ctx, "tickers.BTCUSDT", "tickers.ETHUSDT"))
+	assert.Equal(t, opMessage{Op: UnsubscribeOperation, Args: []string{"tickers.ETHUSDT"}}, receiveOp(t, client))
+	assert.Equal(t, []string{"tickers.BTCUSDT", "tickers.SOLUSDT"}, subs.ListSubscriptions())
+}
+
+// receiveOp reads the next echoed op request from client, without its req_id.
+func receiveOp(t *testing.T, client *Client) opMessage {
+	t.Helper()
+	msg, err := client.Receive()
+	assert.NoError(t, err)
+	var op opMessage
+	assert.NoError(t, json.Unmarshal(msg, &op))
+	op.ReqID = ""
+	return op
+}
+
+// TestSubscriptionManager_Ack verifies that a rejected subscribe request is returned as
+// *OpError and leaves the topic inactive, and that a missing acknowledgement times out.
+func TestSubscriptionManager_Ack(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req opMessage
+			_ = json.Unmarshal(msg, &req)
+			var ack []byte
+			switch {
+			case slices.Contains(req.Args, "tickers.SILENT"):
+				continue
+			case slices.Contains(req.Args, "tickers.INVALID"):
+				ack = ackFor(msg, false, "error:handler not found,topic:tickers.INVALID")
+			default:
+				ack = ackFor(msg, true, "")
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, ack); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithURL(wsURLFor(srv)))
+	assert.NoError(t, err)
+	defer client.Close()
+	subs := client.Subscriptions()
+
+	assert.NoError(t, subs.Subscribe(context.Background(), "tickers.BTCUSDT"))
+
+	err = subs.Subscribe(context.Background(), "tickers.INVALID")
+	var opErr *OpError
+	if assert.ErrorAs(t, err, &opErr) {
+		assert.Equal(t, SubscribeOperation, opErr.Op)
+		assert.Equal(t, []string{"tickers.INVALID"}, opErr.Args)
+		assert.Contains(t, opErr.RetMsg, "handler not found")
+	}
+	assert.Equal(t, []string{"tickers.BTCUSDT"}, subs.ListSubscriptions())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorContains(t, subs.Subscribe(ctx, "tickers.SILENT"), "waiting for subscribe response")
+	assert.Equal(t, []string{"tickers.BTCUSDT"}, subs.ListSubscriptions())
+}
+
+// TestClient_Handle verifies that messages are routed by topic and that unrouted messages
+// remain available through Receive.
+func TestClient_Handle(t *testing.T) {
+	srv := newEchoServer(t)
+	client, err := NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+	defer client.Close()
+	assert.NoError(t, client.Connect())
+
+	routed := make(chan string, 1)
+	remove := client.Handle("tickers.BTCUSDT", func(message []byte) {
+		routed <- string(message)
+	})
+
+	assert.NoError(t, client.Send([]byte(`{"topic":"tickers.BTCUSDT","data":{}}`)))
+	select {
+	case msg := <-routed:
+		assert.Equal(t, `{"topic":"tickers.BTCUSDT","data":{}}`, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message was not routed to the topic handler")
+	}
+
+	remove()
+	assert.NoError(t, client.Send([]byte(`{"topic":"tickers.BTCUSDT","data":{}}`)))
+	msg, err := client.Receive()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"topic":"tickers.BTCUSDT","data":{}}`, string(msg))
+}
+
+// TestClient_HandlerPanic verifies that a panicking handler is reported and does not stop
+// the stream.
+func TestClient_HandlerPanic(t *testing.T) {
+	srv := newEchoServer(t)
+	errs := make(chan error, 1)
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithOnHandlerError(func(err error) { errs <- err }))
+	assert.NoError(t, err)
+	defer client.Close()
+	assert.NoError(t, client.Connect())
+
+	routed := make(chan struct{}, 2)
+	client.Handle("tickers.BTCUSDT", func(message []byte) {
+		routed <- struct{}{}
+		panic("boom")
+	})
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, client.Send([]byte(`{"topic":"tickers.BTCUSDT","data":{}}`)))
+		select {
+		case <-routed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("message was not routed to the topic handler")
+		}
+		select {
+		case err := <-errs:
+			var panicErr *HandlerPanicError
+			if assert.ErrorAs(t, err, &panicErr) {
+				assert.Equal(t, "tickers.BTCUSDT", panicErr.Topic)
+				assert.Equal(t, "boom", panicErr.Value)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler panic was not reported")
+		}
+	}
+}
+
+// TestClient_PongTimeout verifies that the client reconnects when the server stops answering
+// pings.
+func TestClient_PongTimeout(t *testing.T) {
+	var connections atomic.Int32
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connections.Add(1)
+		for {
+			// Swallow every ping without replying.
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+	client.pingInterval = 50 * time.Millisecond
+	client.PongTimeout = 50 * time.Millisecond
+	client.ReconnectPolicy = ReconnectPolicy{InitialDelay: 10 * time.Millisecond}
+	defer client.Close()
+
+	assert.NoError(t, client.Connect())
+	assert.Eventually(t, func() bool { return connections.Load() >= 2 }, 5*time.Second, 20*time.Millisecond)
+}
+
+// TestClient_ServerPing verifies that pongs keep the connection alive and that pings sent by
+// the server are answered.
+func TestClient_ServerPing(t *testing.T) {
+	var connections atomic.Int32
+	replies := make(chan []byte, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connections.Add(1)
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"op":"ping","req_id":"srv"}`)); err != nil {
+			return
+		}
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req PingMsg
+			_ = json.Unmarshal(msg, &req)
+			switch req.Op {
+			case PingOperation:
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"success":true,"ret_msg":"pong","op":"ping"}`))
+			case PongOperation:
+				replies <- msg
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+	client.pingInterval = 20 * time.Millisecond
+	client.PongTimeout = 50 * time.Millisecond
+	defer client.Close()
+
+	assert.NoError(t, client.Connect())
+
+	select {
+	case msg := <-replies:
+		assert.JSONEq(t, `{"op":"pong","req_id":"srv"}`, string(msg))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the pong reply")
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, int32(1), connections.Load())
+
+	stats := client.Stats()
+	assert.Positive(t, stats.Samples)
+	assert.Positive(t, stats.LastRTT)
+	assert.Positive(t, stats.AvgRTT)
+}
+
+// TestClient_AuthenticateAck verifies that Authenticate waits for the server's acknowledgement
+// and reports a rejection as *ErrAuthFailed.
+func TestClient_AuthenticateAck(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				Op   string   `json:"op"`
+				Args []string `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			if req.Op != AuthOperation {
+				continue
+			}
+			ack := `{"success":true,"ret_msg":"","op":"auth","conn_id":"1"}`
+			if req.Args[0] != "good-key" {
+				ack = `{"success":false,"ret_msg":"error:USVC1111","op":"auth","conn_id":"1"}`
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewPrivateClient("good-key", "secret", true, maxActiveTime, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+	defer client.Close()
+
+	assert.NoError(t, client.Authenticate("good-key", "1", "sig"))
+
+	err = client.Authenticate("bad-key", "1", "sig")
+	var authErr *ErrAuthFailed
+	if assert.ErrorAs(t, err, &authErr) {
+		assert.Equal(t, "error:USVC1111", authErr.RetMsg)
+	}
+}
+
+// TestClient_StateChanges verifies the lifecycle states reported through OnStateChange.
+func TestClient_StateChanges(t *testing.T) {
+	srv := newEchoServer(t)
+	client, err := NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	client.wsURL = wsURLFor(srv)
+
+	var mu sync.Mutex
+	var states []State
+	client.OnStateChange = func(from, to State) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, to)
+	}
+
+	assert.Equal(t, StateDisconnected, client.State())
+	assert.NoError(t, client.Connect())
+	assert.Equal(t, StateConnected, client.State())
+
+	client.Close()
+	assert.Equal(t, StateClosed, client.State())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []State{StateConnecting, StateConnected, StateClosed}, states)
+	assert.Equal(t, "closed", StateClosed.String())
+}
+
+// TestNewClient_Options verifies that NewClient applies its options and rejects invalid ones.
+func TestNewClient_Options(t *testing.T) {
+	srv := newEchoServer(t)
+	policy := ReconnectPolicy{InitialDelay: time.Millisecond, MaxAttempts: 2}
+	dialer := &websocket.Dialer{HandshakeTimeout: time.Second}
+
+	client, err := NewClient(
+		WithCredentials("key", "secret"),
+		WithTestnet(true),
+		WithCategory("spot"),
+		WithMaxActiveTime(maxActiveTime),
+		WithPingInterval(time.Second),
+		WithPongTimeout(2*time.Second),
+		WithReconnectPolicy(policy),
+		WithDialer(dialer),
+		WithURL(wsURLFor(srv)),
+	)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	assert.Equal(t, ChannelType(Private), client.Channel)
+	assert.Equal(t, "key", client.APIKey)
+	assert.Equal(t, "secret", client.APISecret)
+	assert.True(t, client.IsTestNet)
+	assert.Equal(t, "spot", client.Category)
+	assert.Equal(t, maxActiveTime, client.MaxActiveTime)
+	assert.Equal(t, time.Second, client.heartbeatInterval())
+	assert.Equal(t, 2*time.Second, client.pongTimeout())
+	assert.Equal(t, policy, client.ReconnectPolicy)
+	assert.Same(t, dialer, client.dialer)
+	assert.NoError(t, client.Connect())
+
+	_, err = NewClient(WithPingInterval(-time.Second))
+	assert.Error(t, err)
+}
+
+// TestClient_Proxy verifies that WithProxy tunnels the connection through an HTTP proxy.
+func TestClient_Proxy(t *testing.T) {
+	srv := newEchoServer(t)
+
+	var tunnels atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			http.Error(w, "CONNECT only", http.StatusMethodNotAllowed)
+			return
+		}
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer upstream.Close()
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			return
+		}
+		downstream, _, err := hijacker.Hijack()
+		if err != nil {
+			return
+		}
+		defer downstream.Close()
+		tunnels.Add(1)
+		_, _ = downstream.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		go func() { _, _ = io.Copy(upstream, downstream) }()
+		_, _ = io.Copy(downstream, upstream)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	assert.NoError(t, err)
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithProxy(proxyURL))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	assert.NoError(t, client.Connect())
+	assert.NoError(t, client.Send([]byte(`{"op":"echo"}`)))
+	msg, err := client.Receive()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"op":"echo"}`, string(msg))
+	assert.Equal(t, int32(1), tunnels.Load())
+}
+
+// TestClient_TLSConfigAndHeaders verifies that WithTLSConfig and WithHeader are applied to
+// the handshake.
+func TestClient_TLSConfigAndHeaders(t *testing.T) {
+	userAgents := make(chan string, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents <- r.Header.Get("User-Agent")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	tlsConfig := srv.Client().Transport.(*http.Transport).TLSClientConfig
+	client, err := NewClient(
+		WithURL(wsURLFor(srv)),
+		WithTLSConfig(tlsConfig),
+		WithHeader("User-Agent", "go-bybit-test"),
+	)
+	assert.NoError(t, err)
+	defer client.Close()
+
+	assert.NoError(t, client.Connect())
+	assert.Equal(t, "go-bybit-test", <-userAgents)
+
+	// Without the server's CA the handshake fails.
+	untrusted, err := NewClient(WithURL(wsURLFor(srv)))
+	assert.NoError(t, err)
+	defer untrusted.Close()
+	assert.Error(t, untrusted.Connect())
+}
+
+// recordingLogger collects log lines for assertions.
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (r *recordingLogger) record(level, format string, v ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines = append(r.lines, level+" "+fmt.Sprintf(format, v...))
+}
+
+func (r *recordingLogger) Debug(format string, v ...any) { r.record("DEBUG", format, v...) }
+func (r *recordingLogger) Info(format string, v ...any)  { r.record("INFO", format, v...) }
+func (r *recordingLogger) Warn(format string, v ...any)  { r.record("WARN", format, v...) }
+func (r *recordingLogger) Error(format string, v ...any) { r.record("ERROR", format, v...) }
+
+// TestClient_Logger verifies that client output is routed through the configured Logger and
+// that the slog adapter honours the handler's level.
+func TestClient_Logger(t *testing.T) {
+	srv := newEchoServer(t)
+	rec := &recordingLogger{}
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithLogger(rec))
+	assert.NoError(t, err)
+	assert.NoError(t, client.Connect())
+	client.Close()
+
+	rec.mu.Lock()
+	assert.Contains(t, rec.lines, "INFO Connected to "+wsURLFor(srv))
+	assert.Contains(t, rec.lines, "INFO Connection closed")
+	rec.mu.Unlock()
+
+	var buf strings.Builder
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	logger.Debug("hidden %d", 1)
+	logger.Warn("shown %d", 2)
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "level=WARN msg=\"shown 2\"")
+}
+
+// TestClient_OnRawMessage verifies that the raw message hook sees both directions.
+func TestClient_OnRawMessage(t *testing.T) {
+	srv := newEchoServer(t)
+
+	var mu sync.Mutex
+	seen := make(map[Direction][]string)
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithOnRawMessage(func(direction Direction, message []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[direction] = append(seen[direction], string(message))
+	}))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	assert.NoError(t, client.Send([]byte(`{"op":"echo"}`)))
+	_, err = client.Receive()
+	assert.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{`{"op":"echo"}`}, seen[Outbound])
+	assert.Equal(t, []string{`{"op":"echo"}`}, seen[Inbound])
+	assert.Equal(t, "outbound", Outbound.String())
+}
+
+// TestClient_Shutdown verifies that Shutdown performs the close handshake, stops the
+// background goroutines and releases pending Receive calls.
+func TestClient_Shutdown(t *testing.T) {
+	closeCodes := make(chan int, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				var closeErr *websocket.CloseError
+				if errors.As(err, &closeErr) {
+					closeCodes <- closeErr.Code
+				}
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithURL(wsURLFor(srv)))
+	assert.NoError(t, err)
+	assert.NoError(t, client.Connect())
+
+	receiveErr := make(chan error, 1)
+	go func() {
+		_, err := client.Receive()
+		receiveErr <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, client.Shutdown(ctx))
+
+	assert.Equal(t, websocket.CloseNormalClosure, <-closeCodes)
+	assert.ErrorIs(t, <-receiveErr, ErrClientClosed)
+	assert.Nil(t, client.Conn)
+	assert.NoError(t, client.Shutdown(ctx))
+}
+
+// TestClient_MaxActiveTime verifies that max_active_time is validated and added to the
+// private URL.
+func TestClient_MaxActiveTime(t *testing.T) {
+	client, err := NewPrivateClient("key", "secret", true, "5m", "linear")
+	assert.NoError(t, err)
+	assert.Equal(t, "wss://stream-testnet.bybit.com/v5/private?max_active_time=5m", client.buildURL())
+
+	client, err = NewPrivateClient("key", "secret", false, "", "linear")
+	assert.NoError(t, err)
+	assert.Equal(t, "wss://stream.bybit.com/v5/private", client.buildURL())
+
+	for _, valid := range []string{"30s", "600s", "1m", "10m"} {
+		assert.NoError(t, validateMaxActiveTime(valid), valid)
+	}
+	for _, invalid := range []string{"29s", "601s", "11m", "0m", "5h", "m", "1.5m"} {
+		_, err := NewPrivateClient("key", "secret", true, invalid, "linear")
+		assert.Error(t, err, invalid)
+	}
+}
+
+// TestSubscriptionManager_Chunking verifies that subscribe requests are split to the
+// per-request argument limit.
+func TestSubscriptionManager_Chunking(t *testing.T) {
+	srv := newEchoServer(t)
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithCategory("spot"))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	topics := make([]string, 25)
+	for i := range topics {
+		topics[i] = fmt.Sprintf("publicTrade.SYM%d", i)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, client.Subscriptions().Subscribe(ctx, topics...))
+
+	var sizes []int
+	for len(sizes) < 3 {
+		msg, err := client.ReceiveContext(ctx)
+		if !assert.NoError(t, err) {
+			return
+		}
+		var op opMessage
+		assert.NoError(t, json.Unmarshal(msg, &op))
+		assert.Equal(t, SubscribeOperation, op.Op)
+		sizes = append(sizes, len(op.Args))
+	}
+	assert.Equal(t, []int{10, 10, 5}, sizes)
+}
+
+// TestClient_Watch verifies that a silent topic is reported and resubscribed while a topic
+// that keeps delivering is not.
+func TestClient_Watch(t *testing.T) {
+	srv := newEchoServer(t)
+	stale := make(chan string, 4)
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithOnStale(func(topic string, silence time.Duration) {
+		assert.GreaterOrEqual(t, silence, 100*time.Millisecond)
+		stale <- topic
+	}))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, client.Subscriptions().Subscribe(ctx, "tickers.BTCUSDT"))
+	assert.Equal(t, opMessage{Op: SubscribeOperation, Args: []string{"tickers.BTCUSDT"}}, receiveOp(t, client))
+	client.Handle("tickers.ETHUSDT", func([]byte) {})
+
+	stopBTC := client.Watch("tickers.BTCUSDT", 100*time.Millisecond, StaleResubscribe)
+	defer stopBTC()
+	stopETH := client.Watch("tickers.ETHUSDT", 100*time.Millisecond, StaleNotify)
+	defer stopETH()
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, client.Send([]byte(`{"topic":"tickers.ETHUSDT","data":{}}`)))
+		time.Sleep(25 * time.Millisecond)
+	}
+
+	select {
+	case topic := <-stale:
+		assert.Equal(t, "tickers.BTCUSDT", topic)
+	case <-ctx.Done():
+		t.Fatal("stale topic was not reported")
+	}
+	assert.Equal(t, opMessage{Op: UnsubscribeOperation, Args: []string{"tickers.BTCUSDT"}}, receiveOp(t, client))
+	assert.Equal(t, opMessage{Op: SubscribeOperation, Args: []string{"tickers.BTCUSDT"}}, receiveOp(t, client))
+}
+
+// TestClient_WatchTwice verifies that stopping one of two watches of a topic leaves the other
+// tracking its messages.
+func TestClient_WatchTwice(t *testing.T) {
+	srv := newEchoServer(t)
+	stale := make(chan string, 4)
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithOnStale(func(topic string, silence time.Duration) {
+		stale <- topic
+	}))
+	assert.NoError(t, err)
+	defer client.Close()
+	client.Handle("tickers.ETHUSDT", func([]byte) {})
+
+	stopFirst := client.Watch("tickers.ETHUSDT", 100*time.Millisecond, StaleNotify)
+	stopSecond := client.Watch("tickers.ETHUSDT", 100*time.Millisecond, StaleNotify)
+	defer stopSecond()
+	stopFirst()
+	for i := 0; i < 8; i++ {
+		assert.NoError(t, client.Send([]byte(`{"topic":"tickers.ETHUSDT","data":{}}`)))
+		time.Sleep(25 * time.Millisecond)
+	}
+	select {
+	case topic := <-stale:
+		t.Fatalf("expected %s not to be reported while it receives messages", topic)
+	default:
+	}
+
+	stopSecond()
+	client.watchdog.mu.Lock()
+	_, watched := client.watchdog.topics["tickers.ETHUSDT"]
+	client.watchdog.mu.Unlock()
+	assert.False(t, watched)
+}
+
+type signerFunc func(payload string) (string, error)
+
+func (f signerFunc) Sign(payload string) (string, error) {
+	return f(payload)
+}
+
+// TestClient_Signer verifies that a Signer replaces the HMAC signature of the API secret and
+// that its failures are returned.
+func TestClient_Signer(t *testing.T) {
+	client, err := NewClient(WithSigner("key", signerFunc(func(payload string) (string, error) {
+		return "signed:" + payload, nil
+	})))
+	assert.NoError(t, err)
+	assert.EqualValues(t, Private, client.Channel)
+	assert.NotNil(t, client.ForCategory("spot").Signer)
+
+	expires, signature, err := client.sign()
+	assert.NoError(t, err)
+	assert.Equal(t, "signed:GET/realtime"+expires, signature)
+
+	client.Signer = signerFunc(func(string) (string, error) { return "", errors.New("no key") })
+	_, _, err = client.sign()
+	assert.ErrorContains(t, err, "no key")
+}
+
+// TestClient_Environment verifies the endpoints of the demo environment, with private and
+// trade streams on the demo host and market data from mainnet, and of regional domains.
+func TestClient_Environment(t *testing.T) {
+	public, err := NewClient(WithEnvironment(Demo), WithCategory("spot"))
+	assert.NoError(t, err)
+	assert.Equal(t, "wss://stream.bybit.com/v5/public/spot", public.buildURL())
+
+	private, err := NewClient(WithCredentials("key", "secret"), WithEnvironment(Demo))
+	assert.NoError(t, err)
+	assert.Equal(t, "wss://stream-demo.bybit.com/v5/private", private.buildURL())
+	assert.Equal(t, Demo, private.ForCategory("linear").Environment)
+
+	testnet, err := NewClient(WithCredentials("key", "secret"), WithChannel(Trade), WithEnvironment(Testnet))
+	assert.NoError(t, err)
+	assert.True(t, testnet.IsTestNet)
+	assert.Equal(t, "wss://stream-testnet.bybit.com/v5/trade", testnet.buildURL())
+
+	regional, err := NewClient(WithBaseDomain(DomainTR), WithCategory("spot"))
+	assert.NoError(t, err)
+	assert.Equal(t, "wss://stream.bybit-tr.com/v5/public/spot", regional.buildURL())
+	assert.Equal(t, DomainTR, regional.ForCategory("linear").Domain)
+}
+
+// metricsFunc adapts a function to MetricsObserver.
+type metricsFunc func(topic string, sent time.Time, handling time.Duration)
+
+func (f metricsFunc) ObserveMessage(topic string, sent time.Time, handling time.Duration) {
+	f(topic, sent, handling)
+}
+
+func (f metricsFunc) ObserveReconnect(err error) {}
+
+// TestClient_Metrics verifies that topic messages are reported with their timestamp.
+func TestClient_Metrics(t *testing.T) {
+	srv := newEchoServer(t)
+	type observation struct {
+		topic string
+		sent  time.Time
+	}
+	observed := make(chan observation, 2)
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithMetrics(metricsFunc(func(topic string, sent time.Time, handling time.Duration) {
+		observed <- observation{topic, sent}
+	})))
+	assert.NoError(t, err)
+	defer client.Close()
+	assert.NoError(t, client.Connect())
+	client.Handle("tickers.BTCUSDT", func(message []byte) {})
+
+	assert.NoError(t, client.Send([]byte(`{"topic":"tickers.BTCUSDT","ts":1700000000000,"data":{}}`)))
+	assert.NoError(t, client.Send([]byte(`{"topic":"tickers.ETHUSDT","data":{}}`)))
+	for _, want := range []observation{{"tickers.BTCUSDT", time.UnixMilli(1700000000000)}, {"tickers.ETHUSDT", time.Time{}}} {
+		select {
+		case got := <-observed:
+			assert.Equal(t, want.topic, got.topic)
+			assert.True(t, want.sent.Equal(got.sent), "sent %v, want %v", got.sent, want.sent)
+		case <-time.After(5 * time.Second):
+			t.Fatal("message was not observed")
+		}
+	}
+}
+
+// tracerFunc adapts a function to Tracer.
+type tracerFunc func(ctx context.Context, operation string, attributes map[string]string) (context.Context, func(err error))
+
+func (f tracerFunc) Start(ctx context.Context, operation string, attributes map[string]string) (context.Context, func(err error)) {
+	return f(ctx, operation, attributes)
+}
+
+// TestClient_Tracer verifies that connecting and shutting down are traced.
+func TestClient_Tracer(t *testing.T) {
+	srv := newEchoServer(t)
+	var (
+		mu    sync.Mutex
+		spans []string
+	)
+	tracer := tracerFunc(func(ctx context.Context, operation string, attributes map[string]string) (context.Context, func(err error)) {
+		assert.Equal(t, "linear", attributes["category"])
+		return ctx, func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			spans = append(spans, fmt.Sprintf("%s:%v", operation, err))
+		}
+	})
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithCategory("linear"), WithTracer(tracer))
+	assert.NoError(t, err)
+	assert.NoError(t, client.Connect())
+	assert.NoError(t, client.Shutdown(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"connect:<nil>", "shutdown:<nil>"}, spans)
+}
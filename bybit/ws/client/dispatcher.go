@@ -0,0 +1,219 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// InboxSize is the number of unrouted messages buffered for Receive. When the buffer is
+// full the oldest message is discarded so that the read pump never blocks.
+const InboxSize = 256
+
+// Handler processes a raw message published on a topic.
+type Handler func(message []byte)
+
+// envelope holds the routing fields shared by every Bybit WebSocket message.
+type envelope struct {
+	Topic       string `json:"topic"`
+	Op          string `json:"op"`
+	RetMsg      string `json:"ret_msg"`
+	ReqID       string `json:"req_id"`
+	ConnID      string `json:"conn_id"`
+	Success     *bool  `json:"success"`
+	RetCode     *int   `json:"retCode"`
+	TradeRetMsg string `json:"retMsg"`
+	TradeReqID  string `json:"reqId"`
+	TradeConnID string `json:"connId"`
+	// TS is kept raw so that a message with an unexpected timestamp format is still routed.
+	TS json.RawMessage `json:"ts"`
+}
+
+// HandlerPanicError is reported to OnHandlerError when a handler panics.
+type HandlerPanicError struct {
+	Topic string
+	Value any
+	Stack []byte
+}
+
+func (e *HandlerPanicError) Error() string {
+	return fmt.Sprintf("handler for %s panicked: %v", e.Topic, e.Value)
+}
+
+// handlerEntry is a registered handler together with the id used to remove it.
+type handlerEntry struct {
+	id      uint64
+	handler Handler
+}
+
+// dispatcher routes incoming messages to the handlers registered for their topic.
+type dispatcher struct {
+	mu       sync.RWMutex
+	nextID   uint64
+	handlers map[string][]handlerEntry
+}
+
+// Handle registers handler for messages whose "topic" field equals topic. Several handlers
+// may be registered for the same topic; they are invoked in registration order on the
+// client's read goroutine, so they should return quickly. The returned function removes
+// the handler.
+func (c *Client) Handle(topic string, handler Handler) (remove func()) {
+	c.init()
+	return c.dispatcher.add(topic, handler)
+}
+
+// RemoveHandlers removes every handler registered for topic.
+func (c *Client) RemoveHandlers(topic string) {
+	c.dispatcher.mu.Lock()
+	defer c.dispatcher.mu.Unlock()
+	delete(c.dispatcher.handlers, topic)
+}
+
+// add registers handler for topic and returns a function that removes it.
+func (d *dispatcher) add(topic string, handler Handler) (remove func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.handlers == nil {
+		d.handlers = make(map[string][]handlerEntry)
+	}
+	d.nextID++
+	id := d.nextID
+	d.handlers[topic] = append(d.handlers[topic], handlerEntry{id: id, handler: handler})
+
+	return func() { d.remove(topic, id) }
+}
+
+// remove deletes a single handler registration.
+func (d *dispatcher) remove(topic string, id uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries := d.handlers[topic]
+	for i, entry := range entries {
+		if entry.id == id {
+			entries = append(entries[:i:i], entries[i+1:]...)
+			break
+		}
+	}
+	if len(entries) == 0 {
+		delete(d.handlers, topic)
+		return
+	}
+	d.handlers[topic] = entries
+}
+
+// lookup returns a snapshot of the handlers registered for topic.
+func (d *dispatcher) lookup(topic string) []handlerEntry {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.handlers[topic]
+}
+
+// readPump is the only goroutine that reads from the connection. It routes each message to
+// the handlers registered for its topic and queues everything else for Receive. Read errors
+// trigger a reconnect, after which the pump continues on the new connection.
+func (c *Client) readPump() {
+	conn := c.waitForConnection(nil)
+	for conn != nil {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if c.closing() {
+				return
+			}
+			c.logger.Warn("Error receiving message: %v", err)
+			if c.currentConn() == conn {
+				c.handleReconnection()
+			}
+			conn = c.waitForConnection(conn)
+			continue
+		}
+		c.observe(Inbound, message)
+		c.dispatch(message)
+	}
+}
+
+// dispatch delivers a single message.
+func (c *Client) dispatch(message []byte) {
+	var env envelope
+	if err := json.Unmarshal(message, &env); err != nil {
+		c.deliverToInbox(message)
+		return
+	}
+	c.handleHeartbeat(env)
+	if c.handleAck(env, message) {
+		return
+	}
+	if env.Topic != "" {
+		c.watchdog.touch(env.Topic)
+		if entries := c.dispatcher.lookup(env.Topic); len(entries) > 0 {
+			start := time.Now()
+			for _, entry := range entries {
+				c.invoke(env.Topic, entry.handler, message)
+			}
+			c.observeMessage(env, time.Since(start))
+			return
+		}
+		c.observeMessage(env, 0)
+	}
+	c.deliverToInbox(message)
+}
+
+// invoke calls handler with message. A panic in handler is recovered, logged and reported to
+// OnHandlerError so that the read pump and the other handlers keep running.
+func (c *Client) invoke(topic string, handler Handler, message []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := &HandlerPanicError{Topic: topic, Value: r, Stack: debug.Stack()}
+			c.logger.Error("%v\n%s", err, err.Stack)
+			if c.OnHandlerError != nil {
+				c.OnHandlerError(err)
+			}
+		}
+	}()
+	handler(message)
+}
+
+// deliverToInbox queues message for Receive, dropping the oldest queued message if full.
+func (c *Client) deliverToInbox(message []byte) {
+	for {
+		select {
+		case c.inbox <- message:
+			return
+		default:
+		}
+		select {
+		case <-c.inbox:
+		default:
+		}
+	}
+}
+
+// currentConn returns the active connection, or nil while disconnected.
+func (c *Client) currentConn() *websocket.Conn {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+	return c.Conn
+}
+
+// waitForConnection returns the current connection once it differs from previous, or nil
+// when the client is closed.
+func (c *Client) waitForConnection(previous *websocket.Conn) *websocket.Conn {
+	for {
+		c.connLock.Lock()
+		conn, ready := c.Conn, c.connReady
+		c.connLock.Unlock()
+
+		if conn != nil && conn != previous {
+			return conn
+		}
+		select {
+		case <-ready:
+		case <-c.done:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package client
+
+// Environment selects the Bybit deployment a client connects to.
+type Environment int
+
+const (
+	Mainnet Environment = iota
+	Testnet
+	// Demo is demo trading. It has its own private and trade streams, while market data
+	// comes from the mainnet public streams.
+	Demo
+)
+
+// Domains of the regional Bybit sites, for WithBaseDomain. Users of a regulated region must
+// use its domain; DomainBytick is an alternative to DomainBybit.
+const (
+	DomainBybit  = "bybit.com"
+	DomainBytick = "bytick.com"
+	DomainNL     = "bybit.nl"
+	DomainTR     = "bybit-tr.com"
+	DomainHK     = "bybit.com.hk"
+)
+
+// Prefixes of the stream hosts, which are <prefix>.<domain>.
+const (
+	mainnetPrefix = "stream"
+	testnetPrefix = "stream-testnet"
+	demoPrefix    = "stream-demo"
+)
+
+func (e Environment) String() string {
+	switch e {
+	case Testnet:
+		return "testnet"
+	case Demo:
+		return "demo"
+	default:
+		return "mainnet"
+	}
+}
+
+// WithEnvironment selects the deployment the client connects to, overriding WithTestnet.
+func WithEnvironment(env Environment) Option {
+	return func(c *Client) {
+		c.Environment = env
+		c.IsTestNet = env == Testnet
+	}
+}
+
+// WithBaseDomain connects to the regional site on domain, such as DomainTR, instead of
+// bybit.com.
+func WithBaseDomain(domain string) Option {
+	return func(c *Client) {
+		c.Domain = domain
+	}
+}
+
+// host returns the stream host of the client's deployment, channel and domain.
+func (c *Client) host() string {
+	domain := c.Domain
+	if domain == "" {
+		domain = DomainBybit
+	}
+	switch {
+	case c.Environment == Demo && c.Channel != Public:
+		return demoPrefix + "." + domain
+	case c.Environment == Demo:
+		return mainnetPrefix + "." + domain
+	case c.IsTestNet:
+		return testnetPrefix + "." + domain
+	default:
+		return mainnetPrefix + "." + domain
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	// PongOperation is the op Bybit uses for pong replies on private connections and the
+	// op the client uses to answer server pings.
+	PongOperation = "pong"
+	// DefaultPongTimeout is how long the client waits for a pong before it treats the
+	// connection as stale, when Client.PongTimeout is zero.
+	DefaultPongTimeout = 10 * time.Second
+)
+
+// keepAlive sends a ping every ping interval and reconnects when the server does not answer
+// within the pong timeout or the ping cannot be written. It returns once the client is closed.
+func (c *Client) keepAlive() {
+	ticker := time.NewTicker(c.heartbeatInterval())
+	defer ticker.Stop()
+
+	var pongDeadline <-chan time.Time
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			if c.sendPingAndHandleReconnection() && pongDeadline == nil {
+				pongDeadline = time.After(c.pongTimeout())
+			}
+		case <-pongDeadline:
+			pongDeadline = nil
+			c.checkPong()
+		}
+	}
+}
+
+// checkPong reconnects when no pong has arrived since the last ping was sent.
+func (c *Client) checkPong() {
+	if c.lastPong.Load() >= c.lastPing.Load() {
+		return
+	}
+	c.logger.Warn("No pong received within %s, reconnecting", c.pongTimeout())
+	go c.handleReconnection()
+}
+
+// recordPong marks the connection as alive and, when the pong answers an outstanding ping,
+// records the round-trip time.
+func (c *Client) recordPong() {
+	now := time.Now().UnixNano()
+	sent := c.lastPing.Load()
+	if previous := c.lastPong.Swap(now); sent <= previous {
+		return
+	}
+
+	rtt := time.Duration(now - sent)
+	c.rtt.add(rtt)
+	if c.OnRTT != nil {
+		c.OnRTT(rtt)
+	}
+}
+
+// handleHeartbeat records pongs and answers server pings. Public connections acknowledge a
+// ping with {"op":"ping","ret_msg":"pong"}, private ones with {"op":"pong"}; a ping without
+// ret_msg was initiated by the server.
+func (c *Client) handleHeartbeat(env envelope) {
+	switch {
+	case env.Op == PongOperation, env.Op == PingOperation && env.RetMsg == PongOperation:
+		c.recordPong()
+	case env.Op == PingOperation && env.RetMsg == "":
+		go c.replyToPing(env.ReqID)
+	}
+}
+
+// replyToPing answers a server-initiated ping.
+func (c *Client) replyToPing(reqID string) {
+	jsonData, err := json.Marshal(PingMsg{Op: PongOperation, ReqID: reqID})
+	if err != nil {
+		c.logger.Error("Error marshaling pong message: %v", err)
+		return
+	}
+	if err := c.enqueue(context.Background(), jsonData); err != nil {
+		c.logger.Warn("Error sending pong: %v", err)
+	}
+}
+
+// heartbeatInterval returns the interval between pings.
+func (c *Client) heartbeatInterval() time.Duration {
+	if c.pingInterval > 0 {
+		return c.pingInterval
+	}
+	return PingInterval
+}
+
+// pongTimeout returns the configured pong timeout, falling back to DefaultPongTimeout.
+func (c *Client) pongTimeout() time.Duration {
+	if c.PongTimeout > 0 {
+		return c.PongTimeout
+	}
+	return DefaultPongTimeout
+}
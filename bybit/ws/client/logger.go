@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+)
+
+// Logger receives the client's diagnostic output. The logger package's *logger.Logger
+// satisfies it, and NewSlogLogger adapts a *slog.Logger.
+type Logger interface {
+	Debug(format string, v ...any)
+	Info(format string, v ...any)
+	Warn(format string, v ...any)
+	Error(format string, v ...any)
+}
+
+// NewStdLogger adapts a *log.Logger, prefixing every line with its level.
+func NewStdLogger(l *log.Logger) Logger {
+	return stdLogger{l}
+}
+
+// NewSlogLogger adapts a *slog.Logger. Messages are formatted before they are logged.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return slogLogger{l}
+}
+
+// NopLogger returns a Logger that discards everything.
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+// defaultLogger is used when no logger is configured.
+func defaultLogger() Logger {
+	return NewStdLogger(log.New(os.Stdout, "[WebSocketClient] ", log.LstdFlags))
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+func (s stdLogger) Debug(format string, v ...any) { s.l.Printf("DEBUG "+format, v...) }
+func (s stdLogger) Info(format string, v ...any)  { s.l.Printf("INFO "+format, v...) }
+func (s stdLogger) Warn(format string, v ...any)  { s.l.Printf("WARN "+format, v...) }
+func (s stdLogger) Error(format string, v ...any) { s.l.Printf("ERROR "+format, v...) }
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) Debug(format string, v ...any) { s.log(slog.LevelDebug, format, v...) }
+func (s slogLogger) Info(format string, v ...any)  { s.log(slog.LevelInfo, format, v...) }
+func (s slogLogger) Warn(format string, v ...any)  { s.log(slog.LevelWarn, format, v...) }
+func (s slogLogger) Error(format string, v ...any) { s.log(slog.LevelError, format, v...) }
+
+func (s slogLogger) log(level slog.Level, format string, v ...any) {
+	ctx := context.Background()
+	if s.l.Enabled(ctx, level) {
+		s.l.Log(ctx, level, fmt.Sprintf(format, v...))
+	}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// Logger returns the client's logger so that stream services can log through it.
+func (c *Client) Logger() Logger {
+	c.init()
+	return c.logger
+}
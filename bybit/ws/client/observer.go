@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Direction tells whether a raw message was received from or sent to the server.
+type Direction int
+
+const (
+	// Inbound marks a message read from the connection.
+	Inbound Direction = iota
+	// Outbound marks a message written to the connection.
+	Outbound
+)
+
+// String returns "inbound" or "outbound".
+func (d Direction) String() string {
+	if d == Outbound {
+		return "outbound"
+	}
+	return "inbound"
+}
+
+// observe passes message to the OnRawMessage hook, if one is set.
+func (c *Client) observe(direction Direction, message []byte) {
+	if c.OnRawMessage != nil {
+		c.OnRawMessage(direction, message)
+	}
+}
+
+// MetricsObserver receives the activity of a client for metrics. Its methods are called on
+// the client's goroutines and should return quickly.
+type MetricsObserver interface {
+	// ObserveMessage is called for every message published on a topic, after its handlers
+	// returned. sent is the timestamp Bybit gave the message, zero when it has none, and
+	// handling is how long the handlers took.
+	ObserveMessage(topic string, sent time.Time, handling time.Duration)
+	// ObserveReconnect is called after every reconnection attempt with its error.
+	ObserveReconnect(err error)
+}
+
+// observeMessage passes a topic message to the Metrics observer, if one is set.
+func (c *Client) observeMessage(env envelope, handling time.Duration) {
+	if c.Metrics == nil {
+		return
+	}
+	var sent time.Time
+	if ts, err := strconv.ParseInt(strings.Trim(string(env.TS), `"`), 10, 64); err == nil && ts > 0 {
+		sent = time.UnixMilli(ts)
+	}
+	c.Metrics.ObserveMessage(env.Topic, sent, handling)
+}
+
+// Tracer starts spans around the lifecycle events of a client: connecting, every
+// reconnection attempt, shutting down and acknowledged requests such as auth, subscribe
+// and order.create, which are named after their op. The returned context carries the span
+// for the rest of the event, and end is called with its outcome. See the tracing package.
+type Tracer interface {
+	Start(ctx context.Context, operation string, attributes map[string]string) (_ context.Context, end func(err error))
+}
+
+// startSpan starts a span with the Tracer, if one is set. attributes are given as key
+// value pairs in addition to the channel and category of the client.
+func (c *Client) startSpan(ctx context.Context, operation string, attributes ...string) (context.Context, func(err error)) {
+	if c.Tracer == nil {
+		return ctx, func(error) {}
+	}
+	attrs := map[string]string{"channel": string(c.Channel)}
+	if c.Category != "" {
+		attrs["category"] = c.Category
+	}
+	for i := 0; i+1 < len(attributes); i += 2 {
+		attrs[attributes[i]] = attributes[i+1]
+	}
+	return c.Tracer.Start(ctx, operation, attrs)
+}
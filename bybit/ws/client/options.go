@@ -0,0 +1,285 @@
+package client
+
+import (
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Option configures a Client created with NewClient.
+type Option func(*Client)
+
+// NewClient creates a WebSocket client configured by opts. Without options it returns a
+// public mainnet client for the linear category.
+func NewClient(opts ...Option) (*Client, error) {
+	client := &Client{
+		Channel:   Public,
+		Connected: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	if client.pingInterval < 0 {
+		return nil, errors.New("ping interval must not be negative")
+	}
+	if client.PongTimeout < 0 {
+		return nil, errors.New("pong timeout must not be negative")
+	}
+	if err := validateMaxActiveTime(client.MaxActiveTime); err != nil {
+		return nil, err
+	}
+	client.init()
+	return client, nil
+}
+
+// ForCategory returns a new, unconnected client with the same configuration as c but
+// for another product category, so that services can open one connection per endpoint.
+func (c *Client) ForCategory(category string) *Client {
+	c.init()
+	derived := &Client{
+		logger:            c.logger,
+		IsTestNet:         c.IsTestNet,
+		Environment:       c.Environment,
+		Domain:            c.Domain,
+		APIKey:            c.APIKey,
+		APISecret:         c.APISecret,
+		Signer:            c.Signer,
+		Channel:           c.Channel,
+		Path:              c.Path,
+		Connected:         make(chan struct{}),
+		OnConnected:       c.OnConnected,
+		OnConnectionError: c.OnConnectionError,
+		Category:          category,
+		MaxActiveTime:     c.MaxActiveTime,
+		OnStateChange:     c.OnStateChange,
+		OnRawMessage:      c.OnRawMessage,
+		OnRTT:             c.OnRTT,
+		OnHandlerError:    c.OnHandlerError,
+		OnStale:           c.OnStale,
+		Metrics:           c.Metrics,
+		Tracer:            c.Tracer,
+		ReconnectPolicy:   c.ReconnectPolicy,
+		PongTimeout:       c.PongTimeout,
+		BrokerID:          c.BrokerID,
+		wsURL:             c.wsURL,
+		pingInterval:      c.pingInterval,
+		dialer:            c.dialer,
+		proxy:             c.proxy,
+		tlsConfig:         c.tlsConfig,
+		header:            c.header,
+		maxArgs:           c.maxArgs,
+		buffer:            c.buffer,
+	}
+	derived.init()
+	return derived
+}
+
+// WithTestnet selects the testnet endpoints when isTestNet is true.
+func WithTestnet(isTestNet bool) Option {
+	return func(c *Client) {
+		c.IsTestNet = isTestNet
+	}
+}
+
+// WithCategory sets the product category used to pick the public endpoint.
+func WithCategory(category string) Option {
+	return func(c *Client) {
+		c.Category = category
+	}
+}
+
+// WithCredentials makes the client private and sets the API key pair used to authenticate.
+func WithCredentials(apiKey, apiSecret string) Option {
+	return func(c *Client) {
+		c.Channel = Private
+		c.APIKey = apiKey
+		c.APISecret = apiSecret
+	}
+}
+
+// WithSigner makes the client private and authenticates apiKey with signatures made by
+// signer, such as the REST client's RSASigner for RSA API keys.
+func WithSigner(apiKey string, signer Signer) Option {
+	return func(c *Client) {
+		c.Channel = Private
+		c.APIKey = apiKey
+		c.Signer = signer
+	}
+}
+
+// WithChannel selects the channel the client connects to. It must follow WithCredentials or
+// WithSigner, which select the private channel.
+func WithChannel(channel ChannelType) Option {
+	return func(c *Client) {
+		c.Channel = channel
+	}
+}
+
+// WithMaxActiveTime sets the max_active_time of a private connection, between "30s" and
+// "10m". Bybit's default applies when it is empty.
+func WithMaxActiveTime(maxActiveTime string) Option {
+	return func(c *Client) {
+		c.MaxActiveTime = maxActiveTime
+	}
+}
+
+// WithPingInterval sets the interval between pings. The default is PingInterval.
+func WithPingInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.pingInterval = interval
+	}
+}
+
+// WithPongTimeout sets how long to wait for a pong before reconnecting. The default is
+// DefaultPongTimeout.
+func WithPongTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.PongTimeout = timeout
+	}
+}
+
+// WithReconnectPolicy sets the backoff used between reconnection attempts.
+func WithReconnectPolicy(policy ReconnectPolicy) Option {
+	return func(c *Client) {
+		c.ReconnectPolicy = policy
+	}
+}
+
+// WithLogger sets the logger used for connection diagnostics. Use NopLogger to silence
+// the client.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithDialer sets the dialer used to open connections, for example to change handshake
+// timeouts or NetDial. The default is websocket.DefaultDialer.
+func WithDialer(dialer *websocket.Dialer) Option {
+	return func(c *Client) {
+		c.dialer = dialer
+	}
+}
+
+// WithProxy routes connections through the proxy at proxyURL. HTTP, HTTPS and SOCKS5
+// proxies are supported. By default the proxy is taken from the environment.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Client) {
+		c.proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for wss connections, for example to pin
+// certificates or trust a corporate proxy's CA.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = config
+	}
+}
+
+// WithBrokerID sets the broker id sent with order entry calls, see Client.BrokerID.
+func WithBrokerID(id string) Option {
+	return func(c *Client) {
+		c.BrokerID = id
+	}
+}
+
+// WithHeader adds an HTTP header, such as a custom User-Agent, to the WebSocket handshake.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		if c.header == nil {
+			c.header = make(http.Header)
+		}
+		c.header.Add(key, value)
+	}
+}
+
+// WithURL overrides the endpoint derived from the channel, category and testnet settings,
+// for example to connect to a local test server.
+func WithURL(url string) Option {
+	return func(c *Client) {
+		c.wsURL = url
+	}
+}
+
+// WithOnStateChange registers a callback for connection state transitions.
+func WithOnStateChange(fn func(from, to State)) Option {
+	return func(c *Client) {
+		c.OnStateChange = fn
+	}
+}
+
+// WithOnRawMessage registers a hook that sees every frame sent or received, for wire-level
+// debugging.
+func WithOnRawMessage(fn func(direction Direction, message []byte)) Option {
+	return func(c *Client) {
+		c.OnRawMessage = fn
+	}
+}
+
+// WithOnRTT registers a callback that receives the round-trip time of every answered ping.
+func WithOnRTT(fn func(rtt time.Duration)) Option {
+	return func(c *Client) {
+		c.OnRTT = fn
+	}
+}
+
+// WithMetrics reports the client's messages and reconnections to metrics.
+func WithMetrics(metrics MetricsObserver) Option {
+	return func(c *Client) {
+		c.Metrics = metrics
+	}
+}
+
+// WithTracer traces the client's lifecycle events with tracer.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.Tracer = tracer
+	}
+}
+
+// WithOnHandlerError registers a callback that receives the error of every topic handler
+// that panicked.
+func WithOnHandlerError(fn func(err error)) Option {
+	return func(c *Client) {
+		c.OnHandlerError = fn
+	}
+}
+
+// WithMessageBuffer sets the size and overflow policy of the raw message channels that
+// stream services such as kline expose through GetMessagesChan.
+func WithMessageBuffer(cfg BufferConfig) Option {
+	return func(c *Client) {
+		c.buffer = &cfg
+	}
+}
+
+// MessageBuffer returns the buffer configuration set by WithMessageBuffer, or
+// DefaultBufferConfig.
+func (c *Client) MessageBuffer() BufferConfig {
+	if c.buffer == nil {
+		return DefaultBufferConfig
+	}
+	return *c.buffer
+}
+
+// WithOnStale registers a callback that is invoked whenever a topic watched with Watch stops
+// delivering messages.
+func WithOnStale(fn func(topic string, silence time.Duration)) Option {
+	return func(c *Client) {
+		c.OnStale = fn
+	}
+}
+
+// WithMaxArgsPerRequest limits the number of topics sent in one subscribe or unsubscribe
+// request. By default spot public connections send at most SpotMaxArgsPerRequest topics
+// per request and other connections are not limited.
+func WithMaxArgsPerRequest(n int) Option {
+	return func(c *Client) {
+		c.maxArgs = n
+	}
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// MaxArgsLength is the maximum combined length of the topics Bybit accepts on a single
+// connection.
+const MaxArgsLength = 21000
+
+// Pool spreads subscriptions over as many connections as needed to stay within Bybit's
+// per-connection limits. Connections are derived from a template client with ForCategory
+// and opened on demand; messages from every connection are routed to the handlers
+// registered with Pool.Handle.
+type Pool struct {
+	template  *Client
+	maxTopics int
+
+	mu         sync.Mutex
+	conns      []*poolConn
+	owners     map[string]*poolConn
+	dispatcher dispatcher
+}
+
+// poolConn is a pooled connection and the topics placed on it.
+type poolConn struct {
+	client *Client
+	length int
+	routes map[string]func()
+}
+
+// NewPool creates a pool whose connections share template's configuration and hold at
+// most maxTopicsPerConn topics each. With maxTopicsPerConn <= 0 only MaxArgsLength limits
+// a connection. The template itself is never connected by the pool.
+func NewPool(template *Client, maxTopicsPerConn int) *Pool {
+	return &Pool{
+		template:  template,
+		maxTopics: maxTopicsPerConn,
+		owners:    make(map[string]*poolConn),
+	}
+}
+
+// Handle registers handler for messages on topic, whichever connection carries it.
+func (p *Pool) Handle(topic string, handler Handler) (remove func()) {
+	return p.dispatcher.add(topic, handler)
+}
+
+// Subscribe subscribes to topics, opening new connections when the existing ones are full.
+// Topics that are already subscribed stay on their connection.
+func (p *Pool) Subscribe(ctx context.Context, topics ...string) error {
+	p.mu.Lock()
+	var order []*poolConn
+	groups := make(map[*poolConn][]string)
+	for _, topic := range dedupe(topics) {
+		pc, ok := p.owners[topic]
+		if !ok {
+			pc = p.place(topic)
+		}
+		if _, ok := groups[pc]; !ok {
+			order = append(order, pc)
+		}
+		groups[pc] = append(groups[pc], topic)
+	}
+	p.mu.Unlock()
+
+	for _, pc := range order {
+		if err := pc.client.Subscriptions().Subscribe(ctx, groups[pc]...); err != nil {
+			p.reclaim(groups[pc])
+			return err
+		}
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from topics on whichever connections carry them.
+func (p *Pool) Unsubscribe(ctx context.Context, topics ...string) error {
+	p.mu.Lock()
+	var order []*poolConn
+	groups := make(map[*poolConn][]string)
+	for _, topic := range dedupe(topics) {
+		pc, ok := p.owners[topic]
+		if !ok {
+			continue
+		}
+		if _, ok := groups[pc]; !ok {
+			order = append(order, pc)
+		}
+		groups[pc] = append(groups[pc], topic)
+	}
+	p.mu.Unlock()
+
+	var firstErr error
+	for _, pc := range order {
+		if err := pc.client.Subscriptions().Unsubscribe(ctx, groups[pc]...); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		p.reclaim(groups[pc])
+	}
+	return firstErr
+}
+
+// Size returns the number of connections in the pool.
+func (p *Pool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.conns)
+}
+
+// Close closes every pooled connection.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	conns := p.conns
+	p.conns = nil
+	p.owners = make(map[string]*poolConn)
+	p.mu.Unlock()
+
+	for _, pc := range conns {
+		pc.client.Close()
+	}
+}
+
+// place assigns topic to the first connection with room for it, creating a connection if
+// necessary. The caller must hold p.mu.
+func (p *Pool) place(topic string) *poolConn {
+	var target *poolConn
+	for _, pc := range p.conns {
+		if p.fits(pc, topic) {
+			target = pc
+			break
+		}
+	}
+	if target == nil {
+		target = &poolConn{
+			client: p.template.ForCategory(p.template.Category),
+			routes: make(map[string]func()),
+		}
+		p.conns = append(p.conns, target)
+	}
+
+	target.length += len(topic)
+	target.routes[topic] = target.client.Handle(topic, func(message []byte) {
+		for _, entry := range p.dispatcher.lookup(topic) {
+			entry.handler(message)
+		}
+	})
+	p.owners[topic] = target
+	return target
+}
+
+// fits reports whether topic can be added to pc.
+func (p *Pool) fits(pc *poolConn, topic string) bool {
+	if p.maxTopics > 0 && len(pc.routes) >= p.maxTopics {
+		return false
+	}
+	return pc.length+len(topic) <= MaxArgsLength
+}
+
+// reclaim frees the slots of topics that are no longer subscribed on their connection.
+func (p *Pool) reclaim(topics []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, topic := range topics {
+		pc, ok := p.owners[topic]
+		if !ok || pc.client.Subscriptions().active(topic) {
+			continue
+		}
+		pc.routes[topic]()
+		delete(pc.routes, topic)
+		pc.length -= len(topic)
+		delete(p.owners, topic)
+	}
+}
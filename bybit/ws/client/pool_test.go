@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTopicServer starts a server that publishes one message on every topic it is asked to
+// subscribe to and counts the connections it accepts.
+func newTopicServer(t *testing.T, connections *atomic.Int32) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		connections.Add(1)
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if ack := ackFor(msg, true, ""); ack != nil {
+				if err := conn.WriteMessage(websocket.TextMessage, ack); err != nil {
+					return
+				}
+			}
+			var req opMessage
+			if err := json.Unmarshal(msg, &req); err != nil || req.Op != SubscribeOperation {
+				continue
+			}
+			for _, topic := range req.Args {
+				update := fmt.Sprintf(`{"topic":%q,"type":"snapshot","data":{}}`, topic)
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(update)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestPool verifies that the pool opens a connection per maxTopicsPerConn topics, routes
+// messages back to the handlers and frees slots on unsubscribe.
+func TestPool(t *testing.T) {
+	var connections atomic.Int32
+	srv := newTopicServer(t, &connections)
+	template, err := NewClient(WithURL(wsURLFor(srv)))
+	assert.NoError(t, err)
+
+	pool := NewPool(template, 2)
+	defer pool.Close()
+
+	topics := []string{"tickers.A", "tickers.B", "tickers.C", "tickers.D", "tickers.E"}
+	var mu sync.Mutex
+	received := make(map[string]int)
+	for _, topic := range topics {
+		topic := topic
+		pool.Handle(topic, func([]byte) {
+			mu.Lock()
+			defer mu.Unlock()
+			received[topic]++
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, pool.Subscribe(ctx, topics...))
+	assert.Equal(t, 3, pool.Size())
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == len(topics)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(3), connections.Load())
+
+	// Freeing a slot lets the next topic reuse an existing connection.
+	assert.NoError(t, pool.Unsubscribe(ctx, "tickers.A"))
+	assert.NoError(t, pool.Subscribe(ctx, "tickers.F"))
+	assert.Equal(t, 3, pool.Size())
+}
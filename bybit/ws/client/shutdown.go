@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// CloseTimeout bounds how long Close waits for the graceful shutdown to complete.
+const CloseTimeout = 5 * time.Second
+
+// Close gracefully closes the WebSocket connection, waiting at most CloseTimeout.
+func (c *Client) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), CloseTimeout)
+	defer cancel()
+	if err := c.Shutdown(ctx); err != nil {
+		c.logger.Warn("Shutdown did not complete: %v", err)
+	}
+}
+
+// Shutdown closes the client gracefully. It sends a close frame and waits for the server to
+// acknowledge it, which lets the read pump deliver the messages already in flight to their
+// handlers. It then stops the writer and keep-alive goroutines, makes pending Receive calls
+// return ErrClientClosed and closes the connection. If ctx expires first, the connection is
+// closed immediately and ctx's error is returned. Only the first call has any effect.
+func (c *Client) Shutdown(ctx context.Context) error {
+	var err error
+	c.closeOnce.Do(func() {
+		var end func(error)
+		ctx, end = c.startSpan(ctx, "shutdown")
+		err = c.shutdown(ctx)
+		end(err)
+	})
+	return err
+}
+
+func (c *Client) shutdown(ctx context.Context) error {
+	c.init()
+
+	c.connLock.Lock()
+	c.isClosed = true
+	conn := c.Conn
+	c.connLock.Unlock()
+	c.setState(StateClosed)
+
+	var err error
+	if conn != nil && c.started.Load() {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			deadline = time.Now().Add(WriteTimeout)
+		}
+		closeMsg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+		if writeErr := conn.WriteControl(websocket.CloseMessage, closeMsg, deadline); writeErr == nil {
+			// The read pump returns once the server echoes the close frame.
+			select {
+			case <-c.readerDone:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+	}
+
+	close(c.done)
+
+	c.connLock.Lock()
+	if c.Conn != nil {
+		if closeErr := c.Conn.Close(); closeErr != nil && c.OnConnectionError != nil {
+			c.OnConnectionError(closeErr)
+		}
+		c.Conn = nil
+	}
+	c.connLock.Unlock()
+
+	loopsDone := make(chan struct{})
+	go func() {
+		c.loops.Wait()
+		close(loopsDone)
+	}()
+	select {
+	case <-loopsDone:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	c.logger.Info("Connection closed")
+	return err
+}
+
+// closing reports whether Close or Shutdown has been called.
+func (c *Client) closing() bool {
+	c.connLock.Lock()
+	defer c.connLock.Unlock()
+	return c.isClosed
+}
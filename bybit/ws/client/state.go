@@ -0,0 +1,61 @@
+package client
+
+// State is a stage in the lifecycle of a Client connection.
+type State int32
+
+const (
+	// StateDisconnected is the state of a client that has not connected yet or gave up reconnecting.
+	StateDisconnected State = iota
+	// StateConnecting is the state while the initial connection is being dialled.
+	StateConnecting
+	// StateConnected is the state once the WebSocket connection is open.
+	StateConnected
+	// StateAuthenticated is the state once a private connection has been authenticated.
+	StateAuthenticated
+	// StateReconnecting is the state while the client redials after losing the connection.
+	StateReconnecting
+	// StateClosed is the final state after Close.
+	StateClosed
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateDisconnected:
+		return "disconnected"
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateAuthenticated:
+		return "authenticated"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateClosed:
+		return "closed"
+	default:
+		return "unknown"
+	}
+}
+
+// State returns the current connection state.
+func (c *Client) State() State {
+	return State(c.state.Load())
+}
+
+// setState moves the client to state to and notifies OnStateChange. A closed client never
+// leaves StateClosed.
+func (c *Client) setState(to State) {
+	for {
+		from := State(c.state.Load())
+		if from == to || from == StateClosed {
+			return
+		}
+		if c.state.CompareAndSwap(int32(from), int32(to)) {
+			if c.OnStateChange != nil {
+				c.OnStateChange(from, to)
+			}
+			return
+		}
+	}
+}
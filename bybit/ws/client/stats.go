@@ -0,0 +1,65 @@
+package client
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// rttWindow is the number of recent round trips Stats aggregates.
+const rttWindow = 256
+
+// Stats describes the ping/pong round-trip times of a connection. LastRTT is the most recent
+// sample; AvgRTT and P99RTT cover the last 256 samples. Samples counts every round trip
+// measured since the client was created.
+type Stats struct {
+	LastRTT time.Duration
+	AvgRTT  time.Duration
+	P99RTT  time.Duration
+	Samples int
+}
+
+// rttStats is a fixed-size ring of round-trip samples.
+type rttStats struct {
+	mu      sync.Mutex
+	samples [rttWindow]time.Duration
+	total   int
+	last    time.Duration
+}
+
+// add records a round trip.
+func (s *rttStats) add(rtt time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples[s.total%rttWindow] = rtt
+	s.total++
+	s.last = rtt
+}
+
+// snapshot aggregates the recorded samples.
+func (s *rttStats) snapshot() Stats {
+	s.mu.Lock()
+	n := min(s.total, rttWindow)
+	window := make([]time.Duration, n)
+	copy(window, s.samples[:n])
+	stats := Stats{LastRTT: s.last, Samples: s.total}
+	s.mu.Unlock()
+
+	if n == 0 {
+		return stats
+	}
+	var sum time.Duration
+	for _, rtt := range window {
+		sum += rtt
+	}
+	stats.AvgRTT = sum / time.Duration(n)
+
+	sort.Slice(window, func(i, j int) bool { return window[i] < window[j] })
+	stats.P99RTT = window[(n*99+99)/100-1]
+	return stats
+}
+
+// Stats returns the round-trip statistics of the client's pings.
+func (c *Client) Stats() Stats {
+	return c.rtt.snapshot()
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRTTStats(t *testing.T) {
+	var s rttStats
+	assert.Equal(t, Stats{}, s.snapshot())
+
+	for i := 1; i <= 100; i++ {
+		s.add(time.Duration(i) * time.Millisecond)
+	}
+	stats := s.snapshot()
+	assert.Equal(t, 100*time.Millisecond, stats.LastRTT)
+	assert.Equal(t, 50500*time.Microsecond, stats.AvgRTT)
+	assert.Equal(t, 99*time.Millisecond, stats.P99RTT)
+	assert.Equal(t, 100, stats.Samples)
+
+	// Only the most recent rttWindow samples are aggregated.
+	for i := 0; i < rttWindow; i++ {
+		s.add(time.Millisecond)
+	}
+	stats = s.snapshot()
+	assert.Equal(t, time.Millisecond, stats.AvgRTT)
+	assert.Equal(t, time.Millisecond, stats.P99RTT)
+	assert.Equal(t, 100+rttWindow, stats.Samples)
+}
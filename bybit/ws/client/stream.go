@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PrivateStream is the topic of a private stream service, such as the order or position
+// stream, whose messages carry a list of T. The first Subscribe authenticates the
+// connection and subscribes to the topic; later calls only replace the handler. Subscribe
+// and Unsubscribe are serialized, so concurrent calls never register the topic twice.
+type PrivateStream[T any] struct {
+	client *Client
+	topic  string
+
+	// ops serializes Subscribe and Unsubscribe, which hold it across the network calls.
+	ops         sync.Mutex
+	unsubscribe func() error
+
+	mu      sync.Mutex
+	handler func(T)
+}
+
+// NewPrivateStream creates a PrivateStream of topic on the private client c.
+func NewPrivateStream[T any](c *Client, topic string) *PrivateStream[T] {
+	return &PrivateStream[T]{client: c, topic: topic}
+}
+
+// Topic returns the topic of the stream.
+func (s *PrivateStream[T]) Topic() string {
+	return s.topic
+}
+
+// Subscribe authenticates the connection if needed and subscribes to the topic. handler is
+// invoked once per item of every message and replaces any previous handler.
+func (s *PrivateStream[T]) Subscribe(ctx context.Context, handler func(T)) error {
+	s.ops.Lock()
+	defer s.ops.Unlock()
+	if err := s.client.EnsureAuthenticated(ctx); err != nil {
+		return fmt.Errorf("failed to authenticate for %s: %w", s.topic, err)
+	}
+	s.setHandler(handler)
+	if s.unsubscribe != nil {
+		return nil
+	}
+
+	unsubscribe, err := Subscribe(ctx, s.client, s.topic, s.dispatch)
+	if err != nil {
+		s.setHandler(nil)
+		return fmt.Errorf("failed to subscribe to %s: %w", s.topic, err)
+	}
+	s.unsubscribe = unsubscribe
+	return nil
+}
+
+// Unsubscribe removes the handler and unsubscribes from the topic. It does nothing when the
+// stream is not subscribed.
+func (s *PrivateStream[T]) Unsubscribe() error {
+	s.ops.Lock()
+	defer s.ops.Unlock()
+	if s.unsubscribe == nil {
+		return nil
+	}
+	unsubscribe := s.unsubscribe
+	s.unsubscribe = nil
+	s.setHandler(nil)
+	if err := unsubscribe(); err != nil {
+		return fmt.Errorf("failed to unsubscribe from %s: %w", s.topic, err)
+	}
+	return nil
+}
+
+// Close unsubscribes and logs a failure. The client stays open because it may be shared
+// with other services.
+func (s *PrivateStream[T]) Close() {
+	if err := s.Unsubscribe(); err != nil {
+		s.client.Logger().Error("%v", err)
+	}
+}
+
+func (s *PrivateStream[T]) setHandler(handler func(T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = handler
+}
+
+// dispatch passes the items of a message to the current handler.
+func (s *PrivateStream[T]) dispatch(items []T) {
+	s.mu.Lock()
+	handler := s.handler
+	s.mu.Unlock()
+	if handler == nil {
+		return
+	}
+	for _, item := range items {
+		handler(item)
+	}
+}
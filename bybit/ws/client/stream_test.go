@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPrivateStream verifies that concurrent Subscribe calls authenticate and subscribe
+// once, that a later Subscribe replaces the handler and that Unsubscribe sends the op and
+// drops the handler.
+func TestPrivateStream(t *testing.T) {
+	ops := make(chan string, 16)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req opMessage
+			if err := json.Unmarshal(msg, &req); err != nil || req.ReqID == "" {
+				continue
+			}
+			ops <- req.Op
+			if err := conn.WriteMessage(websocket.TextMessage, ackFor(msg, true, "")); err != nil {
+				return
+			}
+			if req.Op == SubscribeOperation {
+				update := fmt.Sprintf(`{"id":"1","topic":%q,"creationTime":1,"data":["a","b"]}`, req.Args[0])
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(update)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(WithURL(wsURLFor(srv)), WithCredentials("key", "secret"))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	received := make(chan string, 16)
+	stream := NewPrivateStream[string](client, "order")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, stream.Subscribe(context.Background(), func(item string) { received <- item }))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, AuthOperation, <-ops)
+	assert.Equal(t, SubscribeOperation, <-ops)
+	assert.Len(t, ops, 0)
+	assert.Equal(t, []string{"order"}, client.Subscriptions().ListSubscriptions())
+
+	for _, want := range []string{"a", "b"} {
+		select {
+		case item := <-received:
+			assert.Equal(t, want, item)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the update")
+		}
+	}
+
+	var replaced []string
+	assert.NoError(t, stream.Subscribe(context.Background(), func(item string) { replaced = append(replaced, item) }))
+	stream.dispatch([]string{"c"})
+	assert.Equal(t, []string{"c"}, replaced)
+	assert.Len(t, received, 0)
+
+	assert.NoError(t, stream.Unsubscribe())
+	assert.Equal(t, UnsubscribeOperation, <-ops)
+	assert.Empty(t, client.Subscriptions().ListSubscriptions())
+	stream.dispatch([]string{"d"})
+	assert.Equal(t, []string{"c"}, replaced)
+
+	assert.NoError(t, stream.Unsubscribe())
+	assert.Len(t, ops, 0)
+}
@@ -63,8 +63,5 @@ type SuccessResponse struct {
 	RetMsg  string `json:"ret_msg"` // RetMsg provides details on the return message of the request
 }
 
-// Environment denotes the environment in which the Bybit API operates (e.g., "production", "development").
-type Environment string
-
 // SubChannel represents a sub-channel for Bybit API's WebSocket communications.
 type SubChannel string
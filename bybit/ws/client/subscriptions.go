@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+const (
+	SubscribeOperation   = "subscribe"
+	UnsubscribeOperation = "unsubscribe"
+	// SpotMaxArgsPerRequest is the number of topics Bybit accepts in one subscribe request
+	// on the spot public stream.
+	SpotMaxArgsPerRequest = 10
+)
+
+// opMessage is the wire format of subscribe and unsubscribe requests.
+type opMessage struct {
+	ReqID string   `json:"req_id,omitempty"`
+	Op    string   `json:"op"`
+	Args  []string `json:"args"`
+}
+
+// SubscriptionManager tracks the topics a Client is subscribed to. Topics are reference
+// counted so that several stream services can share a topic: the subscribe request is only
+// sent for the first subscriber and the unsubscribe request only for the last one. The
+// active set is replayed after every reconnect.
+type SubscriptionManager struct {
+	client *Client
+	mu     sync.Mutex
+	topics map[string]int
+}
+
+// newSubscriptionManager creates an empty manager bound to c.
+func newSubscriptionManager(c *Client) *SubscriptionManager {
+	return &SubscriptionManager{
+		client: c,
+		topics: make(map[string]int),
+	}
+}
+
+// Subscriptions returns the subscription manager of the client.
+func (c *Client) Subscriptions() *SubscriptionManager {
+	c.init()
+	return c.subscriptions
+}
+
+// Subscribe subscribes to the given topics. Topics that are already active are not sent
+// again; the remaining ones are packed into as few subscribe requests as the per-request
+// argument limit allows.
+func (m *SubscriptionManager) Subscribe(ctx context.Context, topics ...string) error {
+	m.mu.Lock()
+	var pending []string
+	for _, topic := range dedupe(topics) {
+		if m.topics[topic] == 0 {
+			pending = append(pending, topic)
+		}
+		m.topics[topic]++
+	}
+	m.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+	if err := m.send(ctx, SubscribeOperation, pending); err != nil {
+		m.release(topics)
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe drops one reference to each of the given topics and sends unsubscribe
+// requests for the topics that no longer have any subscribers.
+func (m *SubscriptionManager) Unsubscribe(ctx context.Context, topics ...string) error {
+	released := m.release(topics)
+	if len(released) == 0 {
+		return nil
+	}
+	return m.send(ctx, UnsubscribeOperation, released)
+}
+
+// Resubscribe sends an unsubscribe request followed by a subscribe request for the given
+// active topics, regardless of how many subscribers they have, so that the server starts
+// them afresh with a new snapshot. Inactive topics are ignored.
+func (m *SubscriptionManager) Resubscribe(ctx context.Context, topics ...string) error {
+	var active []string
+	for _, topic := range dedupe(topics) {
+		if m.active(topic) {
+			active = append(active, topic)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	if err := m.send(ctx, UnsubscribeOperation, active); err != nil {
+		return err
+	}
+	return m.send(ctx, SubscribeOperation, active)
+}
+
+// ListSubscriptions returns the active topics in lexical order.
+func (m *SubscriptionManager) ListSubscriptions() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	topics := make([]string, 0, len(m.topics))
+	for topic := range m.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// active reports whether topic has at least one subscriber.
+func (m *SubscriptionManager) active(topic string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.topics[topic] > 0
+}
+
+// release decrements the reference count of each topic and returns those that reached zero.
+func (m *SubscriptionManager) release(topics []string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var released []string
+	for _, topic := range dedupe(topics) {
+		count, ok := m.topics[topic]
+		if !ok {
+			continue
+		}
+		if count <= 1 {
+			delete(m.topics, topic)
+			released = append(released, topic)
+			continue
+		}
+		m.topics[topic] = count - 1
+	}
+	return released
+}
+
+// send issues op requests for topics and waits for each acknowledgement. A rejected
+// request returns *OpError.
+func (m *SubscriptionManager) send(ctx context.Context, op string, topics []string) error {
+	for _, args := range m.chunks(topics) {
+		ack, err := m.client.request(ctx, op, args)
+		if err != nil {
+			return err
+		}
+		if !ack.Success {
+			return &OpError{Op: op, Args: args, RetMsg: ack.RetMsg}
+		}
+	}
+	return nil
+}
+
+// chunks splits topics into groups of at most the client's per-request argument limit.
+func (m *SubscriptionManager) chunks(topics []string) [][]string {
+	size := m.client.argsPerRequest()
+	if size <= 0 {
+		size = len(topics)
+	}
+	var out [][]string
+	for start := 0; start < len(topics); start += size {
+		out = append(out, topics[start:min(start+size, len(topics))])
+	}
+	return out
+}
+
+// track records the topics of raw subscribe and unsubscribe requests sent with Client.Send
+// so that they are also replayed after a reconnect.
+func (m *SubscriptionManager) track(message []byte) {
+	var op opMessage
+	if err := json.Unmarshal(message, &op); err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	switch op.Op {
+	case SubscribeOperation:
+		for _, topic := range op.Args {
+			if m.topics[topic] == 0 {
+				m.topics[topic] = 1
+			}
+		}
+	case UnsubscribeOperation:
+		for _, topic := range op.Args {
+			delete(m.topics, topic)
+		}
+	}
+}
+
+// replay re-sends every active topic on the current connection. It runs on the reconnect
+// path and does not wait for acknowledgements.
+func (m *SubscriptionManager) replay(ctx context.Context) error {
+	topics := m.ListSubscriptions()
+	if len(topics) == 0 {
+		return nil
+	}
+	m.client.logger.Info("Resubscribing to %d topics", len(topics))
+	for _, args := range m.chunks(topics) {
+		msg, err := json.Marshal(opMessage{Op: SubscribeOperation, Args: args})
+		if err != nil {
+			return err
+		}
+		if err := m.client.enqueue(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dedupe returns topics without duplicates, preserving the original order.
+func dedupe(topics []string) []string {
+	seen := make(map[string]struct{}, len(topics))
+	out := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		if _, ok := seen[topic]; ok {
+			continue
+		}
+		seen[topic] = struct{}{}
+		out = append(out, topic)
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Message is the envelope shared by Bybit stream messages, with the data payload decoded
+// into T.
+type Message[T any] struct {
+	Topic string `json:"topic"`
+	Type  string `json:"type"`
+	TS    int64  `json:"ts"`
+	CTS   int64  `json:"cts,omitempty"`
+	Data  T      `json:"data"`
+}
+
+// Subscribe subscribes c to topic and calls handler with the data payload of every message,
+// decoded into T. Messages that cannot be decoded are logged and skipped. The returned
+// function removes the handler and unsubscribes.
+func Subscribe[T any](ctx context.Context, c *Client, topic string, handler func(T)) (unsubscribe func() error, err error) {
+	return SubscribeMessage(ctx, c, topic, func(msg Message[T]) {
+		handler(msg.Data)
+	})
+}
+
+// SubscribeChan is like Subscribe but delivers the decoded data payloads on a channel
+// configured by cfg, so a slow consumer is handled by the buffer's overflow policy. The
+// channel is closed by unsubscribe.
+func SubscribeChan[T any](ctx context.Context, c *Client, topic string, cfg BufferConfig) (data <-chan T, unsubscribe func() error, err error) {
+	buffer := NewBuffer[T](cfg)
+	unsubscribe, err = Subscribe(ctx, c, topic, buffer.Push)
+	if err != nil {
+		buffer.Close()
+		return nil, nil, err
+	}
+	return buffer.C(), func() error {
+		defer buffer.Close()
+		return unsubscribe()
+	}, nil
+}
+
+// SubscribeMessage is like Subscribe but passes the whole decoded message, including its
+// type and timestamps, to handler.
+func SubscribeMessage[T any](ctx context.Context, c *Client, topic string, handler func(Message[T])) (unsubscribe func() error, err error) {
+	remove := c.Handle(topic, func(raw []byte) {
+		var msg Message[T]
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			c.logger.Error("Error decoding message on %s: %v", topic, err)
+			return
+		}
+		handler(msg)
+	})
+
+	if err := c.Subscriptions().Subscribe(ctx, topic); err != nil {
+		remove()
+		return nil, err
+	}
+	return func() error {
+		remove()
+		return c.Subscriptions().Unsubscribe(context.Background(), topic)
+	}, nil
+}
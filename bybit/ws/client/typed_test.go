@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe_Typed(t *testing.T) {
+	var connections atomic.Int32
+	srv := newTopicServer(t, &connections)
+	client, err := NewClient(WithURL(wsURLFor(srv)))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages := make(chan Message[map[string]any], 1)
+	unsubscribe, err := SubscribeMessage(ctx, client, "tickers.BTCUSDT", func(msg Message[map[string]any]) {
+		messages <- msg
+	})
+	assert.NoError(t, err)
+
+	select {
+	case msg := <-messages:
+		assert.Equal(t, "tickers.BTCUSDT", msg.Topic)
+		assert.Equal(t, "snapshot", msg.Type)
+		assert.NotNil(t, msg.Data)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the typed message")
+	}
+
+	assert.NoError(t, unsubscribe())
+	assert.Empty(t, client.Subscriptions().ListSubscriptions())
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// StaleAction is what the watchdog does, besides calling OnStale, when a watched topic
+// stops delivering messages.
+type StaleAction int
+
+const (
+	// StaleNotify only calls OnStale.
+	StaleNotify StaleAction = iota
+	// StaleResubscribe resubscribes to the topic so that the server restarts it.
+	StaleResubscribe
+	// StaleReconnect reconnects the client, which replays every subscription.
+	StaleReconnect
+)
+
+// watchdog tracks when each watched topic last received a message.
+type watchdog struct {
+	mu     sync.Mutex
+	topics map[string]*watchedTopic
+}
+
+// watchedTopic is shared by the watches of a topic and removed with the last of them.
+type watchedTopic struct {
+	last     time.Time
+	watchers int
+}
+
+// touch records a message on topic if it is watched.
+func (w *watchdog) touch(topic string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if watched, ok := w.topics[topic]; ok {
+		watched.last = time.Now()
+	}
+}
+
+// add registers a watch of topic and returns its entry.
+func (w *watchdog) add(topic string) *watchedTopic {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.topics == nil {
+		w.topics = make(map[string]*watchedTopic)
+	}
+	watched, ok := w.topics[topic]
+	if !ok {
+		watched = &watchedTopic{last: time.Now()}
+		w.topics[topic] = watched
+	}
+	watched.watchers++
+	return watched
+}
+
+// remove unregisters a watch of topic, dropping the entry once no watch is left.
+func (w *watchdog) remove(topic string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if watched, ok := w.topics[topic]; ok {
+		if watched.watchers--; watched.watchers == 0 {
+			delete(w.topics, topic)
+		}
+	}
+}
+
+// Watch reports topic as stale when no message arrives on it for timeout, even though the
+// connection itself stays healthy. OnStale is called with the silence so far and action is
+// taken; the timer then restarts, so a topic that stays silent is reported again after
+// every timeout. A topic may be watched several times; the returned function stops this
+// watch only.
+func (c *Client) Watch(topic string, timeout time.Duration, action StaleAction) (stop func()) {
+	c.init()
+	watched := c.watchdog.add(topic)
+	stopped := make(chan struct{})
+	go c.watch(topic, watched, timeout, action, stopped)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stopped)
+			c.watchdog.remove(topic)
+		})
+	}
+}
+
+// watch checks topic for silence until stopped or the client is closed.
+func (c *Client) watch(topic string, watched *watchedTopic, timeout time.Duration, action StaleAction, stopped <-chan struct{}) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-stopped:
+			return
+		case <-c.done:
+			return
+		case <-timer.C:
+		}
+
+		c.watchdog.mu.Lock()
+		last := watched.last
+		c.watchdog.mu.Unlock()
+
+		silence := time.Since(last)
+		if silence < timeout {
+			timer.Reset(timeout - silence)
+			continue
+		}
+		c.logger.Warn("No message on %s for %s", topic, silence.Round(time.Millisecond))
+		if c.OnStale != nil {
+			c.OnStale(topic, silence)
+		}
+		c.recoverStale(topic, action)
+
+		c.watchdog.mu.Lock()
+		watched.last = time.Now()
+		c.watchdog.mu.Unlock()
+		timer.Reset(timeout)
+	}
+}
+
+// recoverStale takes action on a stale topic.
+func (c *Client) recoverStale(topic string, action StaleAction) {
+	switch action {
+	case StaleResubscribe:
+		if err := c.Subscriptions().Resubscribe(context.Background(), topic); err != nil {
+			c.logger.Error("Failed to resubscribe to stale topic %s: %v", topic, err)
+		}
+	case StaleReconnect:
+		c.handleReconnection()
+	}
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// WriteTimeout bounds a single write when the caller did not supply a deadline.
+	WriteTimeout = 10 * time.Second
+	// OutboundQueueSize is the capacity of the outbound message queue.
+	OutboundQueueSize = 64
+)
+
+// ErrClientClosed is returned when a message is queued on a client that has been closed.
+var ErrClientClosed = errors.New("websocket client closed")
+
+// outboundMessage is a frame waiting to be written by the writer goroutine.
+type outboundMessage struct {
+	data     []byte
+	deadline time.Time
+	result   chan error
+}
+
+// writer is the only goroutine that writes to the WebSocket connection. gorilla/websocket
+// supports at most one concurrent writer, so pings, auth requests and subscriptions are all
+// funnelled through the outbound queue.
+func (c *Client) writer() {
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.outbound:
+			msg.result <- c.writeFrame(msg.data, msg.deadline)
+		}
+	}
+}
+
+// writeFrame writes a single text frame to the current connection.
+func (c *Client) writeFrame(data []byte, deadline time.Time) error {
+	c.connLock.Lock()
+	conn := c.Conn
+	c.connLock.Unlock()
+
+	if conn == nil {
+		return errors.New("attempt to write on nil connection")
+	}
+	if deadline.IsZero() {
+		deadline = time.Now().Add(WriteTimeout)
+	}
+	if err := conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return err
+	}
+	c.observe(Outbound, data)
+	return nil
+}
+
+// enqueue hands data to the writer goroutine and waits for the write to complete.
+func (c *Client) enqueue(ctx context.Context, data []byte) error {
+	deadline, _ := ctx.Deadline()
+	msg := outboundMessage{
+		data:     data,
+		deadline: deadline,
+		result:   make(chan error, 1),
+	}
+
+	select {
+	case c.outbound <- msg:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return ErrClientClosed
+	}
+
+	select {
+	case err := <-msg.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return ErrClientClosed
+	}
+}
@@ -1,11 +1,106 @@
 package dcp
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+	"fmt"
+	"time"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/trade"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Topic is the DCP topic. Product specific topics are Topic + "." + product.
+const Topic = "dcp"
+
+// Products accepted by New.
+const (
+	ProductFuture = "future"
+	ProductSpot   = "spot"
+	ProductOption = "option"
+)
+
+// Bounds of the DCP time window accepted by Bybit.
+const (
+	MinTimeWindow = 10 * time.Second
+	MaxTimeWindow = 300 * time.Second
+)
+
+// restProducts maps stream products to the product names of the REST endpoint.
+var restProducts = map[string]string{
+	ProductFuture: "DERIVATIVES",
+	ProductSpot:   "SPOT",
+	ProductOption: "OPTIONS",
+}
+
+// Response is a private DCP message.
+type Response struct {
+	ID           string    `json:"id"`
+	Topic        string    `json:"topic"`
+	CreationTime int64     `json:"creationTime"`
+	Data         []DcpData `json:"data"`
+}
+
+// DcpData is the disconnect-cancel-protection status of one product.
+type DcpData struct {
+	Product    string `json:"product"`
+	DcpStatus  string `json:"dcpStatus"`
+	TimeWindow int    `json:"timeWindow"`
+}
+
+// WindowSetter sets the DCP time window over REST. trade.Trade satisfies it.
+type WindowSetter interface {
+	SetDisconnectCancelAll(ctx context.Context, req *trade.SetDisconnectCancelAllRequest) (*trade.APIResponse, error)
+}
+
+// Dcp manages disconnect cancel protection on a private connection. While the connection is
+// subscribed to the DCP topic, Bybit cancels the account's open orders of the product when
+// the connection stays down for longer than the time window. The client's ping loop keeps
+// the connection alive in the meantime.
 type Dcp struct {
-	*client.Client
+	stream  *client.PrivateStream[DcpData]
+	product string
+}
+
+// New creates a Dcp for product on top of the private client cli. An empty product uses the
+// plain DCP topic.
+func New(cli *client.Client, product string) *Dcp {
+	topic := Topic
+	if product != "" {
+		topic = Topic + "." + product
+	}
+	return &Dcp{stream: client.NewPrivateStream[DcpData](cli, topic), product: product}
+}
+
+// Enable sets the DCP time window through setter and subscribes to the DCP topic, which
+// arms the protection for this connection. ctx bounds the REST call. handler may be nil.
+func (d *Dcp) Enable(ctx context.Context, setter WindowSetter, timeWindow time.Duration, handler func(DcpData)) error {
+	if timeWindow < MinTimeWindow || timeWindow > MaxTimeWindow {
+		return fmt.Errorf("DCP time window must be between %s and %s, got %s", MinTimeWindow, MaxTimeWindow, timeWindow)
+	}
+	req := &trade.SetDisconnectCancelAllRequest{TimeWindow: int(timeWindow / time.Second)}
+	if product, ok := restProducts[d.product]; ok {
+		req.Product = &product
+	}
+	if _, err := setter.SetDisconnectCancelAll(ctx, req); err != nil {
+		return fmt.Errorf("failed to set DCP time window: %v", err)
+	}
+	return d.Subscribe(handler)
+}
+
+// Subscribe authenticates the connection if needed and subscribes to DCP status updates.
+// The handler is invoked once per product update and replaces any previous handler.
+func (d *Dcp) Subscribe(handler func(DcpData)) error {
+	return d.stream.Subscribe(context.Background(), handler)
+}
+
+// Unsubscribe unsubscribes from the DCP topic, which disarms the protection for this
+// connection, and removes the handler.
+func (d *Dcp) Unsubscribe() error {
+	return d.stream.Unsubscribe()
 }
 
-func New(cli *client.Client) Dcp {
-	return Dcp{cli}
+// Close unsubscribes from the DCP topic. The client stays open because it may be shared
+// with other services.
+func (d *Dcp) Close() {
+	d.stream.Close()
 }
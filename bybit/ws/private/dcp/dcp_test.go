@@ -0,0 +1,98 @@
+package dcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/trade"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// windowRecorder is a WindowSetter that records the requests it receives.
+type windowRecorder struct {
+	requests []*trade.SetDisconnectCancelAllRequest
+}
+
+func (w *windowRecorder) SetDisconnectCancelAll(_ context.Context, req *trade.SetDisconnectCancelAllRequest) (*trade.APIResponse, error) {
+	w.requests = append(w.requests, req)
+	return &trade.APIResponse{}, nil
+}
+
+// TestDcp_Enable verifies that Enable validates the time window, sets it for the matching
+// REST product and subscribes to the product topic.
+func TestDcp_Enable(t *testing.T) {
+	ops := make(chan string, 4)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID string   `json:"req_id"`
+				Op    string   `json:"op"`
+				Args  []string `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			if req.ReqID == "" {
+				continue
+			}
+			ops <- req.Op
+			ack := fmt.Sprintf(`{"success":true,"ret_msg":"","conn_id":"1","req_id":%q,"op":%q}`, req.ReqID, req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+			if req.Op == "subscribe" {
+				update := fmt.Sprintf(`{"id":"1","topic":%q,"creationTime":1,"data":[{"product":"DERIVATIVES","dcpStatus":"ON","timeWindow":30}]}`, req.Args[0])
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(update)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(
+		client.WithURL("ws"+strings.TrimPrefix(srv.URL, "http")),
+		client.WithCredentials("key", "secret"),
+	)
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	setter := &windowRecorder{}
+	d := New(cli, ProductFuture)
+	assert.Error(t, d.Enable(context.Background(), setter, 5*time.Second, nil))
+	assert.Empty(t, setter.requests)
+
+	updates := make(chan DcpData, 1)
+	assert.NoError(t, d.Enable(context.Background(), setter, 30*time.Second, func(data DcpData) { updates <- data }))
+	if assert.Len(t, setter.requests, 1) {
+		assert.Equal(t, 30, setter.requests[0].TimeWindow)
+		assert.Equal(t, "DERIVATIVES", *setter.requests[0].Product)
+	}
+	assert.Equal(t, "auth", <-ops)
+	assert.Equal(t, "subscribe", <-ops)
+
+	select {
+	case data := <-updates:
+		assert.Equal(t, "ON", data.DcpStatus)
+		assert.Equal(t, 30, data.TimeWindow)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the DCP status")
+	}
+	assert.Equal(t, []string{"dcp.future"}, cli.Subscriptions().ListSubscriptions())
+}
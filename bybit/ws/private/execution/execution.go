@@ -1,11 +1,93 @@
 package execution
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+	"time"
 
+	restclient "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Topic is the all-in-one execution topic. Category specific topics are Topic + "." + category.
+const Topic = "execution"
+
+// Response is a private execution message.
+type Response struct {
+	ID           string          `json:"id"`
+	Topic        string          `json:"topic"`
+	CreationTime int64           `json:"creationTime"`
+	Data         []ExecutionData `json:"data"`
+}
+
+// ExecutionData is a single fill of one of the account's orders. Its numbers are typed like
+// those of the REST trade.Execution; fields Bybit leaves empty decode as zero.
+type ExecutionData struct {
+	Category      string             `json:"category"`
+	Symbol        string             `json:"symbol"`
+	IsLeverage    string             `json:"isLeverage"`
+	OrderID       string             `json:"orderId"`
+	OrderLinkID   string             `json:"orderLinkId"`
+	Side          string             `json:"side"`
+	OrderPrice    restclient.Decimal `json:"orderPrice"`
+	OrderQty      restclient.Decimal `json:"orderQty"`
+	LeavesQty     restclient.Decimal `json:"leavesQty"`
+	CreateType    string             `json:"createType"`
+	OrderType     string             `json:"orderType"`
+	StopOrderType string             `json:"stopOrderType"`
+	ExecFee       restclient.Decimal `json:"execFee"`
+	ExecID        string             `json:"execId"`
+	ExecPrice     restclient.Decimal `json:"execPrice"`
+	ExecQty       restclient.Decimal `json:"execQty"`
+	ExecType      string             `json:"execType"`
+	ExecValue     restclient.Decimal `json:"execValue"`
+	// ExecTime is Unix milliseconds.
+	ExecTime        restclient.Int     `json:"execTime"`
+	IsMaker         bool               `json:"isMaker"`
+	FeeRate         restclient.Decimal `json:"feeRate"`
+	TradeIv         restclient.Decimal `json:"tradeIv"`
+	MarkIv          restclient.Decimal `json:"markIv"`
+	MarkPrice       restclient.Decimal `json:"markPrice"`
+	IndexPrice      restclient.Decimal `json:"indexPrice"`
+	UnderlyingPrice restclient.Decimal `json:"underlyingPrice"`
+	BlockTradeID    string             `json:"blockTradeId"`
+	ClosedSize      restclient.Decimal `json:"closedSize"`
+	Seq             int64              `json:"seq"`
+	MarketUnit      string             `json:"marketUnit"`
+}
+
+// ExecutedAt returns the time of the fill.
+func (e ExecutionData) ExecutedAt() time.Time {
+	return time.UnixMilli(int64(e.ExecTime))
+}
+
+// Execution streams the account's fills over an authenticated private connection.
 type Execution struct {
-	*client.Client
+	stream *client.PrivateStream[ExecutionData]
+}
+
+// New creates an Execution on top of the private client cli. With an empty category it uses
+// the all-in-one topic, otherwise only fills of that category are streamed.
+func New(cli *client.Client, category string) *Execution {
+	topic := Topic
+	if category != "" {
+		topic = Topic + "." + category
+	}
+	return &Execution{stream: client.NewPrivateStream[ExecutionData](cli, topic)}
+}
+
+// Subscribe authenticates the connection if needed and subscribes to executions. The
+// handler is invoked once per fill and replaces any previous handler.
+func (e *Execution) Subscribe(handler func(ExecutionData)) error {
+	return e.stream.Subscribe(context.Background(), handler)
+}
+
+// Unsubscribe unsubscribes from executions and removes the handler.
+func (e *Execution) Unsubscribe() error {
+	return e.stream.Unsubscribe()
 }
 
-func New(cli *client.Client) Execution {
-	return Execution{cli}
+// Close unsubscribes from executions. The client stays open because it may be shared with
+// other services.
+func (e *Execution) Close() {
+	e.stream.Close()
 }
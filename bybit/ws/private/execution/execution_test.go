@@ -0,0 +1,102 @@
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	restclient "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// executionFrame is an execution message as documented by Bybit.
+const executionFrame = `{"id":"592324803b2785-26fa-4214-9963-bdd4727f07be","topic":%q,"creationTime":1672364174455,"data":[{"category":"linear","symbol":"XRPUSDT","execFee":"0.005061","execId":"7e2ae69c-4edf-5800-a352-893d52b446aa","execPrice":"0.3374","execQty":"25","execType":"Trade","execValue":"8.435","isMaker":false,"feeRate":"0.0006","tradeIv":"","markIv":"","blockTradeId":"","markPrice":"0.3391","indexPrice":"","underlyingPrice":"","leavesQty":"0","orderId":"f6e324ff-99c2-4e89-9739-3086e47f9381","orderLinkId":"","orderPrice":"0.3207","orderQty":"25","orderType":"Market","stopOrderType":"UNKNOWN","side":"Sell","execTime":"1672364174443","isLeverage":"0","closedSize":"","seq":4688002127}]}`
+
+// TestResponse_Decode verifies that a recorded execution message decodes into the same
+// numeric types as the REST executions and that empty fields decode as zero.
+func TestResponse_Decode(t *testing.T) {
+	var res Response
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(executionFrame, "execution")), &res))
+	if !assert.Len(t, res.Data, 1) {
+		return
+	}
+	data := res.Data[0]
+	assert.Equal(t, "XRPUSDT", data.Symbol)
+	assert.True(t, restclient.MustParseDecimal("0.3374").Equal(data.ExecPrice))
+	assert.True(t, restclient.MustParseDecimal("25").Equal(data.ExecQty))
+	assert.True(t, restclient.MustParseDecimal("0.005061").Equal(data.ExecFee))
+	assert.True(t, restclient.MustParseDecimal("8.435").Equal(data.ExecValue))
+	assert.True(t, data.ExecPrice.Mul(data.ExecQty).Equal(data.ExecValue))
+	assert.True(t, data.TradeIv.IsZero())
+	assert.True(t, data.ClosedSize.IsZero())
+	assert.Equal(t, restclient.Int(1672364174443), data.ExecTime)
+	assert.Equal(t, time.UnixMilli(1672364174443), data.ExecutedAt())
+	assert.Equal(t, int64(4688002127), data.Seq)
+}
+
+// TestExecution_Subscribe verifies that the stream subscribes to the category topic and
+// passes every fill of a recorded message to the handler.
+func TestExecution_Subscribe(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID string   `json:"req_id"`
+				Op    string   `json:"op"`
+				Args  []string `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			if req.ReqID == "" {
+				continue
+			}
+			ack := fmt.Sprintf(`{"success":true,"ret_msg":"","conn_id":"1","req_id":%q,"op":%q}`, req.ReqID, req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+			if req.Op == "subscribe" {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(executionFrame, req.Args[0]))); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(
+		client.WithURL("ws"+strings.TrimPrefix(srv.URL, "http")),
+		client.WithCredentials("key", "secret"),
+	)
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	updates := make(chan ExecutionData, 1)
+	e := New(cli, "linear")
+	assert.NoError(t, e.Subscribe(func(data ExecutionData) { updates <- data }))
+	assert.Equal(t, []string{"execution.linear"}, cli.Subscriptions().ListSubscriptions())
+
+	select {
+	case data := <-updates:
+		assert.Equal(t, "7e2ae69c-4edf-5800-a352-893d52b446aa", data.ExecID)
+		assert.True(t, restclient.MustParseDecimal("0.3374").Equal(data.ExecPrice))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the execution")
+	}
+
+	assert.NoError(t, e.Unsubscribe())
+	assert.Empty(t, cli.Subscriptions().ListSubscriptions())
+}
@@ -0,0 +1,77 @@
+package fastexecution
+
+import (
+	"context"
+	"time"
+
+	restclient "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Topic is the all-in-one fast execution topic. Category specific topics are Topic + "." +
+// category.
+const Topic = "execution.fast"
+
+// Response is a private fast execution message.
+type Response struct {
+	ID           string          `json:"id"`
+	Topic        string          `json:"topic"`
+	CreationTime int64           `json:"creationTime"`
+	Data         []ExecutionData `json:"data"`
+}
+
+// ExecutionData is the reduced payload of a single fill. Its numbers are typed like those of
+// the execution stream.
+type ExecutionData struct {
+	Category    string             `json:"category"`
+	Symbol      string             `json:"symbol"`
+	ExecID      string             `json:"execId"`
+	ExecPrice   restclient.Decimal `json:"execPrice"`
+	ExecQty     restclient.Decimal `json:"execQty"`
+	OrderID     string             `json:"orderId"`
+	IsMaker     bool               `json:"isMaker"`
+	OrderLinkID string             `json:"orderLinkId"`
+	Side        string             `json:"side"`
+	// ExecTime is Unix milliseconds.
+	ExecTime restclient.Int `json:"execTime"`
+	Seq      int64          `json:"seq"`
+}
+
+// ExecutedAt returns the time of the fill.
+func (e ExecutionData) ExecutedAt() time.Time {
+	return time.UnixMilli(int64(e.ExecTime))
+}
+
+// FastExecution streams the account's fills with a reduced payload and lower latency than
+// the execution stream. It carries no fees or order details; use the execution stream when
+// those are needed.
+type FastExecution struct {
+	stream *client.PrivateStream[ExecutionData]
+}
+
+// New creates a FastExecution on top of the private client cli. With an empty category it
+// uses the all-in-one topic, otherwise only fills of that category are streamed.
+func New(cli *client.Client, category string) *FastExecution {
+	topic := Topic
+	if category != "" {
+		topic = Topic + "." + category
+	}
+	return &FastExecution{stream: client.NewPrivateStream[ExecutionData](cli, topic)}
+}
+
+// Subscribe authenticates the connection if needed and subscribes to fast executions. The
+// handler is invoked once per fill and replaces any previous handler.
+func (e *FastExecution) Subscribe(handler func(ExecutionData)) error {
+	return e.stream.Subscribe(context.Background(), handler)
+}
+
+// Unsubscribe unsubscribes from fast executions and removes the handler.
+func (e *FastExecution) Unsubscribe() error {
+	return e.stream.Unsubscribe()
+}
+
+// Close unsubscribes from fast executions. The client stays open because it may be shared
+// with other services.
+func (e *FastExecution) Close() {
+	e.stream.Close()
+}
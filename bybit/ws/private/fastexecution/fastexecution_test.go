@@ -0,0 +1,97 @@
+package fastexecution
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	restclient "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// fastExecutionFrame is a fast execution message as documented by Bybit.
+const fastExecutionFrame = `{"topic":%q,"creationTime":1716800399338,"data":[{"category":"linear","symbol":"ICPUSDT","execId":"3510f361-0add-5c7b-a2e7-9679810944fc","execPrice":"12.015","execQty":"3000","orderId":"443d63fa-b4c3-4297-b7b1-23bca88b04dc","isMaker":false,"orderLinkId":"test-00001","side":"Sell","execTime":"1716800399334","seq":34771365464}]}`
+
+// TestResponse_Decode verifies that a recorded fast execution message decodes into the
+// numeric types of the execution stream.
+func TestResponse_Decode(t *testing.T) {
+	var res Response
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(fastExecutionFrame, Topic)), &res))
+	if !assert.Len(t, res.Data, 1) {
+		return
+	}
+	data := res.Data[0]
+	assert.Equal(t, "ICPUSDT", data.Symbol)
+	assert.Equal(t, "test-00001", data.OrderLinkID)
+	assert.True(t, restclient.MustParseDecimal("12.015").Equal(data.ExecPrice))
+	assert.True(t, restclient.MustParseDecimal("3000").Equal(data.ExecQty))
+	assert.Equal(t, time.UnixMilli(1716800399334), data.ExecutedAt())
+	assert.Equal(t, int64(34771365464), data.Seq)
+}
+
+// TestFastExecution_Subscribe verifies that the stream subscribes to the category topic and
+// passes every fill of a recorded message to the handler.
+func TestFastExecution_Subscribe(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID string   `json:"req_id"`
+				Op    string   `json:"op"`
+				Args  []string `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			if req.ReqID == "" {
+				continue
+			}
+			ack := fmt.Sprintf(`{"success":true,"ret_msg":"","conn_id":"1","req_id":%q,"op":%q}`, req.ReqID, req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+			if req.Op == "subscribe" {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(fastExecutionFrame, req.Args[0]))); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(
+		client.WithURL("ws"+strings.TrimPrefix(srv.URL, "http")),
+		client.WithCredentials("key", "secret"),
+	)
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	updates := make(chan ExecutionData, 1)
+	e := New(cli, "linear")
+	assert.NoError(t, e.Subscribe(func(data ExecutionData) { updates <- data }))
+	assert.Equal(t, []string{"execution.fast.linear"}, cli.Subscriptions().ListSubscriptions())
+
+	select {
+	case data := <-updates:
+		assert.Equal(t, "3510f361-0add-5c7b-a2e7-9679810944fc", data.ExecID)
+		assert.Equal(t, "Sell", data.Side)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the fast execution")
+	}
+
+	assert.NoError(t, e.Unsubscribe())
+	assert.Empty(t, cli.Subscriptions().ListSubscriptions())
+}
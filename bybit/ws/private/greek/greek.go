@@ -1,11 +1,72 @@
 package greek
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Topic is the greeks topic. It carries every base coin; Subscribe filters them locally.
+const Topic = "greeks"
+
+// Response is a private greeks message.
+type Response struct {
+	ID           string      `json:"id"`
+	Topic        string      `json:"topic"`
+	CreationTime int64       `json:"creationTime"`
+	Data         []GreekData `json:"data"`
+}
+
+// GreekData is the aggregated greeks of the account's options on one base coin.
+type GreekData struct {
+	BaseCoin   string `json:"baseCoin"`
+	TotalDelta string `json:"totalDelta"`
+	TotalGamma string `json:"totalGamma"`
+	TotalVega  string `json:"totalVega"`
+	TotalTheta string `json:"totalTheta"`
+}
+
+// Greek streams the option greeks of the account over an authenticated private connection.
 type Greek struct {
-	*client.Client
+	stream *client.PrivateStream[GreekData]
+}
+
+// New creates a Greek on top of the private client cli.
+func New(cli *client.Client) *Greek {
+	return &Greek{stream: client.NewPrivateStream[GreekData](cli, Topic)}
+}
+
+// Subscribe authenticates the connection if needed and subscribes to greeks updates. The
+// handler is invoked once per updated base coin in baseCoins, or for every base coin when
+// none are given, and replaces any previous handler and filter.
+func (g *Greek) Subscribe(handler func(GreekData), baseCoins ...string) error {
+	return g.stream.Subscribe(context.Background(), filterBaseCoins(handler, baseCoins))
+}
+
+// Unsubscribe unsubscribes from greeks updates and removes the handler.
+func (g *Greek) Unsubscribe() error {
+	return g.stream.Unsubscribe()
+}
+
+// Close unsubscribes from greeks updates. The client stays open because it may be shared
+// with other services.
+func (g *Greek) Close() {
+	g.stream.Close()
 }
 
-func New(cli *client.Client) Greek {
-	return Greek{cli}
+// filterBaseCoins wraps handler to receive only the greeks of baseCoins, or of every base
+// coin when none are given.
+func filterBaseCoins(handler func(GreekData), baseCoins []string) func(GreekData) {
+	if len(baseCoins) == 0 {
+		return handler
+	}
+	wanted := make(map[string]bool, len(baseCoins))
+	for _, coin := range baseCoins {
+		wanted[coin] = true
+	}
+	return func(data GreekData) {
+		if wanted[data.BaseCoin] {
+			handler(data)
+		}
+	}
 }
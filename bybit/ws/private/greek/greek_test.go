@@ -0,0 +1,32 @@
+package greek
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGreek_BaseCoinFilter verifies that only the base coins passed to Subscribe reach the
+// handler.
+func TestGreek_BaseCoinFilter(t *testing.T) {
+	var resp Response
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"1","topic":"greeks","creationTime":1,"data":[`+
+		`{"baseCoin":"BTC","totalDelta":"0.1","totalGamma":"0","totalVega":"0","totalTheta":"0"},`+
+		`{"baseCoin":"ETH","totalDelta":"-0.2","totalGamma":"0","totalVega":"0","totalTheta":"0"}]}`), &resp))
+
+	var received []string
+	handler := filterBaseCoins(func(data GreekData) { received = append(received, data.BaseCoin) }, []string{"ETH"})
+	for _, data := range resp.Data {
+		handler(data)
+	}
+	assert.Equal(t, []string{"ETH"}, received)
+
+	received = nil
+	handler = filterBaseCoins(func(data GreekData) { received = append(received, data.BaseCoin) }, nil)
+	for _, data := range resp.Data {
+		handler(data)
+	}
+	assert.Equal(t, []string{"BTC", "ETH"}, received)
+}
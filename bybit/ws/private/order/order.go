@@ -1,11 +1,101 @@
 package order
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Topic is the all-in-one order topic. Category specific topics are Topic + "." + category.
+const Topic = "order"
+
+// Response is a private order message.
+type Response struct {
+	ID           string      `json:"id"`
+	Topic        string      `json:"topic"`
+	CreationTime int64       `json:"creationTime"`
+	Data         []OrderData `json:"data"`
+}
+
+// OrderData is the state of a single order after a change.
+type OrderData struct {
+	Category           string `json:"category"`
+	OrderID            string `json:"orderId"`
+	OrderLinkID        string `json:"orderLinkId"`
+	IsLeverage         string `json:"isLeverage"`
+	BlockTradeID       string `json:"blockTradeId"`
+	Symbol             string `json:"symbol"`
+	Price              string `json:"price"`
+	Qty                string `json:"qty"`
+	Side               string `json:"side"`
+	PositionIdx        int    `json:"positionIdx"`
+	OrderStatus        string `json:"orderStatus"`
+	CreateType         string `json:"createType"`
+	CancelType         string `json:"cancelType"`
+	RejectReason       string `json:"rejectReason"`
+	AvgPrice           string `json:"avgPrice"`
+	LeavesQty          string `json:"leavesQty"`
+	LeavesValue        string `json:"leavesValue"`
+	CumExecQty         string `json:"cumExecQty"`
+	CumExecValue       string `json:"cumExecValue"`
+	CumExecFee         string `json:"cumExecFee"`
+	ClosedPnl          string `json:"closedPnl"`
+	FeeCurrency        string `json:"feeCurrency"`
+	TimeInForce        string `json:"timeInForce"`
+	OrderType          string `json:"orderType"`
+	StopOrderType      string `json:"stopOrderType"`
+	OcoTriggerBy       string `json:"ocoTriggerBy"`
+	OrderIv            string `json:"orderIv"`
+	MarketUnit         string `json:"marketUnit"`
+	TriggerPrice       string `json:"triggerPrice"`
+	TakeProfit         string `json:"takeProfit"`
+	StopLoss           string `json:"stopLoss"`
+	TpslMode           string `json:"tpslMode"`
+	TpLimitPrice       string `json:"tpLimitPrice"`
+	SlLimitPrice       string `json:"slLimitPrice"`
+	TpTriggerBy        string `json:"tpTriggerBy"`
+	SlTriggerBy        string `json:"slTriggerBy"`
+	TriggerDirection   int    `json:"triggerDirection"`
+	TriggerBy          string `json:"triggerBy"`
+	LastPriceOnCreated string `json:"lastPriceOnCreated"`
+	ReduceOnly         bool   `json:"reduceOnly"`
+	CloseOnTrigger     bool   `json:"closeOnTrigger"`
+	PlaceType          string `json:"placeType"`
+	SmpType            string `json:"smpType"`
+	SmpGroup           int    `json:"smpGroup"`
+	SmpOrderID         string `json:"smpOrderId"`
+	CreatedTime        string `json:"createdTime"`
+	UpdatedTime        string `json:"updatedTime"`
+}
+
+// Order streams updates of the account's orders over an authenticated private connection.
 type Order struct {
-	*client.Client
+	stream *client.PrivateStream[OrderData]
+}
+
+// New creates an Order on top of the private client cli. With an empty category it uses the
+// all-in-one topic, otherwise only orders of that category are streamed.
+func New(cli *client.Client, category string) *Order {
+	topic := Topic
+	if category != "" {
+		topic = Topic + "." + category
+	}
+	return &Order{stream: client.NewPrivateStream[OrderData](cli, topic)}
+}
+
+// Subscribe authenticates the connection if needed and subscribes to order updates. The
+// handler is invoked once per changed order and replaces any previous handler.
+func (o *Order) Subscribe(handler func(OrderData)) error {
+	return o.stream.Subscribe(context.Background(), handler)
+}
+
+// Unsubscribe unsubscribes from order updates and removes the handler.
+func (o *Order) Unsubscribe() error {
+	return o.stream.Unsubscribe()
 }
 
-func New(cli *client.Client) Order {
-	return Order{cli}
+// Close unsubscribes from order updates. The client stays open because it may be shared
+// with other services.
+func (o *Order) Close() {
+	o.stream.Close()
 }
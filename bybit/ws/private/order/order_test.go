@@ -0,0 +1,82 @@
+package order
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOrder_Subscribe verifies that the stream authenticates before subscribing to the
+// category topic and decodes order updates.
+func TestOrder_Subscribe(t *testing.T) {
+	ops := make(chan string, 4)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID string   `json:"req_id"`
+				Op    string   `json:"op"`
+				Args  []string `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			if req.ReqID == "" {
+				continue
+			}
+			ops <- req.Op
+			ack := fmt.Sprintf(`{"success":true,"ret_msg":"","conn_id":"1","req_id":%q,"op":%q}`, req.ReqID, req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+			if req.Op == "subscribe" {
+				update := fmt.Sprintf(`{"id":"1","topic":%q,"creationTime":1,"data":[{"category":"linear","orderId":"o-1","symbol":"BTCUSDT","side":"Buy","orderStatus":"Filled","cumExecQty":"0.01","positionIdx":0,"reduceOnly":false}]}`, req.Args[0])
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(update)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(
+		client.WithURL("ws"+strings.TrimPrefix(srv.URL, "http")),
+		client.WithCredentials("key", "secret"),
+	)
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	updates := make(chan OrderData, 1)
+	o := New(cli, "linear")
+	assert.NoError(t, o.Subscribe(func(data OrderData) { updates <- data }))
+	assert.Equal(t, "auth", <-ops)
+	assert.Equal(t, "subscribe", <-ops)
+
+	select {
+	case data := <-updates:
+		assert.Equal(t, "o-1", data.OrderID)
+		assert.Equal(t, "Filled", data.OrderStatus)
+		assert.Equal(t, "0.01", data.CumExecQty)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the order update")
+	}
+	assert.Equal(t, []string{"order.linear"}, cli.Subscriptions().ListSubscriptions())
+
+	assert.NoError(t, o.Unsubscribe())
+	assert.Empty(t, cli.Subscriptions().ListSubscriptions())
+}
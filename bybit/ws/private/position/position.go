@@ -1,11 +1,145 @@
 package position
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+	"sync"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Topic is the all-in-one position topic. Category specific topics are Topic + "." + category.
+const Topic = "position"
+
+// Response is a private position message.
+type Response struct {
+	ID           string         `json:"id"`
+	Topic        string         `json:"topic"`
+	CreationTime int64          `json:"creationTime"`
+	Data         []PositionData `json:"data"`
+}
+
+// PositionData is the state of a single position after a change.
+type PositionData struct {
+	Category               string `json:"category"`
+	Symbol                 string `json:"symbol"`
+	Side                   string `json:"side"`
+	Size                   string `json:"size"`
+	PositionIdx            int    `json:"positionIdx"`
+	TradeMode              int    `json:"tradeMode"`
+	PositionValue          string `json:"positionValue"`
+	RiskID                 int    `json:"riskId"`
+	RiskLimitValue         string `json:"riskLimitValue"`
+	EntryPrice             string `json:"entryPrice"`
+	MarkPrice              string `json:"markPrice"`
+	Leverage               string `json:"leverage"`
+	PositionBalance        string `json:"positionBalance"`
+	AutoAddMargin          int    `json:"autoAddMargin"`
+	PositionIM             string `json:"positionIM"`
+	PositionMM             string `json:"positionMM"`
+	LiqPrice               string `json:"liqPrice"`
+	BustPrice              string `json:"bustPrice"`
+	TpslMode               string `json:"tpslMode"`
+	TakeProfit             string `json:"takeProfit"`
+	StopLoss               string `json:"stopLoss"`
+	TrailingStop           string `json:"trailingStop"`
+	UnrealisedPnl          string `json:"unrealisedPnl"`
+	CurRealisedPnl         string `json:"curRealisedPnl"`
+	CumRealisedPnl         string `json:"cumRealisedPnl"`
+	SessionAvgPrice        string `json:"sessionAvgPrice"`
+	Delta                  string `json:"delta"`
+	Gamma                  string `json:"gamma"`
+	Vega                   string `json:"vega"`
+	Theta                  string `json:"theta"`
+	PositionStatus         string `json:"positionStatus"`
+	AdlRankIndicator       int    `json:"adlRankIndicator"`
+	IsReduceOnly           bool   `json:"isReduceOnly"`
+	MmrSysUpdatedTime      string `json:"mmrSysUpdatedTime"`
+	LeverageSysUpdatedTime string `json:"leverageSysUpdatedTime"`
+	CreatedTime            string `json:"createdTime"`
+	UpdatedTime            string `json:"updatedTime"`
+	Seq                    int64  `json:"seq"`
+}
+
+// Position streams updates of the account's positions over an authenticated private
+// connection, either to a callback or to a channel.
 type Position struct {
-	*client.Client
+	stream *client.PrivateStream[PositionData]
+
+	mu      sync.Mutex
+	updates *client.Buffer[PositionData]
+}
+
+// New creates a Position on top of the private client cli. With an empty category it uses
+// the all-in-one topic, otherwise only positions of that category are streamed.
+func New(cli *client.Client, category string) *Position {
+	topic := Topic
+	if category != "" {
+		topic = Topic + "." + category
+	}
+	return &Position{stream: client.NewPrivateStream[PositionData](cli, topic)}
+}
+
+// Subscribe authenticates the connection if needed and subscribes to position updates. The
+// handler is invoked once per changed position and replaces any previous handler or
+// channel.
+func (p *Position) Subscribe(handler func(PositionData)) error {
+	return p.subscribe(handler, nil)
+}
+
+// Updates subscribes like Subscribe but delivers position updates on a channel configured
+// by cfg. client.DropOldest or client.CoalesceLatest let a slow reader see the latest state
+// rather than stalling the connection. The channel is closed by Unsubscribe and Close.
+//
+// A Policy left unset, which is client.DropNewest, is replaced by client.DropOldest:
+// dropping the newest updates would leave a slow reader holding stale positions.
+func (p *Position) Updates(cfg client.BufferConfig) (<-chan PositionData, error) {
+	updates := client.NewBuffer[PositionData](updatesConfig(cfg))
+	if err := p.subscribe(updates.Push, updates); err != nil {
+		updates.Close()
+		return nil, err
+	}
+	return updates.C(), nil
+}
+
+// updatesConfig replaces the DropNewest policy of cfg by DropOldest.
+func updatesConfig(cfg client.BufferConfig) client.BufferConfig {
+	if cfg.Policy == client.DropNewest {
+		cfg.Policy = client.DropOldest
+	}
+	return cfg
+}
+
+// subscribe installs handler, and the channel it feeds if any, closing the channel it
+// replaces.
+func (p *Position) subscribe(handler func(PositionData), updates *client.Buffer[PositionData]) error {
+	if err := p.stream.Subscribe(context.Background(), handler); err != nil {
+		return err
+	}
+	p.swapUpdates(updates)
+	return nil
+}
+
+// Unsubscribe unsubscribes from position updates, removes the handler and closes the
+// channel returned by Updates.
+func (p *Position) Unsubscribe() error {
+	defer p.swapUpdates(nil)
+	return p.stream.Unsubscribe()
+}
+
+// Close unsubscribes from position updates. The client stays open because it may be shared
+// with other services.
+func (p *Position) Close() {
+	defer p.swapUpdates(nil)
+	p.stream.Close()
 }
 
-func New(cli *client.Client) Position {
-	return Position{cli}
+// swapUpdates replaces the channel fed by the handler and closes the previous one.
+func (p *Position) swapUpdates(updates *client.Buffer[PositionData]) {
+	p.mu.Lock()
+	previous := p.updates
+	p.updates = updates
+	p.mu.Unlock()
+	if previous != nil && previous != updates {
+		previous.Close()
+	}
 }
@@ -0,0 +1,96 @@
+package position
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPosition_Updates verifies that position updates are delivered on the channel and that
+// the channel is closed on Unsubscribe.
+func TestPosition_Updates(t *testing.T) {
+	ops := make(chan string, 4)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID string   `json:"req_id"`
+				Op    string   `json:"op"`
+				Args  []string `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			if req.ReqID == "" {
+				continue
+			}
+			ops <- req.Op
+			ack := fmt.Sprintf(`{"success":true,"ret_msg":"","conn_id":"1","req_id":%q,"op":%q}`, req.ReqID, req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+			if req.Op == "subscribe" {
+				update := fmt.Sprintf(`{"id":"1","topic":%q,"creationTime":1,"data":[{"category":"linear","symbol":"BTCUSDT","side":"Buy","size":"0.01","entryPrice":"30000","liqPrice":"15000","unrealisedPnl":"1.5","positionIdx":0,"seq":7}]}`, req.Args[0])
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(update)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(
+		client.WithURL("ws"+strings.TrimPrefix(srv.URL, "http")),
+		client.WithCredentials("key", "secret"),
+	)
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	p := New(cli, "linear")
+	updates, err := p.Updates(client.BufferConfig{Size: 4, Policy: client.DropOldest})
+	assert.NoError(t, err)
+	assert.Equal(t, "auth", <-ops)
+	assert.Equal(t, "subscribe", <-ops)
+
+	select {
+	case data := <-updates:
+		assert.Equal(t, "BTCUSDT", data.Symbol)
+		assert.Equal(t, "0.01", data.Size)
+		assert.Equal(t, "15000", data.LiqPrice)
+		assert.Equal(t, int64(7), data.Seq)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the position update")
+	}
+
+	assert.NoError(t, p.Unsubscribe())
+	_, open := <-updates
+	assert.False(t, open)
+}
+
+// TestPosition_UpdatesDefaultPolicy verifies that an unset policy keeps the newest updates.
+func TestPosition_UpdatesDefaultPolicy(t *testing.T) {
+	cfg := updatesConfig(client.BufferConfig{Size: 1})
+	assert.Equal(t, client.DropOldest, cfg.Policy)
+	assert.Equal(t, client.CoalesceLatest, updatesConfig(client.BufferConfig{Policy: client.CoalesceLatest}).Policy)
+
+	updates := client.NewBuffer[PositionData](cfg)
+	defer updates.Close()
+	updates.Push(PositionData{Seq: 1})
+	updates.Push(PositionData{Seq: 2})
+	assert.Equal(t, int64(2), (<-updates.C()).Seq)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private/dcp"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private/execution"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private/fastexecution"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private/greek"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private/order"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/private/position"
@@ -11,12 +12,13 @@ import (
 )
 
 type Private interface {
-	Dcp(category string) dcp.Dcp
-	Execution(category string) execution.Execution
-	Greek(category string) greek.Greek
-	Order(category string) order.Order
-	Position(category string) position.Position
-	Wallet(category string) wallet.Wallet
+	Dcp(product string) *dcp.Dcp
+	Execution(category string) *execution.Execution
+	FastExecution(category string) *fastexecution.FastExecution
+	Greek() *greek.Greek
+	Order(category string) *order.Order
+	Position(category string) *position.Position
+	Wallet() *wallet.Wallet
 }
 
 type implPrivate struct {
@@ -24,52 +26,40 @@ type implPrivate struct {
 	isTest bool
 }
 
-func (i *implPrivate) Dcp(category string) dcp.Dcp {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return dcp.New(cli)
+// Dcp returns the disconnect cancel protection of product on the shared private connection.
+func (i *implPrivate) Dcp(product string) *dcp.Dcp {
+	return dcp.New(i.client, product)
 }
 
-func (i *implPrivate) Execution(category string) execution.Execution {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return execution.New(cli)
+// Execution returns an execution stream of category on the shared private connection.
+func (i *implPrivate) Execution(category string) *execution.Execution {
+	return execution.New(i.client, category)
 }
 
-func (i *implPrivate) Greek(category string) greek.Greek {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return greek.New(cli)
+// FastExecution returns a low-latency execution stream of category on the shared private
+// connection.
+func (i *implPrivate) FastExecution(category string) *fastexecution.FastExecution {
+	return fastexecution.New(i.client, category)
 }
 
-func (i *implPrivate) Order(category string) order.Order {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return order.New(cli)
+// Greek returns the option greeks stream on the shared private connection.
+func (i *implPrivate) Greek() *greek.Greek {
+	return greek.New(i.client)
 }
 
-func (i *implPrivate) Position(category string) position.Position {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return position.New(cli)
+// Order returns an order stream of category on the shared private connection.
+func (i *implPrivate) Order(category string) *order.Order {
+	return order.New(i.client, category)
 }
 
-func (i *implPrivate) Wallet(category string) wallet.Wallet {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return wallet.New(cli)
+// Position returns a position stream of category on the shared private connection.
+func (i *implPrivate) Position(category string) *position.Position {
+	return position.New(i.client, category)
+}
+
+// Wallet returns the wallet stream on the shared private connection.
+func (i *implPrivate) Wallet() *wallet.Wallet {
+	return wallet.New(i.client)
 }
 
 func (i *implPrivate) SetClient(client_ *client.Client) Private {
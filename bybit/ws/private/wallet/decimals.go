@@ -0,0 +1,142 @@
+package wallet
+
+import restclient "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+
+// Wallet updates keep Bybit's decimal strings, like the REST account responses. The
+// accessors below decode them into restclient.Decimal for callers that need exact
+// arithmetic.
+
+// decimal parses an amount. The empty string Bybit sends for unset amounts, and any
+// malformed value, read as zero.
+func decimal(s string) restclient.Decimal {
+	d, err := restclient.ParseDecimal(s)
+	if err != nil {
+		return restclient.Decimal{}
+	}
+	return d
+}
+
+// AccountIMRateDecimal returns AccountIMRate as a restclient.Decimal.
+func (w WalletData) AccountIMRateDecimal() restclient.Decimal {
+	return decimal(w.AccountIMRate)
+}
+
+// AccountMMRateDecimal returns AccountMMRate as a restclient.Decimal.
+func (w WalletData) AccountMMRateDecimal() restclient.Decimal {
+	return decimal(w.AccountMMRate)
+}
+
+// AccountLTVDecimal returns AccountLTV as a restclient.Decimal.
+func (w WalletData) AccountLTVDecimal() restclient.Decimal {
+	return decimal(w.AccountLTV)
+}
+
+// TotalEquityDecimal returns TotalEquity as a restclient.Decimal.
+func (w WalletData) TotalEquityDecimal() restclient.Decimal {
+	return decimal(w.TotalEquity)
+}
+
+// TotalWalletBalanceDecimal returns TotalWalletBalance as a restclient.Decimal.
+func (w WalletData) TotalWalletBalanceDecimal() restclient.Decimal {
+	return decimal(w.TotalWalletBalance)
+}
+
+// TotalMarginBalanceDecimal returns TotalMarginBalance as a restclient.Decimal.
+func (w WalletData) TotalMarginBalanceDecimal() restclient.Decimal {
+	return decimal(w.TotalMarginBalance)
+}
+
+// TotalAvailableBalanceDecimal returns TotalAvailableBalance as a restclient.Decimal.
+func (w WalletData) TotalAvailableBalanceDecimal() restclient.Decimal {
+	return decimal(w.TotalAvailableBalance)
+}
+
+// TotalPerpUPLDecimal returns TotalPerpUPL as a restclient.Decimal.
+func (w WalletData) TotalPerpUPLDecimal() restclient.Decimal {
+	return decimal(w.TotalPerpUPL)
+}
+
+// TotalInitialMarginDecimal returns TotalInitialMargin as a restclient.Decimal.
+func (w WalletData) TotalInitialMarginDecimal() restclient.Decimal {
+	return decimal(w.TotalInitialMargin)
+}
+
+// TotalMaintenanceMarginDecimal returns TotalMaintenanceMargin as a restclient.Decimal.
+func (w WalletData) TotalMaintenanceMarginDecimal() restclient.Decimal {
+	return decimal(w.TotalMaintenanceMargin)
+}
+
+// EquityDecimal returns Equity as a restclient.Decimal.
+func (c Coin) EquityDecimal() restclient.Decimal {
+	return decimal(c.Equity)
+}
+
+// UsdValueDecimal returns UsdValue as a restclient.Decimal.
+func (c Coin) UsdValueDecimal() restclient.Decimal {
+	return decimal(c.UsdValue)
+}
+
+// WalletBalanceDecimal returns WalletBalance as a restclient.Decimal.
+func (c Coin) WalletBalanceDecimal() restclient.Decimal {
+	return decimal(c.WalletBalance)
+}
+
+// AvailableToWithdrawDecimal returns AvailableToWithdraw as a restclient.Decimal.
+func (c Coin) AvailableToWithdrawDecimal() restclient.Decimal {
+	return decimal(c.AvailableToWithdraw)
+}
+
+// AvailableToBorrowDecimal returns AvailableToBorrow as a restclient.Decimal.
+func (c Coin) AvailableToBorrowDecimal() restclient.Decimal {
+	return decimal(c.AvailableToBorrow)
+}
+
+// BorrowAmountDecimal returns BorrowAmount as a restclient.Decimal.
+func (c Coin) BorrowAmountDecimal() restclient.Decimal {
+	return decimal(c.BorrowAmount)
+}
+
+// AccruedInterestDecimal returns AccruedInterest as a restclient.Decimal.
+func (c Coin) AccruedInterestDecimal() restclient.Decimal {
+	return decimal(c.AccruedInterest)
+}
+
+// TotalOrderIMDecimal returns TotalOrderIM as a restclient.Decimal.
+func (c Coin) TotalOrderIMDecimal() restclient.Decimal {
+	return decimal(c.TotalOrderIM)
+}
+
+// TotalPositionIMDecimal returns TotalPositionIM as a restclient.Decimal.
+func (c Coin) TotalPositionIMDecimal() restclient.Decimal {
+	return decimal(c.TotalPositionIM)
+}
+
+// TotalPositionMMDecimal returns TotalPositionMM as a restclient.Decimal.
+func (c Coin) TotalPositionMMDecimal() restclient.Decimal {
+	return decimal(c.TotalPositionMM)
+}
+
+// UnrealisedPnlDecimal returns UnrealisedPnl as a restclient.Decimal.
+func (c Coin) UnrealisedPnlDecimal() restclient.Decimal {
+	return decimal(c.UnrealisedPnl)
+}
+
+// CumRealisedPnlDecimal returns CumRealisedPnl as a restclient.Decimal.
+func (c Coin) CumRealisedPnlDecimal() restclient.Decimal {
+	return decimal(c.CumRealisedPnl)
+}
+
+// BonusDecimal returns Bonus as a restclient.Decimal.
+func (c Coin) BonusDecimal() restclient.Decimal {
+	return decimal(c.Bonus)
+}
+
+// LockedDecimal returns Locked as a restclient.Decimal.
+func (c Coin) LockedDecimal() restclient.Decimal {
+	return decimal(c.Locked)
+}
+
+// SpotHedgingQtyDecimal returns SpotHedgingQty as a restclient.Decimal.
+func (c Coin) SpotHedgingQtyDecimal() restclient.Decimal {
+	return decimal(c.SpotHedgingQty)
+}
@@ -1,11 +1,86 @@
 package wallet
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Topic is the wallet topic. Wallet updates are not split by category.
+const Topic = "wallet"
+
+// Response is a private wallet message.
+type Response struct {
+	ID           string       `json:"id"`
+	Topic        string       `json:"topic"`
+	CreationTime int64        `json:"creationTime"`
+	Data         []WalletData `json:"data"`
+}
+
+// WalletData is the state of the account's wallet after a change. Amounts are Bybit's
+// decimal strings, which are empty when unset; the Decimal accessors parse them.
+type WalletData struct {
+	AccountType            string `json:"accountType"`
+	AccountIMRate          string `json:"accountIMRate"`
+	AccountMMRate          string `json:"accountMMRate"`
+	AccountLTV             string `json:"accountLTV"`
+	TotalEquity            string `json:"totalEquity"`
+	TotalWalletBalance     string `json:"totalWalletBalance"`
+	TotalMarginBalance     string `json:"totalMarginBalance"`
+	TotalAvailableBalance  string `json:"totalAvailableBalance"`
+	TotalPerpUPL           string `json:"totalPerpUPL"`
+	TotalInitialMargin     string `json:"totalInitialMargin"`
+	TotalMaintenanceMargin string `json:"totalMaintenanceMargin"`
+	Coin                   []Coin `json:"coin"`
+}
+
+// Coin is the balance of a single coin in the wallet. Amounts are Bybit's decimal
+// strings, which are empty when unset; the Decimal accessors parse them.
+type Coin struct {
+	Coin                string `json:"coin"`
+	Equity              string `json:"equity"`
+	UsdValue            string `json:"usdValue"`
+	WalletBalance       string `json:"walletBalance"`
+	AvailableToWithdraw string `json:"availableToWithdraw"`
+	AvailableToBorrow   string `json:"availableToBorrow"`
+	BorrowAmount        string `json:"borrowAmount"`
+	AccruedInterest     string `json:"accruedInterest"`
+	TotalOrderIM        string `json:"totalOrderIM"`
+	TotalPositionIM     string `json:"totalPositionIM"`
+	TotalPositionMM     string `json:"totalPositionMM"`
+	UnrealisedPnl       string `json:"unrealisedPnl"`
+	CumRealisedPnl      string `json:"cumRealisedPnl"`
+	Bonus               string `json:"bonus"`
+	Locked              string `json:"locked"`
+	SpotHedgingQty      string `json:"spotHedgingQty"`
+	CollateralSwitch    bool   `json:"collateralSwitch"`
+	MarginCollateral    bool   `json:"marginCollateral"`
+}
+
+// Wallet streams updates of the unified account's wallet over an authenticated private
+// connection.
 type Wallet struct {
-	*client.Client
+	stream *client.PrivateStream[WalletData]
+}
+
+// New creates a Wallet on top of the private client cli.
+func New(cli *client.Client) *Wallet {
+	return &Wallet{stream: client.NewPrivateStream[WalletData](cli, Topic)}
+}
+
+// Subscribe authenticates the connection if needed and subscribes to wallet updates. The
+// handler is invoked once per update and replaces any previous handler.
+func (w *Wallet) Subscribe(handler func(WalletData)) error {
+	return w.stream.Subscribe(context.Background(), handler)
+}
+
+// Unsubscribe unsubscribes from wallet updates and removes the handler.
+func (w *Wallet) Unsubscribe() error {
+	return w.stream.Unsubscribe()
 }
 
-func New(cli *client.Client) Wallet {
-	return Wallet{cli}
+// Close unsubscribes from wallet updates. The client stays open because it may be shared
+// with other services.
+func (w *Wallet) Close() {
+	w.stream.Close()
 }
@@ -0,0 +1,36 @@
+package wallet
+
+import (
+	"encoding/json"
+	"testing"
+
+	restclient "github.com/cploutarchou/crypto-sdk-suite/bybit/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// walletFrame is a wallet message as documented by Bybit, in which unset amounts are empty
+// strings.
+const walletFrame = `{"id":"592324d2bce751-ad38-48eb-8f42-4671d1fb4d4e","topic":"wallet","creationTime":1700034722104,"data":[{"accountIMRate":"0","accountMMRate":"0","totalEquity":"10262.91335023","totalWalletBalance":"9684.46297164","totalMarginBalance":"9684.46297164","totalAvailableBalance":"9556.6056555","totalPerpUPL":"0","totalInitialMargin":"0","totalMaintenanceMargin":"0","coin":[{"coin":"BTC","equity":"0.00102964","usdValue":"36.70759517","walletBalance":"0.00102964","availableToWithdraw":"","availableToBorrow":"","borrowAmount":"0","accruedInterest":"0","totalOrderIM":"","totalPositionIM":"","totalPositionMM":"","unrealisedPnl":"0","cumRealisedPnl":"-0.00000973","bonus":"0","collateralSwitch":true,"marginCollateral":true,"locked":"0","spotHedgingQty":"0.01592413"}],"accountLTV":"0","accountType":"UNIFIED"}]}`
+
+// TestResponse_Decode verifies that a recorded wallet message decodes and that the Decimal
+// accessors read empty amounts as zero.
+func TestResponse_Decode(t *testing.T) {
+	var res Response
+	assert.NoError(t, json.Unmarshal([]byte(walletFrame), &res))
+	if !assert.Len(t, res.Data, 1) || !assert.Len(t, res.Data[0].Coin, 1) {
+		return
+	}
+	data := res.Data[0]
+	assert.Equal(t, "UNIFIED", data.AccountType)
+	assert.Equal(t, "10262.91335023", data.TotalEquity)
+	assert.True(t, restclient.MustParseDecimal("10262.91335023").Equal(data.TotalEquityDecimal()))
+
+	coin := data.Coin[0]
+	assert.Equal(t, "BTC", coin.Coin)
+	assert.Equal(t, "", coin.AvailableToWithdraw)
+	assert.True(t, coin.AvailableToWithdrawDecimal().IsZero())
+	assert.True(t, coin.TotalOrderIMDecimal().IsZero())
+	assert.True(t, restclient.MustParseDecimal("0.00102964").Equal(coin.WalletBalanceDecimal()))
+	assert.True(t, restclient.MustParseDecimal("-0.00000973").Equal(coin.CumRealisedPnlDecimal()))
+	assert.True(t, coin.CollateralSwitch)
+}
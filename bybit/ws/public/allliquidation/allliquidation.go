@@ -0,0 +1,163 @@
+package allliquidation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Response is an allLiquidation message.
+type Response struct {
+	Topic string `json:"topic"`
+	Type  string `json:"type"`
+	TS    int64  `json:"ts"`
+	Data  []Data `json:"data"`
+}
+
+// Data is a single liquidation. Side is the side of the liquidated position: Buy for a long
+// position, Sell for a short one. Size and Price are parsed from the decimal strings Bybit
+// sends.
+type Data struct {
+	UpdatedTime int64   `json:"T"`
+	Symbol      string  `json:"s"`
+	Side        string  `json:"S"`
+	Size        float64 `json:"v"`
+	Price       float64 `json:"p"`
+}
+
+// UnmarshalJSON decodes a liquidation, parsing its size and price.
+func (d *Data) UnmarshalJSON(b []byte) error {
+	type alias Data
+	raw := struct {
+		*alias
+		Size  string `json:"v"`
+		Price string `json:"p"`
+	}{alias: (*alias)(d)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	size, err := strconv.ParseFloat(raw.Size, 64)
+	if err != nil {
+		return fmt.Errorf("invalid liquidation size %q: %v", raw.Size, err)
+	}
+	price, err := strconv.ParseFloat(raw.Price, 64)
+	if err != nil {
+		return fmt.Errorf("invalid liquidation price %q: %v", raw.Price, err)
+	}
+	d.Size, d.Price = size, price
+	return nil
+}
+
+type subscription struct {
+	callback func(Data)
+	remove   func()
+}
+
+// AllLiquidation manages allLiquidation subscriptions. It replaces the liquidation service,
+// whose topic only pushed one liquidation per second per symbol.
+type AllLiquidation struct {
+	client      *client.Client
+	mu          sync.Mutex
+	subscribers map[string]subscription
+}
+
+// New creates an AllLiquidation on top of cli.
+func New(cli *client.Client) *AllLiquidation {
+	return &AllLiquidation{
+		client:      cli,
+		subscribers: make(map[string]subscription),
+	}
+}
+
+// Subscribe subscribes to the liquidations of each symbol. The callback is invoked once per
+// liquidation.
+func (a *AllLiquidation) Subscribe(symbols []string, callback func(Data)) error {
+	topics := topicsFor(symbols)
+
+	a.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := a.subscribers[topic]; exists {
+			sub.remove()
+		}
+		a.subscribers[topic] = subscription{
+			callback: callback,
+			remove:   a.client.Handle(topic, a.handleMessage),
+		}
+	}
+	a.mu.Unlock()
+
+	if err := a.client.Subscriptions().Subscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to subscribe to liquidations: %v", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the liquidations of each symbol and removes their callbacks.
+func (a *AllLiquidation) Unsubscribe(symbols ...string) error {
+	topics := topicsFor(symbols)
+
+	a.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := a.subscribers[topic]; exists {
+			sub.remove()
+			delete(a.subscribers, topic)
+		}
+	}
+	a.mu.Unlock()
+
+	if err := a.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to unsubscribe from liquidations: %v", err)
+	}
+	return nil
+}
+
+// Close unsubscribes from all liquidations of this service and removes their callbacks. The
+// client stays open because it may be shared with other services.
+func (a *AllLiquidation) Close() {
+	a.mu.Lock()
+	topics := make([]string, 0, len(a.subscribers))
+	for topic, sub := range a.subscribers {
+		sub.remove()
+		topics = append(topics, topic)
+	}
+	a.subscribers = make(map[string]subscription)
+	a.mu.Unlock()
+
+	if len(topics) == 0 {
+		return
+	}
+	if err := a.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		a.client.Logger().Error("Failed to unsubscribe from liquidations: %v", err)
+	}
+}
+
+// handleMessage is registered with the client for every subscribed liquidation topic.
+func (a *AllLiquidation) handleMessage(message []byte) {
+	var res Response
+	if err := json.Unmarshal(message, &res); err != nil {
+		a.client.Logger().Error("Error unmarshalling message: %v", err)
+		return
+	}
+
+	a.mu.Lock()
+	sub, exists := a.subscribers[res.Topic]
+	a.mu.Unlock()
+
+	if exists {
+		for _, data := range res.Data {
+			sub.callback(data)
+		}
+	}
+}
+
+func topicsFor(symbols []string) []string {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("allLiquidation.%s", symbol)
+	}
+	return topics
+}
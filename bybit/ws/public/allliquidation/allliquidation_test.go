@@ -0,0 +1,25 @@
+package allliquidation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_UnmarshalJSON(t *testing.T) {
+	var res Response
+	msg := `{"topic":"allLiquidation.BTCUSDT","type":"snapshot","ts":1739502303204,"data":[{"T":1739502302929,"s":"BTCUSDT","S":"Sell","v":"0.003","p":"97107.90"}]}`
+	assert.NoError(t, json.Unmarshal([]byte(msg), &res))
+	assert.Equal(t, "allLiquidation.BTCUSDT", res.Topic)
+	if assert.Len(t, res.Data, 1) {
+		data := res.Data[0]
+		assert.Equal(t, int64(1739502302929), data.UpdatedTime)
+		assert.Equal(t, "BTCUSDT", data.Symbol)
+		assert.Equal(t, "Sell", data.Side)
+		assert.Equal(t, 0.003, data.Size)
+		assert.Equal(t, 97107.9, data.Price)
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`{"v":"1","p":"x"}`), &Data{}))
+}
@@ -1,9 +1,12 @@
 package kline
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 )
 
@@ -13,19 +16,21 @@ type Kline interface {
 	SetClient(client *client.Client) error
 
 	// Subscribe subscribes to kline data for the specified symbols and interval.
-	// It also stores the callback for each topic.
-	Subscribe(symbols []string, interval string, callback func(response Data)) error
+	// It also stores the callback for each topic. An unsupported interval is rejected.
+	Subscribe(symbols []string, interval market.Interval, callback func(response Data)) error
 
-	// Unsubscribe unsubscribes from the specified topics.
-	Unsubscribe(topics ...string) error
+	// Unsubscribe unsubscribes from kline data for the specified symbols and interval
+	// and removes their callbacks.
+	Unsubscribe(interval market.Interval, symbols ...string) error
 
 	// Listen reads the next message from the kline channel.
 	Listen() (int, []byte, error)
 
-	// Close closes the connection to the kline channel.
+	// Close unsubscribes from all kline topics and detaches their callbacks.
 	Close()
 
-	// GetMessagesChan returns a channel that receives messages from the kline channel.
+	// GetMessagesChan returns a channel that receives the raw kline messages. Its size and
+	// overflow policy are set by client.WithMessageBuffer.
 	GetMessagesChan() <-chan []byte
 
 	// Stop stops the kline functionality.
@@ -59,31 +64,30 @@ type Data struct {
 func New(c *client.Client) (Kline, error) {
 	var k klineImpl
 	k.client = c
-	k.Messages = make(chan []byte, 100)
-	k.StopChan = make(chan struct{}, 1)
+	k.messages = client.NewBuffer[[]byte](c.MessageBuffer())
 	k.isTest = c.IsTestNet
+	k.topicCallbacks = make(map[string]topicCallback)
 	err := k.client.Connect()
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
 
 	<-k.client.Connected
-	fmt.Println("Connected to WS")
-
-	go k.listenForMessages()
+	k.client.Logger().Info("Connected to WS")
 
 	return &k, nil
 }
 
 type topicCallback struct {
 	callback func(data Data)
+	remove   func()
 }
 
 type klineImpl struct {
 	client         *client.Client
-	Messages       chan []byte
-	StopChan       chan struct{}
+	messages       *client.Buffer[[]byte]
 	isTest         bool
+	mu             sync.Mutex
 	topicCallbacks map[string]topicCallback
 }
 
@@ -92,46 +96,39 @@ func (k *klineImpl) SetClient(c *client.Client) error {
 	return nil
 }
 
-func (k *klineImpl) Subscribe(symbols []string, interval string, callback func(response Data)) error {
-	if k.topicCallbacks == nil {
-		k.topicCallbacks = make(map[string]topicCallback)
+func (k *klineImpl) Subscribe(symbols []string, interval market.Interval, callback func(response Data)) error {
+	if err := interval.Validate(); err != nil {
+		return err
 	}
-
 	topics := make([]string, len(symbols))
+	k.mu.Lock()
 	for i, symbol := range symbols {
 		topic := fmt.Sprintf("kline.%s.%s", interval, symbol)
 		topics[i] = topic
-		k.topicCallbacks[topic] = topicCallback{callback: callback}
-	}
-
-	subscription := map[string]any{
-		"op":   "subscribe",
-		"args": topics,
-	}
-
-	msg, err := json.Marshal(subscription)
-	if err != nil {
-		return fmt.Errorf("failed to marshal subscription message: %v", err)
+		if tc, exists := k.topicCallbacks[topic]; exists {
+			tc.remove()
+		}
+		k.topicCallbacks[topic] = topicCallback{
+			callback: callback,
+			remove:   k.client.Handle(topic, k.handleMessage),
+		}
 	}
+	k.mu.Unlock()
 
-	if err := k.client.Send(msg); err != nil {
+	if err := k.client.Subscriptions().Subscribe(context.Background(), topics...); err != nil {
 		return fmt.Errorf("failed to subscribe to kline channel: %v", err)
 	}
 
 	return nil
 }
 
-func (k *klineImpl) Unsubscribe(topics ...string) error {
-	unsubscription := map[string]any{
-		"op":   "unsubscribe",
-		"args": topics,
-	}
-	msg, err := json.Marshal(unsubscription)
-	if err != nil {
-		return fmt.Errorf("failed to marshal unsubscription message: %v", err)
+func (k *klineImpl) Unsubscribe(interval market.Interval, symbols ...string) error {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("kline.%s.%s", interval, symbol)
 	}
-
-	if err := k.client.Send(msg); err != nil {
+	k.removeCallbacks(topics...)
+	if err := k.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
 		return fmt.Errorf("failed to unsubscribe from kline channel: %v", err)
 	}
 
@@ -139,45 +136,71 @@ func (k *klineImpl) Unsubscribe(topics ...string) error {
 }
 
 func (k *klineImpl) Listen() (int, []byte, error) {
-	return k.client.Conn.ReadMessage()
+	msg, err := k.client.Receive()
+	return client.WSMessageText, msg, err
 }
 
+// Close unsubscribes from every kline topic of this service and detaches its callbacks.
+// The client stays open because it may be shared with other services.
 func (k *klineImpl) Close() {
-	k.client.Close()
+	k.mu.Lock()
+	topics := make([]string, 0, len(k.topicCallbacks))
+	for topic := range k.topicCallbacks {
+		topics = append(topics, topic)
+	}
+	k.mu.Unlock()
+
+	k.removeCallbacks()
+	if len(topics) == 0 {
+		return
+	}
+	if err := k.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		k.client.Logger().Error("Failed to unsubscribe from kline channel: %v", err)
+	}
 }
 
 func (k *klineImpl) GetMessagesChan() <-chan []byte {
-	return k.Messages
+	return k.messages.C()
 }
 
+// Stop detaches the kline callbacks from the client. The subscriptions stay active.
 func (k *klineImpl) Stop() {
-	k.StopChan <- struct{}{}
+	k.removeCallbacks()
+}
+
+// removeCallbacks detaches the callbacks of the given topics, or of every topic when none are given.
+func (k *klineImpl) removeCallbacks(topics ...string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if len(topics) == 0 {
+		for topic := range k.topicCallbacks {
+			topics = append(topics, topic)
+		}
+	}
+	for _, topic := range topics {
+		if tc, exists := k.topicCallbacks[topic]; exists {
+			tc.remove()
+			delete(k.topicCallbacks, topic)
+		}
+	}
 }
 
-func (k *klineImpl) listenForMessages() {
-	for {
-		select {
-		case <-k.StopChan:
-			return
-		default:
-			_, msg, err := k.client.Conn.ReadMessage()
-			if err != nil {
-				// Handle error, possibly logging and breaking the loop or attempting to reconnect
-				return
-			}
-			k.Messages <- msg
-
-			var resp Response
-			if err := json.Unmarshal(msg, &resp); err != nil {
-				// Handle unmarshal error
-				continue
-			}
-
-			if tc, exists := k.topicCallbacks[resp.Topic]; exists {
-				for _, data := range resp.Data {
-					tc.callback(data)
-				}
-			}
+// handleMessage is registered with the client for every subscribed kline topic.
+func (k *klineImpl) handleMessage(msg []byte) {
+	k.messages.Push(msg)
+
+	var resp Response
+	if err := json.Unmarshal(msg, &resp); err != nil {
+		return
+	}
+
+	k.mu.Lock()
+	tc, exists := k.topicCallbacks[resp.Topic]
+	k.mu.Unlock()
+	if exists {
+		for _, data := range resp.Data {
+			tc.callback(data)
 		}
 	}
 }
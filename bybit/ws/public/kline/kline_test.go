@@ -43,4 +43,5 @@ func TestSubscribe(t *testing.T) {
 
 	kl.Stop()
 	kl.Close()
+	cli.Close()
 }
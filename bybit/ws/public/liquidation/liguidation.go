@@ -1,14 +1,18 @@
+// Package liquidation streams the legacy liquidation topic.
+//
+// Deprecated: Bybit replaced the liquidation topic with allLiquidation; use the
+// allliquidation package instead.
 package liquidation
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 )
 
-var oneHundred = 100
-
 // Liquidation represents the interface for the liquidation functionality.
 type Liquidation interface {
 	// SetClient sets the client for the liquidation functionality.
@@ -18,16 +22,18 @@ type Liquidation interface {
 	// It also stores the callback for each topic.
 	Subscribe(symbols []string, callback func(response Data)) error
 
-	// Unsubscribe unsubscribes from the specified topics.
-	Unsubscribe(topics ...string) error
+	// Unsubscribe unsubscribes from liquidation data for the specified symbols
+	// and removes their callbacks.
+	Unsubscribe(symbols ...string) error
 
 	// Listen reads the next message from the liquidation channel.
 	Listen() (int, []byte, error)
 
-	// Close closes the connection to the liquidation channel.
+	// Close unsubscribes from all liquidation topics and detaches their callbacks.
 	Close()
 
-	// GetMessagesChan returns a channel that receives messages from the liquidation channel.
+	// GetMessagesChan returns a channel that receives the raw liquidation messages. Its size and
+	// overflow policy are set by client.WithMessageBuffer.
 	GetMessagesChan() <-chan []byte
 
 	// Stop stops the liquidation functionality.
@@ -55,31 +61,31 @@ type Data struct {
 func New(cli *client.Client) Liquidation {
 	var l liquidationImpl
 	l.client = cli
-	l.Messages = make(chan []byte, oneHundred)
-	l.StopChan = make(chan struct{}, 1)
+	l.messages = client.NewBuffer[[]byte](cli.MessageBuffer())
 	l.isTest = cli.IsTestNet
+	l.topicCallbacks = make(map[string]topicCallback)
 	err := l.client.Connect()
 	if err != nil {
-		fmt.Printf("Failed to connect: %v", err)
+		l.client.Logger().Error("Failed to connect: %v", err)
+		return &l
 	}
 
 	<-l.client.Connected
-	fmt.Println("Connected to WS")
-
-	go l.listenForMessages()
+	l.client.Logger().Info("Connected to WS")
 
 	return &l
 }
 
 type topicCallback struct {
 	callback func(data Data)
+	remove   func()
 }
 
 type liquidationImpl struct {
 	client         *client.Client
-	Messages       chan []byte
-	StopChan       chan struct{}
+	messages       *client.Buffer[[]byte]
 	isTest         bool
+	mu             sync.Mutex
 	topicCallbacks map[string]topicCallback
 }
 
@@ -89,45 +95,35 @@ func (l *liquidationImpl) SetClient(c *client.Client) error {
 }
 
 func (l *liquidationImpl) Subscribe(symbols []string, callback func(response Data)) error {
-	if l.topicCallbacks == nil {
-		l.topicCallbacks = make(map[string]topicCallback)
-	}
-
 	topics := make([]string, len(symbols))
+	l.mu.Lock()
 	for i, symbol := range symbols {
 		topic := fmt.Sprintf("liquidation.%s", symbol)
 		topics[i] = topic
-		l.topicCallbacks[topic] = topicCallback{callback: callback}
-	}
-
-	subscription := map[string]any{
-		"op":   "subscribe",
-		"args": topics,
-	}
-
-	msg, err := json.Marshal(subscription)
-	if err != nil {
-		return fmt.Errorf("failed to marshal subscription message: %v", err)
+		if tc, exists := l.topicCallbacks[topic]; exists {
+			tc.remove()
+		}
+		l.topicCallbacks[topic] = topicCallback{
+			callback: callback,
+			remove:   l.client.Handle(topic, l.handleMessage),
+		}
 	}
+	l.mu.Unlock()
 
-	if err := l.client.Send(msg); err != nil {
+	if err := l.client.Subscriptions().Subscribe(context.Background(), topics...); err != nil {
 		return fmt.Errorf("failed to subscribe to liquidation channel: %v", err)
 	}
 
 	return nil
 }
 
-func (l *liquidationImpl) Unsubscribe(topics ...string) error {
-	unsubscription := map[string]any{
-		"op":   "unsubscribe",
-		"args": topics,
-	}
-	msg, err := json.Marshal(unsubscription)
-	if err != nil {
-		return fmt.Errorf("failed to marshal unsubscription message: %v", err)
+func (l *liquidationImpl) Unsubscribe(symbols ...string) error {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("liquidation.%s", symbol)
 	}
-
-	if err := l.client.Send(msg); err != nil {
+	l.removeCallbacks(topics...)
+	if err := l.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
 		return fmt.Errorf("failed to unsubscribe from liquidation channel: %v", err)
 	}
 
@@ -135,41 +131,69 @@ func (l *liquidationImpl) Unsubscribe(topics ...string) error {
 }
 
 func (l *liquidationImpl) Listen() (int, []byte, error) {
-	return l.client.Conn.ReadMessage()
+	msg, err := l.client.Receive()
+	return client.WSMessageText, msg, err
 }
 
+// Close unsubscribes from every liquidation topic of this service and detaches its callbacks.
+// The client stays open because it may be shared with other services.
 func (l *liquidationImpl) Close() {
-	l.client.Close()
+	l.mu.Lock()
+	topics := make([]string, 0, len(l.topicCallbacks))
+	for topic := range l.topicCallbacks {
+		topics = append(topics, topic)
+	}
+	l.mu.Unlock()
+
+	l.removeCallbacks()
+	if len(topics) == 0 {
+		return
+	}
+	if err := l.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		l.client.Logger().Error("Failed to unsubscribe from liquidation channel: %v", err)
+	}
 }
 
 func (l *liquidationImpl) GetMessagesChan() <-chan []byte {
-	return l.Messages
+	return l.messages.C()
 }
 
+// Stop detaches the liquidation callbacks from the client. The subscriptions stay active.
 func (l *liquidationImpl) Stop() {
-	l.StopChan <- struct{}{}
+	l.removeCallbacks()
 }
 
-func (l *liquidationImpl) listenForMessages() {
-	for {
-		select {
-		case <-l.StopChan:
-			return
-		default:
-			_, msg, err := l.client.Conn.ReadMessage()
-			if err != nil {
-				continue
-			}
-			l.Messages <- msg
-
-			var resp Response
-			if err := json.Unmarshal(msg, &resp); err != nil {
-				continue
-			}
-
-			if tc, exists := l.topicCallbacks[resp.Topic]; exists {
-				tc.callback(resp.Data)
-			}
+// removeCallbacks detaches the callbacks of the given topics, or of every topic when none are given.
+func (l *liquidationImpl) removeCallbacks(topics ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(topics) == 0 {
+		for topic := range l.topicCallbacks {
+			topics = append(topics, topic)
+		}
+	}
+	for _, topic := range topics {
+		if tc, exists := l.topicCallbacks[topic]; exists {
+			tc.remove()
+			delete(l.topicCallbacks, topic)
 		}
 	}
 }
+
+// handleMessage is registered with the client for every subscribed liquidation topic.
+func (l *liquidationImpl) handleMessage(msg []byte) {
+	l.messages.Push(msg)
+
+	var resp Response
+	if err := json.Unmarshal(msg, &resp); err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	tc, exists := l.topicCallbacks[resp.Topic]
+	l.mu.Unlock()
+	if exists {
+		tc.callback(resp.Data)
+	}
+}
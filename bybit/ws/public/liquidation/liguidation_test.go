@@ -40,4 +40,5 @@ func TestSubscribe(t *testing.T) {
 
 	kl.Stop()
 	kl.Close()
+	cli.Close()
 }
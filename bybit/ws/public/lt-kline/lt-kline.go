@@ -1,27 +1,31 @@
 package lt_kline
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"sync"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 )
 
 // LTKline represents the interface for the LT Kline functionality.
 type LTKline interface {
 	SetClient(client *client.Client) error
-	Subscribe(interval string, symbol string, callback func(response LTKlineResponse)) error
-	// Unsubscribe unsubscribes from the specified topics.
-	Unsubscribe(topics ...string) error
+	Subscribe(interval market.Interval, symbol string, callback func(response LTKlineResponse)) error
+	// Unsubscribe unsubscribes from LT kline data for the specified symbols and interval
+	// and removes their callbacks.
+	Unsubscribe(interval market.Interval, symbols ...string) error
 
 	// Listen reads the next message from the kline channel.
 	Listen() (int, []byte, error)
 
-	// Close closes the connection to the kline channel.
+	// Close unsubscribes from all LT kline topics and detaches their callbacks.
 	Close()
 
-	// GetMessagesChan returns a channel that receives messages from the kline channel.
+	// GetMessagesChan returns a channel that receives the raw LT kline messages. Its size and
+	// overflow policy are set by client.WithMessageBuffer.
 	GetMessagesChan() <-chan []byte
 
 	// Stop stops the kline functionality.
@@ -29,19 +33,21 @@ type LTKline interface {
 }
 type ltKlineImpl struct {
 	client   *client.Client
-	stopChan chan struct{}
-	Messages <-chan []byte
-	StopChan chan struct{}
+	messages *client.Buffer[[]byte]
+	mu       sync.Mutex
+	removers map[string]func()
 }
 
+// Stop detaches the LT kline callbacks from the client. The subscriptions stay active.
 func (l *ltKlineImpl) Stop() {
-	l.StopChan <- struct{}{}
+	l.removeCallbacks()
 }
 
 func New(cli *client.Client) LTKline {
 	return &ltKlineImpl{
 		client:   cli,
-		stopChan: make(chan struct{}, 1),
+		messages: client.NewBuffer[[]byte](cli.MessageBuffer()),
+		removers: make(map[string]func()),
 	}
 }
 
@@ -50,74 +56,97 @@ func (l *ltKlineImpl) SetClient(c *client.Client) error {
 	return nil
 }
 
-func (l *ltKlineImpl) Subscribe(interval string, symbol string, callback func(response LTKlineResponse)) error {
+func (l *ltKlineImpl) Subscribe(interval market.Interval, symbol string, callback func(response LTKlineResponse)) error {
 	return l.SubscribeLTKline(interval, symbol, callback)
 }
 
+// Close unsubscribes from every LT kline topic of this service and detaches its callbacks.
+// The client stays open because it may be shared with other services.
 func (l *ltKlineImpl) Close() {
-	l.stopChan <- struct{}{}
-	l.client.Close()
-}
-func (l *ltKlineImpl) Unsubscribe(topics ...string) error {
-	unsubscription := map[string]any{
-		"op":   "unsubscribe",
-		"args": topics,
+	l.mu.Lock()
+	topics := make([]string, 0, len(l.removers))
+	for topic := range l.removers {
+		topics = append(topics, topic)
+	}
+	l.mu.Unlock()
+
+	l.removeCallbacks()
+	if len(topics) == 0 {
+		return
 	}
-	msg, err := json.Marshal(unsubscription)
-	if err != nil {
-		return fmt.Errorf("failed to marshal unsubscription message: %v", err)
+	if err := l.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		l.client.Logger().Error("Failed to unsubscribe from LT kline channel: %v", err)
 	}
+}
 
-	if err := l.client.Send(msg); err != nil {
+func (l *ltKlineImpl) Unsubscribe(interval market.Interval, symbols ...string) error {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("kline_lt.%s.%s", interval, symbol)
+	}
+	l.removeCallbacks(topics...)
+	if err := l.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
 		return fmt.Errorf("failed to unsubscribe from kline channel: %v", err)
 	}
 
 	return nil
 }
+
 func (l *ltKlineImpl) Listen() (int, []byte, error) {
-	return l.client.Conn.ReadMessage()
+	msg, err := l.client.Receive()
+	return client.WSMessageText, msg, err
 }
+
 func (l *ltKlineImpl) GetMessagesChan() <-chan []byte {
-	return l.Messages
+	return l.messages.C()
 }
 
 // SubscribeLTKline subscribes to the leveraged token kline stream for the specified interval and symbol.
-func (l *ltKlineImpl) SubscribeLTKline(interval string, symbol string, callback func(response LTKlineResponse)) error {
-	topic := fmt.Sprintf("kline_lt.%s.%s", interval, symbol)
-	subscription := map[string]any{
-		"op":   "subscribe",
-		"args": []string{topic},
+func (l *ltKlineImpl) SubscribeLTKline(interval market.Interval, symbol string, callback func(response LTKlineResponse)) error {
+	if err := interval.Validate(); err != nil {
+		return err
 	}
+	topic := fmt.Sprintf("kline_lt.%s.%s", interval, symbol)
 
-	msg, err := json.Marshal(subscription)
-	if err != nil {
-		return fmt.Errorf("failed to marshal subscription message: %v", err)
+	remove := l.client.Handle(topic, func(message []byte) {
+		l.messages.Push(message)
+
+		var resp LTKlineResponse
+		if err := json.Unmarshal(message, &resp); err != nil {
+			l.client.Logger().Error("Error unmarshaling message: %v", err)
+			return
+		}
+		callback(resp)
+	})
+
+	l.mu.Lock()
+	if previous, exists := l.removers[topic]; exists {
+		previous()
 	}
+	l.removers[topic] = remove
+	l.mu.Unlock()
 
-	if err := l.client.Send(msg); err != nil {
+	if err := l.client.Subscriptions().Subscribe(context.Background(), topic); err != nil {
 		return fmt.Errorf("failed to subscribe to LT kline stream: %v", err)
 	}
 
-	// Start a goroutine to listen for messages
-	go func() {
-		for {
-			message, err := l.client.Receive()
-			if err != nil {
-				log.Printf("Error receiving message: %v", err)
-				continue
-			}
-
-			var resp LTKlineResponse
-			if err := json.Unmarshal(message, &resp); err != nil {
-				log.Printf("Error unmarshaling message: %v", err)
-				continue
-			}
-
-			if resp.Topic == topic {
-				callback(resp)
-			}
-		}
-	}()
-
 	return nil
 }
+
+// removeCallbacks detaches the callbacks of the given topics, or of every topic when none are given.
+func (l *ltKlineImpl) removeCallbacks(topics ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(topics) == 0 {
+		for topic := range l.removers {
+			topics = append(topics, topic)
+		}
+	}
+	for _, topic := range topics {
+		if remove, exists := l.removers[topic]; exists {
+			remove()
+			delete(l.removers, topic)
+		}
+	}
+}
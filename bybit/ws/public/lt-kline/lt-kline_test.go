@@ -44,4 +44,5 @@ func TestSubscribe(t *testing.T) {
 
 	ltKline.Stop()
 	ltKline.Close()
+	cli.Close()
 }
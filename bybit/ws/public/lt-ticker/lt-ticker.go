@@ -1,11 +1,134 @@
 package lt_ticker
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Response is a leveraged token ticker message.
+type Response struct {
+	Topic string `json:"topic"`
+	Type  string `json:"type"`
+	TS    int64  `json:"ts"`
+	Data  Data   `json:"data"`
+}
+
+// Data is the ticker of a leveraged token.
+type Data struct {
+	Symbol       string `json:"symbol"`
+	Price24HPcnt string `json:"price24hPcnt"`
+	LastPrice    string `json:"lastPrice"`
+	PrevPrice24H string `json:"prevPrice24h"`
+	HighPrice24H string `json:"highPrice24h"`
+	LowPrice24H  string `json:"lowPrice24h"`
+}
+
+type subscription struct {
+	callback func(Data)
+	remove   func()
+}
+
+// LtTicker manages leveraged token tickers subscriptions.
 type LtTicker struct {
-	*client.Client
+	client      *client.Client
+	mu          sync.Mutex
+	subscribers map[string]subscription
+}
+
+// New creates a LtTicker on top of cli.
+func New(cli *client.Client) *LtTicker {
+	return &LtTicker{
+		client:      cli,
+		subscribers: make(map[string]subscription),
+	}
+}
+
+// Subscribe subscribes to the leveraged token ticker of each symbol.
+func (l *LtTicker) Subscribe(symbols []string, callback func(Data)) error {
+	topics := topicsFor(symbols)
+
+	l.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := l.subscribers[topic]; exists {
+			sub.remove()
+		}
+		l.subscribers[topic] = subscription{
+			callback: callback,
+			remove:   l.client.Handle(topic, l.handleMessage),
+		}
+	}
+	l.mu.Unlock()
+
+	if err := l.client.Subscriptions().Subscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to subscribe to leveraged token tickers: %v", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the leveraged token tickers of each symbol and removes their callbacks.
+func (l *LtTicker) Unsubscribe(symbols ...string) error {
+	topics := topicsFor(symbols)
+
+	l.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := l.subscribers[topic]; exists {
+			sub.remove()
+			delete(l.subscribers, topic)
+		}
+	}
+	l.mu.Unlock()
+
+	if err := l.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to unsubscribe from leveraged token tickers: %v", err)
+	}
+	return nil
+}
+
+// Close unsubscribes from all leveraged token tickers of this service and removes their callbacks. The
+// client stays open because it may be shared with other services.
+func (l *LtTicker) Close() {
+	l.mu.Lock()
+	topics := make([]string, 0, len(l.subscribers))
+	for topic, sub := range l.subscribers {
+		sub.remove()
+		topics = append(topics, topic)
+	}
+	l.subscribers = make(map[string]subscription)
+	l.mu.Unlock()
+
+	if len(topics) == 0 {
+		return
+	}
+	if err := l.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		l.client.Logger().Error("Failed to unsubscribe from leveraged token tickers: %v", err)
+	}
+}
+
+// handleMessage is registered with the client for every subscribed leveraged token tickers topic.
+func (l *LtTicker) handleMessage(message []byte) {
+	var res Response
+	if err := json.Unmarshal(message, &res); err != nil {
+		l.client.Logger().Error("Error unmarshalling message: %v", err)
+		return
+	}
+
+	l.mu.Lock()
+	sub, exists := l.subscribers[res.Topic]
+	l.mu.Unlock()
+
+	if exists {
+		sub.callback(res.Data)
+	}
 }
 
-func New(cli *client.Client) LtTicker {
-	return LtTicker{cli}
+func topicsFor(symbols []string) []string {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("tickers_lt.%s", symbol)
+	}
+	return topics
 }
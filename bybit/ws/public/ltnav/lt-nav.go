@@ -1,11 +1,136 @@
 package ltnav
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Response is a leveraged token net asset value message.
+type Response struct {
+	Topic string `json:"topic"`
+	Type  string `json:"type"`
+	TS    int64  `json:"ts"`
+	Data  Data   `json:"data"`
+}
+
+// Data is the net asset value of a leveraged token.
+type Data struct {
+	Time           int64  `json:"time"`
+	Symbol         string `json:"symbol"`
+	Nav            string `json:"nav"`
+	BasketPosition string `json:"basketPosition"`
+	Leverage       string `json:"leverage"`
+	BasketLoan     string `json:"basketLoan"`
+	Circulation    string `json:"circulation"`
+	Basket         string `json:"basket"`
+}
+
+type subscription struct {
+	callback func(Data)
+	remove   func()
+}
+
+// LtNav manages leveraged token NAV subscriptions.
 type LtNav struct {
-	*client.Client
+	client      *client.Client
+	mu          sync.Mutex
+	subscribers map[string]subscription
+}
+
+// New creates a LtNav on top of cli.
+func New(cli *client.Client) *LtNav {
+	return &LtNav{
+		client:      cli,
+		subscribers: make(map[string]subscription),
+	}
+}
+
+// Subscribe subscribes to the leveraged token net asset value of each symbol.
+func (l *LtNav) Subscribe(symbols []string, callback func(Data)) error {
+	topics := topicsFor(symbols)
+
+	l.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := l.subscribers[topic]; exists {
+			sub.remove()
+		}
+		l.subscribers[topic] = subscription{
+			callback: callback,
+			remove:   l.client.Handle(topic, l.handleMessage),
+		}
+	}
+	l.mu.Unlock()
+
+	if err := l.client.Subscriptions().Subscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to subscribe to leveraged token NAV: %v", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the leveraged token NAV of each symbol and removes their callbacks.
+func (l *LtNav) Unsubscribe(symbols ...string) error {
+	topics := topicsFor(symbols)
+
+	l.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := l.subscribers[topic]; exists {
+			sub.remove()
+			delete(l.subscribers, topic)
+		}
+	}
+	l.mu.Unlock()
+
+	if err := l.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to unsubscribe from leveraged token NAV: %v", err)
+	}
+	return nil
+}
+
+// Close unsubscribes from all leveraged token NAV of this service and removes their callbacks. The
+// client stays open because it may be shared with other services.
+func (l *LtNav) Close() {
+	l.mu.Lock()
+	topics := make([]string, 0, len(l.subscribers))
+	for topic, sub := range l.subscribers {
+		sub.remove()
+		topics = append(topics, topic)
+	}
+	l.subscribers = make(map[string]subscription)
+	l.mu.Unlock()
+
+	if len(topics) == 0 {
+		return
+	}
+	if err := l.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		l.client.Logger().Error("Failed to unsubscribe from leveraged token NAV: %v", err)
+	}
+}
+
+// handleMessage is registered with the client for every subscribed leveraged token NAV topic.
+func (l *LtNav) handleMessage(message []byte) {
+	var res Response
+	if err := json.Unmarshal(message, &res); err != nil {
+		l.client.Logger().Error("Error unmarshalling message: %v", err)
+		return
+	}
+
+	l.mu.Lock()
+	sub, exists := l.subscribers[res.Topic]
+	l.mu.Unlock()
+
+	if exists {
+		sub.callback(res.Data)
+	}
 }
 
-func New(cli *client.Client) LtNav {
-	return LtNav{cli}
+func topicsFor(symbols []string) []string {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("lt.%s", symbol)
+	}
+	return topics
 }
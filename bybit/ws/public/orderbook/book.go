@@ -0,0 +1,199 @@
+package orderbook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Level is a price level of a Book.
+type Level struct {
+	Price string
+	Size  string
+}
+
+// level is a Level with its parsed price, used for ordering.
+type level struct {
+	Level
+	price float64
+}
+
+// Book is a local order book built from the snapshot and delta messages of the order book
+// stream. It is safe for concurrent use: Apply may run on the stream goroutine while other
+// goroutines read the book.
+type Book struct {
+	mu       sync.RWMutex
+	symbol   string
+	bids     map[float64]level
+	asks     map[float64]level
+	ready    bool
+	updateID int64
+	seq      int64
+	ts       int64
+}
+
+// NewBook creates an empty book for symbol.
+func NewBook(symbol string) *Book {
+	return &Book{
+		symbol: symbol,
+		bids:   make(map[float64]level),
+		asks:   make(map[float64]level),
+	}
+}
+
+// SubscribeBook subscribes to the order book of symbol at the given depth and returns a
+// Book kept up to date from the stream.
+func (o *OrderBook) SubscribeBook(symbol string, depth int) (*Book, error) {
+	book := NewBook(symbol)
+	err := o.Subscribe([]string{symbol}, depth, func(res Response) {
+		if err := book.Apply(res); err != nil {
+			o.client.Logger().Error("Failed to apply order book update: %v", err)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// Apply merges an order book message into the book. A snapshot replaces the book; a delta
+// updates the given levels and removes those whose size is zero.
+func (b *Book) Apply(res Response) error {
+	if res.Data.Symbol != "" && res.Data.Symbol != b.symbol {
+		return fmt.Errorf("order book update for %s applied to %s book", res.Data.Symbol, b.symbol)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch res.Type {
+	case "snapshot":
+		b.bids = make(map[float64]level, len(res.Data.Bids))
+		b.asks = make(map[float64]level, len(res.Data.Asks))
+		b.ready = true
+	case "delta":
+		if !b.ready {
+			return errors.New("order book delta received before snapshot")
+		}
+	default:
+		return fmt.Errorf("unknown order book message type %q", res.Type)
+	}
+
+	if err := merge(b.bids, res.Data.Bids); err != nil {
+		return err
+	}
+	if err := merge(b.asks, res.Data.Asks); err != nil {
+		return err
+	}
+	b.updateID = res.Data.UpdateID
+	b.seq = res.Data.Seq
+	b.ts = res.TS
+	return nil
+}
+
+// merge applies [price, size] pairs to side.
+func merge(side map[float64]level, entries [][]string) error {
+	for _, entry := range entries {
+		if len(entry) < 2 {
+			return fmt.Errorf("malformed order book level %v", entry)
+		}
+		price, err := strconv.ParseFloat(entry[0], 64)
+		if err != nil {
+			return fmt.Errorf("invalid price %q: %v", entry[0], err)
+		}
+		size, err := strconv.ParseFloat(entry[1], 64)
+		if err != nil {
+			return fmt.Errorf("invalid size %q: %v", entry[1], err)
+		}
+		if size == 0 {
+			delete(side, price)
+			continue
+		}
+		side[price] = level{Level: Level{Price: entry[0], Size: entry[1]}, price: price}
+	}
+	return nil
+}
+
+// Symbol returns the symbol of the book.
+func (b *Book) Symbol() string {
+	return b.symbol
+}
+
+// UpdateID returns the update id of the last applied message.
+func (b *Book) UpdateID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.updateID
+}
+
+// BestBid returns the highest bid. ok is false when there are no bids.
+func (b *Book) BestBid() (best Level, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return top(b.bids, func(p, q float64) bool { return p > q })
+}
+
+// BestAsk returns the lowest ask. ok is false when there are no asks.
+func (b *Book) BestAsk() (best Level, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return top(b.asks, func(p, q float64) bool { return p < q })
+}
+
+// MidPrice returns the average of the best bid and the best ask. ok is false unless both
+// sides have levels.
+func (b *Book) MidPrice() (mid float64, ok bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	bid, hasBid := topLevel(b.bids, func(p, q float64) bool { return p > q })
+	ask, hasAsk := topLevel(b.asks, func(p, q float64) bool { return p < q })
+	if !hasBid || !hasAsk {
+		return 0, false
+	}
+	return (bid.price + ask.price) / 2, true
+}
+
+// DepthN returns up to n levels per side, bids from the highest price and asks from the
+// lowest.
+func (b *Book) DepthN(n int) (bids, asks []Level) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return sorted(b.bids, n, func(p, q float64) bool { return p > q }),
+		sorted(b.asks, n, func(p, q float64) bool { return p < q })
+}
+
+func top(side map[float64]level, better func(p, q float64) bool) (Level, bool) {
+	l, ok := topLevel(side, better)
+	return l.Level, ok
+}
+
+func topLevel(side map[float64]level, better func(p, q float64) bool) (level, bool) {
+	var best level
+	found := false
+	for _, l := range side {
+		if !found || better(l.price, best.price) {
+			best, found = l, true
+		}
+	}
+	return best, found
+}
+
+func sorted(side map[float64]level, n int, better func(p, q float64) bool) []Level {
+	levels := make([]level, 0, len(side))
+	for _, l := range side {
+		levels = append(levels, l)
+	}
+	sort.Slice(levels, func(i, j int) bool { return better(levels[i].price, levels[j].price) })
+	if n >= 0 && n < len(levels) {
+		levels = levels[:n]
+	}
+
+	out := make([]Level, len(levels))
+	for i, l := range levels {
+		out[i] = l.Level
+	}
+	return out
+}
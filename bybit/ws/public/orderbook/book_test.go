@@ -0,0 +1,58 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBook_Apply(t *testing.T) {
+	book := NewBook("BTCUSDT")
+
+	err := book.Apply(Response{Type: "delta", Data: Data{Symbol: "BTCUSDT"}})
+	assert.Error(t, err, "delta before snapshot")
+
+	assert.NoError(t, book.Apply(Response{Type: "snapshot", Data: Data{
+		Symbol:   "BTCUSDT",
+		Bids:     [][]string{{"100.0", "1"}, {"99.5", "2"}, {"99.0", "3"}},
+		Asks:     [][]string{{"101.0", "1"}, {"102.0", "2"}},
+		UpdateID: 1,
+	}}))
+
+	bid, ok := book.BestBid()
+	assert.True(t, ok)
+	assert.Equal(t, Level{Price: "100.0", Size: "1"}, bid)
+	ask, ok := book.BestAsk()
+	assert.True(t, ok)
+	assert.Equal(t, Level{Price: "101.0", Size: "1"}, ask)
+	mid, ok := book.MidPrice()
+	assert.True(t, ok)
+	assert.Equal(t, 100.5, mid)
+
+	// Remove the best bid, resize a level and add a better ask.
+	assert.NoError(t, book.Apply(Response{Type: "delta", Data: Data{
+		Symbol:   "BTCUSDT",
+		Bids:     [][]string{{"100.0", "0"}, {"99.0", "5"}},
+		Asks:     [][]string{{"100.8", "4"}},
+		UpdateID: 2,
+	}}))
+
+	bids, asks := book.DepthN(2)
+	assert.Equal(t, []Level{{"99.5", "2"}, {"99.0", "5"}}, bids)
+	assert.Equal(t, []Level{{"100.8", "4"}, {"101.0", "1"}}, asks)
+	assert.Equal(t, int64(2), book.UpdateID())
+
+	// A snapshot replaces the whole book.
+	assert.NoError(t, book.Apply(Response{Type: "snapshot", Data: Data{
+		Symbol: "BTCUSDT",
+		Bids:   [][]string{{"50", "1"}},
+	}}))
+	bids, asks = book.DepthN(10)
+	assert.Equal(t, []Level{{"50", "1"}}, bids)
+	assert.Empty(t, asks)
+	_, ok = book.MidPrice()
+	assert.False(t, ok)
+
+	assert.Error(t, book.Apply(Response{Type: "snapshot", Data: Data{Symbol: "ETHUSDT"}}))
+	assert.Error(t, book.Apply(Response{Type: "delta", Data: Data{Symbol: "BTCUSDT", Bids: [][]string{{"x", "1"}}}}))
+}
@@ -0,0 +1,54 @@
+package orderbook
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Order book depths offered by Bybit. Not every category offers every depth; see Depths.
+const (
+	Depth1    = 1
+	Depth25   = 25
+	Depth50   = 50
+	Depth100  = 100
+	Depth200  = 200
+	Depth1000 = 1000
+)
+
+// depths lists the depths of each market. Linear and inverse contracts share a stream
+// layout, as do the legacy category names used by the client.
+var depths = map[string][]int{
+	"spot":    {Depth1, Depth50, Depth200, Depth1000},
+	"linear":  {Depth1, Depth50, Depth200, Depth1000},
+	"inverse": {Depth1, Depth50, Depth200, Depth1000},
+	"option":  {Depth25, Depth100},
+}
+
+// market maps a client category to the key of depths. Categories the client routes to the
+// linear stream, including the empty category, map to "linear".
+func market(category string) string {
+	switch category {
+	case "spot":
+		return "spot"
+	case "inverse", "inverse_contract":
+		return "inverse"
+	case "option", "usdc_option":
+		return "option"
+	default:
+		return "linear"
+	}
+}
+
+// Depths returns the order book depths available for category.
+func Depths(category string) []int {
+	return slices.Clone(depths[market(category)])
+}
+
+// ValidateDepth returns an error unless depth is available for category.
+func ValidateDepth(category string, depth int) error {
+	if !slices.Contains(depths[market(category)], depth) {
+		return fmt.Errorf("order book depth %d is not available for %s, use one of %v",
+			depth, market(category), depths[market(category)])
+	}
+	return nil
+}
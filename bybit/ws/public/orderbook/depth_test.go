@@ -0,0 +1,21 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidateDepth verifies the depths accepted for each category.
+func TestValidateDepth(t *testing.T) {
+	assert.NoError(t, ValidateDepth("spot", Depth1000))
+	assert.NoError(t, ValidateDepth("linear", Depth50))
+	assert.NoError(t, ValidateDepth("", Depth200))
+	assert.NoError(t, ValidateDepth("inverse_contract", Depth1))
+	assert.NoError(t, ValidateDepth("option", Depth25))
+
+	assert.Error(t, ValidateDepth("option", Depth50))
+	assert.Error(t, ValidateDepth("linear", Depth25))
+	assert.Error(t, ValidateDepth("spot", 500))
+	assert.Equal(t, []int{Depth25, Depth100}, Depths("usdc_option"))
+}
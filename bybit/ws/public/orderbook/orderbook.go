@@ -1,11 +1,201 @@
 package orderbook
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Response is an order book message. Type is "snapshot" for the full book and "delta"
+// for incremental updates.
+type Response struct {
+	Topic string `json:"topic"`
+	Type  string `json:"type"`
+	TS    int64  `json:"ts"`
+	Data  Data   `json:"data"`
+	CTS   int64  `json:"cts"`
+}
+
+// Data holds the order book levels. Every level is a [price, size] pair; a size of "0"
+// in a delta removes the level.
+type Data struct {
+	Symbol   string     `json:"s"`
+	Bids     [][]string `json:"b"`
+	Asks     [][]string `json:"a"`
+	UpdateID int64      `json:"u"`
+	Seq      int64      `json:"seq"`
+}
+
+type subscription struct {
+	callback func(Response)
+	remove   func()
+}
+
+// OrderBook manages order book subscriptions. It checks that deltas arrive in sequence and,
+// when one is missed, resubscribes to the topic to obtain a fresh snapshot. Deltas are not
+// delivered between the gap and the new snapshot.
 type OrderBook struct {
-	*client.Client
+	// OnResync, when set, is called with the topic whenever a sequence gap forces a
+	// resubscription. The next message delivered for the topic is a snapshot.
+	OnResync func(topic string)
+
+	client      *client.Client
+	mu          sync.Mutex
+	subscribers map[string]subscription
+	sequences   map[string]*sequence
+}
+
+// sequence is the last update applied to a topic.
+type sequence struct {
+	updateID int64
+	seq      int64
+	// resyncing is set after a gap until the next snapshot arrives.
+	resyncing bool
+}
+
+// New creates an OrderBook on top of cli.
+func New(cli *client.Client) *OrderBook {
+	return &OrderBook{
+		client:      cli,
+		subscribers: make(map[string]subscription),
+		sequences:   make(map[string]*sequence),
+	}
+}
+
+// Subscribe subscribes to the order book of the given depth for each symbol. The depth must
+// be one of Depths for the client's category.
+func (o *OrderBook) Subscribe(symbols []string, depth int, callback func(Response)) error {
+	if err := ValidateDepth(o.client.Category, depth); err != nil {
+		return err
+	}
+	topics := topicsFor(depth, symbols)
+
+	o.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := o.subscribers[topic]; exists {
+			sub.remove()
+		}
+		o.subscribers[topic] = subscription{
+			callback: callback,
+			remove:   o.client.Handle(topic, o.handleMessage),
+		}
+	}
+	o.mu.Unlock()
+
+	if err := o.client.Subscriptions().Subscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to subscribe to order book: %v", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the order book of the given depth for each symbol and
+// removes their callbacks.
+func (o *OrderBook) Unsubscribe(depth int, symbols ...string) error {
+	topics := topicsFor(depth, symbols)
+
+	o.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := o.subscribers[topic]; exists {
+			sub.remove()
+			delete(o.subscribers, topic)
+		}
+		delete(o.sequences, topic)
+	}
+	o.mu.Unlock()
+
+	if err := o.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to unsubscribe from order book: %v", err)
+	}
+	return nil
+}
+
+// Close unsubscribes from all order book of this service and removes their callbacks. The
+// client stays open because it may be shared with other services.
+func (o *OrderBook) Close() {
+	o.mu.Lock()
+	topics := make([]string, 0, len(o.subscribers))
+	for topic, sub := range o.subscribers {
+		sub.remove()
+		topics = append(topics, topic)
+	}
+	o.subscribers = make(map[string]subscription)
+	o.sequences = make(map[string]*sequence)
+	o.mu.Unlock()
+
+	if len(topics) == 0 {
+		return
+	}
+	if err := o.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		o.client.Logger().Error("Failed to unsubscribe from order book: %v", err)
+	}
+}
+
+// handleMessage is registered with the client for every subscribed order book topic.
+func (o *OrderBook) handleMessage(message []byte) {
+	var res Response
+	if err := json.Unmarshal(message, &res); err != nil {
+		o.client.Logger().Error("Error unmarshalling message: %v", err)
+		return
+	}
+
+	o.mu.Lock()
+	sub, exists := o.subscribers[res.Topic]
+	deliver, resync := o.checkSequence(res)
+	o.mu.Unlock()
+
+	if resync {
+		go o.resync(res.Topic)
+	}
+	if exists && deliver {
+		sub.callback(res)
+	}
+}
+
+// checkSequence records res and reports whether it may be delivered and whether a gap was
+// detected. A snapshot always resets the sequence. A delta must carry the update id that
+// follows the previous one and a larger cross sequence. The caller must hold o.mu.
+func (o *OrderBook) checkSequence(res Response) (deliver, resync bool) {
+	current, tracked := o.sequences[res.Topic]
+	if res.Type == "snapshot" {
+		o.sequences[res.Topic] = &sequence{updateID: res.Data.UpdateID, seq: res.Data.Seq}
+		return true, false
+	}
+
+	switch {
+	case tracked && current.resyncing:
+		return false, false
+	case !tracked:
+		o.sequences[res.Topic] = &sequence{resyncing: true}
+		return false, true
+	case res.Data.UpdateID != current.updateID+1 || (res.Data.Seq != 0 && res.Data.Seq <= current.seq):
+		current.resyncing = true
+		return false, true
+	}
+
+	current.updateID = res.Data.UpdateID
+	current.seq = res.Data.Seq
+	return true, false
+}
+
+// resync resubscribes to topic so that the server sends a new snapshot.
+func (o *OrderBook) resync(topic string) {
+	o.client.Logger().Warn("Order book sequence gap on %s, resubscribing", topic)
+	if err := o.client.Subscriptions().Resubscribe(context.Background(), topic); err != nil {
+		o.client.Logger().Error("Failed to resubscribe to %s: %v", topic, err)
+		return
+	}
+	if o.OnResync != nil {
+		o.OnResync(topic)
+	}
 }
 
-func New(cli *client.Client) OrderBook {
-	return OrderBook{cli}
+func topicsFor(depth int, symbols []string) []string {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("orderbook.%d.%s", depth, symbol)
+	}
+	return topics
 }
@@ -0,0 +1,97 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOrderBook_SequenceGap verifies that a missing delta triggers a resubscription and that
+// deltas are withheld until the fresh snapshot arrives.
+func TestOrderBook_SequenceGap(t *testing.T) {
+	const topic = "orderbook.50.BTCUSDT"
+	message := func(typ string, updateID int64) []byte {
+		return []byte(fmt.Sprintf(`{"topic":%q,"type":%q,"data":{"s":"BTCUSDT","b":[],"a":[],"u":%d,"seq":%d}}`,
+			topic, typ, updateID, updateID*10))
+	}
+
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		subscribes := 0
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID string `json:"req_id"`
+				Op    string `json:"op"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			ack := fmt.Sprintf(`{"success":true,"ret_msg":"","conn_id":"1","req_id":%q,"op":%q}`, req.ReqID, req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+			if req.Op != "subscribe" {
+				continue
+			}
+			subscribes++
+			updates := [][]byte{message("snapshot", 10)}
+			if subscribes == 1 {
+				updates = [][]byte{message("snapshot", 1), message("delta", 2), message("delta", 4), message("delta", 5)}
+			}
+			for _, update := range updates {
+				if err := conn.WriteMessage(websocket.TextMessage, update); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(client.WithURL("ws" + strings.TrimPrefix(srv.URL, "http")))
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	var mu sync.Mutex
+	var received []string
+	resyncs := make(chan string, 1)
+	ob := New(cli)
+	ob.OnResync = func(topic string) { resyncs <- topic }
+	err = ob.Subscribe([]string{"BTCUSDT"}, 50, func(res Response) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, fmt.Sprintf("%s:%d", res.Type, res.Data.UpdateID))
+	})
+	assert.NoError(t, err)
+
+	select {
+	case got := <-resyncs:
+		assert.Equal(t, topic, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the resync")
+	}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"snapshot:1", "delta:2", "snapshot:10"}, received)
+}
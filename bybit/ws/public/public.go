@@ -1,99 +1,115 @@
 package public
 
 import (
+	"sync"
+
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/allliquidation"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/kline"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/liquidation"
 	ltkline "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/lt-kline"
 	ltticker "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/lt-ticker"
 	ltnav "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/ltnav"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/orderbook"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/rpiorderbook"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/ticker"
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/trade"
 )
 
 type Public interface {
+	AllLiquidation(category string) *allliquidation.AllLiquidation
 	Kline(category string) (kline.Kline, error)
+	// Deprecated: use AllLiquidation.
 	Liquidation(category string) liquidation.Liquidation
 	LtKline(category string) ltkline.LTKline
-	LtNav(category string) ltnav.LtNav
-	LtTickers(category string) ltticker.LtTicker
-	OrderBook(category string) orderbook.OrderBook
-	Ticker(category string) ticker.Ticker
-	Trade(category string) trade.Trade
+	LtNav(category string) *ltnav.LtNav
+	LtTickers(category string) *ltticker.LtTicker
+	OrderBook(category string) *orderbook.OrderBook
+	// RPIOrderBook streams the retail price improvement order book, which only spot offers.
+	RPIOrderBook() *rpiorderbook.RPIOrderBook
+	Ticker(category string) *ticker.Ticker
+	Trade(category string) *trade.Trade
+	// Close closes the connections opened for categories other than the client's own.
+	Close()
 }
 
+// implPublic multiplexes every service over one connection per category. Services for the
+// category of the injected client use that client; other categories get a client derived
+// from it, created on first use and shared from then on.
 type implPublic struct {
-	client *client.Client
+	client  *client.Client
+	mu      sync.Mutex
+	clients map[string]*client.Client
+}
+
+func (i *implPublic) AllLiquidation(category string) *allliquidation.AllLiquidation {
+	return allliquidation.New(i.clientFor(category))
 }
 
 func (i *implPublic) Kline(category string) (kline.Kline, error) {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return kline.New(cli)
+	return kline.New(i.clientFor(category))
 }
+
 func (i *implPublic) Liquidation(category string) liquidation.Liquidation {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return liquidation.New(cli)
+	return liquidation.New(i.clientFor(category))
 }
 
 func (i *implPublic) LtKline(category string) ltkline.LTKline {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return ltkline.New(cli)
+	return ltkline.New(i.clientFor(category))
+}
+
+func (i *implPublic) LtNav(category string) *ltnav.LtNav {
+	return ltnav.New(i.clientFor(category))
+}
+
+func (i *implPublic) LtTickers(category string) *ltticker.LtTicker {
+	return ltticker.New(i.clientFor(category))
+}
+
+func (i *implPublic) OrderBook(category string) *orderbook.OrderBook {
+	return orderbook.New(i.clientFor(category))
 }
 
-func (i *implPublic) LtNav(category string) ltnav.LtNav {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return ltnav.New(cli)
+func (i *implPublic) RPIOrderBook() *rpiorderbook.RPIOrderBook {
+	return rpiorderbook.New(i.clientFor("spot"))
 }
 
-func (i *implPublic) LtTickers(category string) ltticker.LtTicker {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return ltticker.New(cli)
+func (i *implPublic) Ticker(category string) *ticker.Ticker {
+	return ticker.New(i.clientFor(category))
 }
 
-func (i *implPublic) OrderBook(category string) orderbook.OrderBook {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return orderbook.New(cli)
+func (i *implPublic) Trade(category string) *trade.Trade {
+	return trade.New(i.clientFor(category))
 }
 
-func (i *implPublic) Ticker(category string) ticker.Ticker {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return ticker.New(cli)
+func (i *implPublic) Close() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for category, cli := range i.clients {
+		cli.Close()
+		delete(i.clients, category)
+	}
 }
 
-func (i *implPublic) Trade(category string) trade.Trade {
-	cli := new(client.Client)
-	cli.Category = category
-	cli.APIKey = i.client.APIKey
-	cli.APISecret = i.client.APISecret
-	return trade.New(cli)
+// clientFor returns the shared client for category.
+func (i *implPublic) clientFor(category string) *client.Client {
+	if category == "" || category == i.client.Category {
+		return i.client
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	cli, exists := i.clients[category]
+	if !exists {
+		cli = i.client.ForCategory(category)
+		i.clients[category] = cli
+	}
+	return cli
 }
 
 func New(wsClient *client.Client, isPublic bool) Public {
-	if isPublic {
-		return &implPublic{client: wsClient}
-	} else {
-		return &implPublic{client: wsClient}
+	return &implPublic{
+		client:  wsClient,
+		clients: make(map[string]*client.Client),
 	}
 }
@@ -0,0 +1,27 @@
+package public
+
+import (
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPublic_SharesClients verifies that services of the same category share one client and
+// that other categories get a derived client with the same configuration.
+func TestPublic_SharesClients(t *testing.T) {
+	cli, err := client.NewPublicClient(true, "linear")
+	assert.NoError(t, err)
+	p := New(cli, true).(*implPublic)
+	defer p.Close()
+
+	assert.Same(t, cli, p.clientFor("linear"))
+	assert.Same(t, cli, p.clientFor(""))
+
+	spot := p.clientFor("spot")
+	assert.NotSame(t, cli, spot)
+	assert.Same(t, spot, p.clientFor("spot"))
+	assert.Equal(t, "spot", spot.Category)
+	assert.True(t, spot.IsTestNet)
+	assert.Equal(t, client.ChannelType(client.Public), spot.Channel)
+}
@@ -0,0 +1,158 @@
+package rpiorderbook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Response is an RPI order book message. Type is "snapshot" for the full book and "delta"
+// for incremental updates.
+type Response struct {
+	Topic string `json:"topic"`
+	Type  string `json:"type"`
+	TS    int64  `json:"ts"`
+	CTS   int64  `json:"cts"`
+	Data  Data   `json:"data"`
+}
+
+// Data holds the levels of the RPI order book.
+type Data struct {
+	Symbol   string  `json:"s"`
+	Bids     []Level `json:"b"`
+	Asks     []Level `json:"a"`
+	UpdateID int64   `json:"u"`
+	Seq      int64   `json:"seq"`
+}
+
+// Level is a price level split into the size of regular orders and the size of retail price
+// improvement orders. A level whose sizes are both "0" in a delta is removed.
+type Level struct {
+	Price      string
+	NonRPISize string
+	RPISize    string
+}
+
+// UnmarshalJSON decodes the [price, nonRpiSize, rpiSize] triple Bybit sends for a level.
+func (l *Level) UnmarshalJSON(b []byte) error {
+	var raw []string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 3 {
+		return fmt.Errorf("malformed RPI order book level %v", raw)
+	}
+	l.Price, l.NonRPISize, l.RPISize = raw[0], raw[1], raw[2]
+	return nil
+}
+
+type subscription struct {
+	callback func(Response)
+	remove   func()
+}
+
+// RPIOrderBook manages subscriptions to the retail price improvement order book of spot
+// symbols, which is only available at depth 50.
+type RPIOrderBook struct {
+	client      *client.Client
+	mu          sync.Mutex
+	subscribers map[string]subscription
+}
+
+// New creates an RPIOrderBook on top of cli, a client of the spot category.
+func New(cli *client.Client) *RPIOrderBook {
+	return &RPIOrderBook{
+		client:      cli,
+		subscribers: make(map[string]subscription),
+	}
+}
+
+// Subscribe subscribes to the RPI order book of each symbol. The callback is invoked once per
+// message.
+func (o *RPIOrderBook) Subscribe(symbols []string, callback func(Response)) error {
+	topics := topicsFor(symbols)
+
+	o.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := o.subscribers[topic]; exists {
+			sub.remove()
+		}
+		o.subscribers[topic] = subscription{
+			callback: callback,
+			remove:   o.client.Handle(topic, o.handleMessage),
+		}
+	}
+	o.mu.Unlock()
+
+	if err := o.client.Subscriptions().Subscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to subscribe to RPI order book: %v", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the RPI order book of each symbol and removes their callbacks.
+func (o *RPIOrderBook) Unsubscribe(symbols ...string) error {
+	topics := topicsFor(symbols)
+
+	o.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := o.subscribers[topic]; exists {
+			sub.remove()
+			delete(o.subscribers, topic)
+		}
+	}
+	o.mu.Unlock()
+
+	if err := o.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to unsubscribe from RPI order book: %v", err)
+	}
+	return nil
+}
+
+// Close unsubscribes from all RPI order books of this service and removes their callbacks. The
+// client stays open because it may be shared with other services.
+func (o *RPIOrderBook) Close() {
+	o.mu.Lock()
+	topics := make([]string, 0, len(o.subscribers))
+	for topic, sub := range o.subscribers {
+		sub.remove()
+		topics = append(topics, topic)
+	}
+	o.subscribers = make(map[string]subscription)
+	o.mu.Unlock()
+
+	if len(topics) == 0 {
+		return
+	}
+	if err := o.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		o.client.Logger().Error("Failed to unsubscribe from RPI order book: %v", err)
+	}
+}
+
+// handleMessage is registered with the client for every subscribed RPI order book topic.
+func (o *RPIOrderBook) handleMessage(message []byte) {
+	var res Response
+	if err := json.Unmarshal(message, &res); err != nil {
+		o.client.Logger().Error("Error unmarshalling message: %v", err)
+		return
+	}
+
+	o.mu.Lock()
+	sub, exists := o.subscribers[res.Topic]
+	o.mu.Unlock()
+
+	if exists {
+		sub.callback(res)
+	}
+}
+
+func topicsFor(symbols []string) []string {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("orderbook.rpi.%s", symbol)
+	}
+	return topics
+}
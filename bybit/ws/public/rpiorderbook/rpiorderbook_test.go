@@ -0,0 +1,20 @@
+package rpiorderbook
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResponse_Unmarshal verifies that RPI levels are decoded from their triples.
+func TestResponse_Unmarshal(t *testing.T) {
+	var res Response
+	err := json.Unmarshal([]byte(`{"topic":"orderbook.rpi.BTCUSDT","type":"snapshot","ts":1,"cts":1,`+
+		`"data":{"s":"BTCUSDT","b":[["65000.1","0.5","0.2"]],"a":[["65000.2","0","1.1"]],"u":7,"seq":70}}`), &res)
+	assert.NoError(t, err)
+	assert.Equal(t, []Level{{Price: "65000.1", NonRPISize: "0.5", RPISize: "0.2"}}, res.Data.Bids)
+	assert.Equal(t, []Level{{Price: "65000.2", NonRPISize: "0", RPISize: "1.1"}}, res.Data.Asks)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"data":{"b":[["65000.1","0.5"]]}}`), &res))
+}
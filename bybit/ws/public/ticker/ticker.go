@@ -4,20 +4,23 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"sync"
 
 	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
 )
 
 type response struct {
-	Topic string `json:"topic"`
-	Type  string `json:"type"`
-	Data  Data   `json:"data"`
-	CS    int64  `json:"cs"`
-	TS    int64  `json:"ts"`
+	Topic string          `json:"topic"`
+	Type  string          `json:"type"`
+	Data  json.RawMessage `json:"data"`
+	CS    int64           `json:"cs"`
+	TS    int64           `json:"ts"`
 }
 
+// Data is a linear, inverse or option ticker. Fields that a category does not publish stay
+// empty: linear and inverse tickers fill the funding and bid1/ask1 fields and option tickers
+// fill the bid/ask, implied volatility and greeks fields. Spot tickers are delivered as
+// SpotData by SubscribeSpot.
 type Data struct {
 	Symbol            string `json:"symbol"`
 	TickDirection     string `json:"tickDirection"`
@@ -39,121 +42,214 @@ type Data struct {
 	Bid1Size          string `json:"bid1Size"`
 	Ask1Price         string `json:"ask1Price"`
 	Ask1Size          string `json:"ask1Size"`
+
+	// Option.
+	BidPrice               string `json:"bidPrice"`
+	BidSize                string `json:"bidSize"`
+	BidIv                  string `json:"bidIv"`
+	AskPrice               string `json:"askPrice"`
+	AskSize                string `json:"askSize"`
+	AskIv                  string `json:"askIv"`
+	MarkPriceIv            string `json:"markPriceIv"`
+	UnderlyingPrice        string `json:"underlyingPrice"`
+	TotalVolume            string `json:"totalVolume"`
+	TotalTurnover          string `json:"totalTurnover"`
+	Delta                  string `json:"delta"`
+	Gamma                  string `json:"gamma"`
+	Vega                   string `json:"vega"`
+	Theta                  string `json:"theta"`
+	PredictedDeliveryPrice string `json:"predictedDeliveryPrice"`
+	Change24H              string `json:"change24h"`
+}
+
+// SpotData is a spot ticker. Spot tickers are always pushed as snapshots.
+type SpotData struct {
+	Symbol        string `json:"symbol"`
+	LastPrice     string `json:"lastPrice"`
+	HighPrice24H  string `json:"highPrice24h"`
+	LowPrice24H   string `json:"lowPrice24h"`
+	PrevPrice24H  string `json:"prevPrice24h"`
+	Volume24H     string `json:"volume24h"`
+	Turnover24H   string `json:"turnover24h"`
+	Price24HPcnt  string `json:"price24hPcnt"`
+	USDIndexPrice string `json:"usdIndexPrice"`
 }
 
-// Ticker manages ticker subscriptions and updates.
+// Ticker manages ticker subscriptions and updates. Linear and inverse tickers push a
+// snapshot followed by deltas that only carry the fields that changed; the ticker merges
+// them into a per-symbol snapshot so that callbacks always receive complete Data.
 type Ticker struct {
 	client      *client.Client
 	subscribers map[string]func(Data)
+	spot        map[string]func(SpotData)
+	removers    map[string]func()
+	snapshots   map[string]Data
 	ctx         context.Context
 	cancel      context.CancelFunc
-	mu          sync.RWMutex
-	sendCh      chan []byte
+	mu          sync.Mutex
 }
 
 // New initializes a new Ticker instance with context for graceful shutdown.
-func New(client *client.Client) Ticker {
+func New(client *client.Client) *Ticker {
 	ctx, cancel := context.WithCancel(context.Background())
-	t := Ticker{
+	return &Ticker{
 		client:      client,
 		subscribers: make(map[string]func(Data)),
+		spot:        make(map[string]func(SpotData)),
+		removers:    make(map[string]func()),
+		snapshots:   make(map[string]Data),
 		ctx:         ctx,
 		cancel:      cancel,
-		sendCh:      make(chan []byte),
 	}
-
-	go t.writer()
-
-	return t
 }
 
-// writer is a goroutine that handles all outgoing messages to the WebSocket connection.
-func (t *Ticker) writer() {
-	for msg := range t.sendCh {
-		err := t.client.Send(msg)
-		if err != nil {
-			log.Printf("Error sending message: %v", err)
-		}
+// Subscribe to the ticker updates of the given symbols. The callback receives the updates
+// of every symbol; Data.Symbol tells them apart.
+func (t *Ticker) Subscribe(symbols []string, callback func(Data)) error {
+	topics := topicsFor(symbols)
+	t.mu.Lock()
+	for _, topic := range topics {
+		delete(t.spot, topic)
+		t.subscribers[topic] = callback
 	}
+	t.mu.Unlock()
+	return t.subscribe(topics)
 }
 
-// Subscribe to the ticker updates for a given symbol.
-func (t *Ticker) Subscribe(symbol string, callback func(Data)) error {
+// SubscribeSpot subscribes to the ticker updates of the given spot symbols, decoding them as
+// SpotData. The ticker must be backed by a spot client.
+func (t *Ticker) SubscribeSpot(symbols []string, callback func(SpotData)) error {
+	topics := topicsFor(symbols)
 	t.mu.Lock()
-	defer t.mu.Unlock()
-	topic := fmt.Sprintf("tickers.%s", symbol)
-	t.subscribers[topic] = callback
-
-	// Correctly construct the subscription message with "args"
-	subscriptionMessage := map[string]any{
-		"op":   "subscribe",
-		"args": []string{topic}, // Use an array for topics as per API requirements
+	for _, topic := range topics {
+		delete(t.subscribers, topic)
+		t.spot[topic] = callback
 	}
-	msg, err := json.Marshal(subscriptionMessage)
-	if err != nil {
-		return fmt.Errorf("failed to marshal subscription message: %v", err)
+	t.mu.Unlock()
+	return t.subscribe(topics)
+}
+
+// subscribe registers the message handler of each topic and subscribes to them in one
+// request. When the request is rejected, as it is when any of the topics is unknown, the
+// topics it added are dropped again and the *client.OpError is returned wrapped.
+func (t *Ticker) subscribe(topics []string) error {
+	var added []string
+	t.mu.Lock()
+	for _, topic := range topics {
+		if _, exists := t.removers[topic]; !exists {
+			t.removers[topic] = t.client.Handle(topic, t.handleMessage)
+			added = append(added, topic)
+		}
 	}
+	t.mu.Unlock()
 
-	// Send the subscription message
-	t.sendCh <- msg
+	if err := t.client.Subscriptions().Subscribe(t.ctx, topics...); err != nil {
+		t.drop(added)
+		return fmt.Errorf("failed to subscribe to %v: %w", topics, err)
+	}
 	return nil
 }
 
-// Listen method modified to support graceful shutdown.
-func (t *Ticker) Listen() {
-	for {
-		select {
-		case <-t.ctx.Done(): // Check if shutdown has been initiated.
-			close(t.sendCh)
-			return
-		default:
-			message, err := t.client.Receive()
-			if err != nil {
-				log.Printf("Error receiving message: %v", err)
-				continue
-			}
-
-			var res response
-			if err := json.Unmarshal(message, &res); err != nil {
-				log.Printf("Error unmarshalling message: %v", err)
-				continue
-			}
-
-			t.mu.RLock()
-			callback, exists := t.subscribers[res.Topic]
-			t.mu.RUnlock()
-
-			if exists && (res.Type == "snapshot" || res.Type == "delta") {
-				go callback(res.Data)
-			}
+// drop removes the callbacks, snapshots and message handlers of topics.
+func (t *Ticker) drop(topics []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, topic := range topics {
+		delete(t.subscribers, topic)
+		delete(t.spot, topic)
+		delete(t.snapshots, topic)
+		if remove, exists := t.removers[topic]; exists {
+			remove()
+			delete(t.removers, topic)
 		}
 	}
 }
 
-// Unsubscribe from the ticker updates for a given symbol.
-func (t *Ticker) Unsubscribe(symbol string) error {
+// Listen blocks until Shutdown is called. Updates are delivered to the subscribed
+// callbacks by the client's read pump, so calling Listen is optional.
+func (t *Ticker) Listen() {
+	<-t.ctx.Done()
+}
+
+// handleMessage is registered with the client for every subscribed ticker topic.
+func (t *Ticker) handleMessage(message []byte) {
+	var res response
+	if err := json.Unmarshal(message, &res); err != nil {
+		t.client.Logger().Error("Error unmarshalling message: %v", err)
+		return
+	}
+
 	t.mu.Lock()
-	defer t.mu.Unlock()
-	topic := fmt.Sprintf("tickers.%s", symbol)
+	if spot, exists := t.spot[res.Topic]; exists {
+		t.mu.Unlock()
+		var data SpotData
+		if err := json.Unmarshal(res.Data, &data); err != nil {
+			t.client.Logger().Error("Error unmarshalling spot ticker data: %v", err)
+			return
+		}
+		spot(data)
+		return
+	}
+	callback, exists := t.subscribers[res.Topic]
+	data, ok := t.merge(res)
+	t.mu.Unlock()
 
-	delete(t.subscribers, topic)
+	if exists && ok {
+		callback(data)
+	}
+}
 
-	// Construct the unsubscription message
-	unsubscriptionMessage := map[string]any{
-		"op":   "unsubscribe",
-		"args": []string{topic},
+// merge applies a snapshot or delta to the stored snapshot of its topic and returns the
+// result. A delta only overwrites the fields it carries. Deltas received before the first
+// snapshot are dropped. The caller must hold t.mu.
+func (t *Ticker) merge(res response) (Data, bool) {
+	var data Data
+	switch res.Type {
+	case "snapshot":
+	case "delta":
+		snapshot, ok := t.snapshots[res.Topic]
+		if !ok {
+			return Data{}, false
+		}
+		data = snapshot
+	default:
+		return Data{}, false
 	}
-	msg, err := json.Marshal(unsubscriptionMessage)
-	if err != nil {
-		return fmt.Errorf("failed to marshal unsubscription message: %v", err)
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.client.Logger().Error("Error unmarshalling ticker data: %v", err)
+		return Data{}, false
 	}
+	t.snapshots[res.Topic] = data
+	return data, true
+}
+
+// Unsubscribe from the ticker updates for the given symbols.
+func (t *Ticker) Unsubscribe(symbols ...string) error {
+	topics := topicsFor(symbols)
+	t.drop(topics)
 
-	// Send the unsubscription message
-	t.sendCh <- msg
+	if err := t.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to unsubscribe from %v: %v", topics, err)
+	}
 	return nil
 }
 
 // Shutdown method to cleanly terminate the Listen loop.
 func (t *Ticker) Shutdown() {
 	t.cancel() // Trigger context cancellation.
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for topic, remove := range t.removers {
+		remove()
+		delete(t.removers, topic)
+	}
+}
+
+func topicsFor(symbols []string) []string {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("tickers.%s", symbol)
+	}
+	return topics
 }
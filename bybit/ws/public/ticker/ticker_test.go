@@ -0,0 +1,122 @@
+package ticker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTicker_MergesDeltas verifies that deltas are merged into the last snapshot so that
+// callbacks receive complete data.
+func TestTicker_MergesDeltas(t *testing.T) {
+	tk := New(nil)
+	var received []Data
+	tk.subscribers["tickers.BTCUSDT"] = func(data Data) { received = append(received, data) }
+
+	tk.handleMessage([]byte(`{"topic":"tickers.BTCUSDT","type":"delta","data":{"symbol":"BTCUSDT","bid1Price":"1"}}`))
+	tk.handleMessage([]byte(`{"topic":"tickers.BTCUSDT","type":"snapshot","data":{"symbol":"BTCUSDT","lastPrice":"65000","markPrice":"65001","fundingRate":"0.0001"}}`))
+	tk.handleMessage([]byte(`{"topic":"tickers.BTCUSDT","type":"delta","data":{"symbol":"BTCUSDT","markPrice":"65002"}}`))
+
+	if assert.Len(t, received, 2) {
+		assert.Equal(t, "65000", received[1].LastPrice)
+		assert.Equal(t, "65002", received[1].MarkPrice)
+		assert.Equal(t, "0.0001", received[1].FundingRate)
+	}
+}
+
+// TestTicker_OptionFields verifies that option specific fields are decoded.
+func TestTicker_OptionFields(t *testing.T) {
+	tk := New(nil)
+	var received Data
+	tk.subscribers["tickers.BTC-6JAN23-17500-C"] = func(data Data) { received = data }
+
+	tk.handleMessage([]byte(`{"topic":"tickers.BTC-6JAN23-17500-C","type":"snapshot","data":{"symbol":"BTC-6JAN23-17500-C","bidPrice":"0","markPriceIv":"0.4229","underlyingPrice":"16804.3","openInterest":"2.3","delta":"0.0001","gamma":"0.00000001","vega":"0.0004","theta":"-0.0032"}}`))
+
+	assert.Equal(t, "0.4229", received.MarkPriceIv)
+	assert.Equal(t, "16804.3", received.UnderlyingPrice)
+	assert.Equal(t, "2.3", received.OpenInterest)
+	assert.Equal(t, "0.0001", received.Delta)
+	assert.Equal(t, "0.00000001", received.Gamma)
+}
+
+// TestTicker_Spot verifies that spot tickers are decoded as SpotData.
+func TestTicker_Spot(t *testing.T) {
+	tk := New(nil)
+	var received SpotData
+	tk.spot["tickers.BTCUSDT"] = func(data SpotData) { received = data }
+
+	tk.handleMessage([]byte(`{"topic":"tickers.BTCUSDT","type":"snapshot","data":{"symbol":"BTCUSDT","lastPrice":"21109.77","price24hPcnt":"-0.0052","usdIndexPrice":"21115.04"}}`))
+
+	assert.Equal(t, "21109.77", received.LastPrice)
+	assert.Equal(t, "21115.04", received.USDIndexPrice)
+	assert.Empty(t, tk.snapshots)
+}
+
+// TestTicker_SubscribeSymbols verifies that several symbols are subscribed in one request and
+// that a rejected request is returned as *client.OpError and drops the rejected topics.
+func TestTicker_SubscribeSymbols(t *testing.T) {
+	frames := make(chan []string, 4)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID string   `json:"req_id"`
+				Op    string   `json:"op"`
+				Args  []string `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			if req.ReqID == "" {
+				continue
+			}
+			frames <- req.Args
+			success, retMsg := true, ""
+			if slices.Contains(req.Args, "tickers.XYZUSDT") {
+				success, retMsg = false, "error:handler not found,topic:tickers.XYZUSDT"
+			}
+			ack := fmt.Sprintf(`{"success":%t,"ret_msg":%q,"conn_id":"1","req_id":%q,"op":%q}`, success, retMsg, req.ReqID, req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(client.WithURL("ws" + strings.TrimPrefix(srv.URL, "http")))
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	tk := New(cli)
+	defer tk.Shutdown()
+	assert.NoError(t, tk.Subscribe([]string{"BTCUSDT", "ETHUSDT", "SOLUSDT"}, func(Data) {}))
+	assert.Equal(t, []string{"tickers.BTCUSDT", "tickers.ETHUSDT", "tickers.SOLUSDT"}, <-frames)
+	assert.Empty(t, frames)
+
+	err = tk.Subscribe([]string{"ADAUSDT", "XYZUSDT"}, func(Data) {})
+	assert.Equal(t, []string{"tickers.ADAUSDT", "tickers.XYZUSDT"}, <-frames)
+	var opErr *client.OpError
+	if assert.ErrorAs(t, err, &opErr) {
+		assert.Contains(t, opErr.RetMsg, "tickers.XYZUSDT")
+	}
+	assert.Equal(t, []string{"tickers.BTCUSDT", "tickers.ETHUSDT", "tickers.SOLUSDT"}, cli.Subscriptions().ListSubscriptions())
+	tk.mu.Lock()
+	assert.Len(t, tk.subscribers, 3)
+	assert.Len(t, tk.removers, 3)
+	tk.mu.Unlock()
+}
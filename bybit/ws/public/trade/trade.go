@@ -1,11 +1,185 @@
 package trade
 
-import "github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
 
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Response is a public trade message.
+type Response struct {
+	Topic string `json:"topic"`
+	Type  string `json:"type"`
+	TS    int64  `json:"ts"`
+	Data  []Data `json:"data"`
+}
+
+// Side is the taker side of a trade.
+type Side string
+
+// Trade sides.
+const (
+	SideBuy  Side = "Buy"
+	SideSell Side = "Sell"
+)
+
+// TickDirection is the direction of a trade's price relative to the previous trade.
+type TickDirection string
+
+// Tick directions. The zero variants mean the price is unchanged and carry the direction of
+// the last change.
+const (
+	PlusTick      TickDirection = "PlusTick"
+	ZeroPlusTick  TickDirection = "ZeroPlusTick"
+	MinusTick     TickDirection = "MinusTick"
+	ZeroMinusTick TickDirection = "ZeroMinusTick"
+)
+
+// Data is a single public trade. Price and Size are parsed from the decimal strings Bybit
+// sends.
+type Data struct {
+	Timestamp     int64         `json:"T"`
+	Symbol        string        `json:"s"`
+	Side          Side          `json:"S"`
+	Size          float64       `json:"v"`
+	Price         float64       `json:"p"`
+	TickDirection TickDirection `json:"L"`
+	TradeID       string        `json:"i"`
+	BlockTrade    bool          `json:"BT"`
+}
+
+// UnmarshalJSON decodes a trade, parsing its price and size.
+func (d *Data) UnmarshalJSON(b []byte) error {
+	type alias Data
+	raw := struct {
+		*alias
+		Size  string `json:"v"`
+		Price string `json:"p"`
+	}{alias: (*alias)(d)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	size, err := strconv.ParseFloat(raw.Size, 64)
+	if err != nil {
+		return fmt.Errorf("invalid trade size %q: %v", raw.Size, err)
+	}
+	price, err := strconv.ParseFloat(raw.Price, 64)
+	if err != nil {
+		return fmt.Errorf("invalid trade price %q: %v", raw.Price, err)
+	}
+	d.Size, d.Price = size, price
+	return nil
+}
+
+type subscription struct {
+	callback func(Data)
+	remove   func()
+}
+
+// Trade manages public trade subscriptions.
 type Trade struct {
-	*client.Client
+	client      *client.Client
+	mu          sync.Mutex
+	subscribers map[string]subscription
+}
+
+// New creates a Trade on top of cli.
+func New(cli *client.Client) *Trade {
+	return &Trade{
+		client:      cli,
+		subscribers: make(map[string]subscription),
+	}
+}
+
+// Subscribe subscribes to the public trades of each symbol. The callback is invoked once
+// per trade.
+func (t *Trade) Subscribe(symbols []string, callback func(Data)) error {
+	topics := topicsFor(symbols)
+
+	t.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := t.subscribers[topic]; exists {
+			sub.remove()
+		}
+		t.subscribers[topic] = subscription{
+			callback: callback,
+			remove:   t.client.Handle(topic, t.handleMessage),
+		}
+	}
+	t.mu.Unlock()
+
+	if err := t.client.Subscriptions().Subscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to subscribe to public trades: %v", err)
+	}
+	return nil
+}
+
+// Unsubscribe unsubscribes from the public trades of each symbol and removes their callbacks.
+func (t *Trade) Unsubscribe(symbols ...string) error {
+	topics := topicsFor(symbols)
+
+	t.mu.Lock()
+	for _, topic := range topics {
+		if sub, exists := t.subscribers[topic]; exists {
+			sub.remove()
+			delete(t.subscribers, topic)
+		}
+	}
+	t.mu.Unlock()
+
+	if err := t.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		return fmt.Errorf("failed to unsubscribe from public trades: %v", err)
+	}
+	return nil
+}
+
+// Close unsubscribes from all public trades of this service and removes their callbacks. The
+// client stays open because it may be shared with other services.
+func (t *Trade) Close() {
+	t.mu.Lock()
+	topics := make([]string, 0, len(t.subscribers))
+	for topic, sub := range t.subscribers {
+		sub.remove()
+		topics = append(topics, topic)
+	}
+	t.subscribers = make(map[string]subscription)
+	t.mu.Unlock()
+
+	if len(topics) == 0 {
+		return
+	}
+	if err := t.client.Subscriptions().Unsubscribe(context.Background(), topics...); err != nil {
+		t.client.Logger().Error("Failed to unsubscribe from public trades: %v", err)
+	}
+}
+
+// handleMessage is registered with the client for every subscribed trade topic.
+func (t *Trade) handleMessage(message []byte) {
+	var res Response
+	if err := json.Unmarshal(message, &res); err != nil {
+		t.client.Logger().Error("Error unmarshalling message: %v", err)
+		return
+	}
+
+	t.mu.Lock()
+	sub, exists := t.subscribers[res.Topic]
+	t.mu.Unlock()
+
+	if exists {
+		for _, data := range res.Data {
+			sub.callback(data)
+		}
+	}
 }
 
-func New(cli *client.Client) Trade {
-	return Trade{cli}
+func topicsFor(symbols []string) []string {
+	topics := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		topics[i] = fmt.Sprintf("publicTrade.%s", symbol)
+	}
+	return topics
 }
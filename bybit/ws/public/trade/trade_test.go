@@ -0,0 +1,106 @@
+package trade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_UnmarshalJSON(t *testing.T) {
+	var res Response
+	msg := `{"topic":"publicTrade.BTCUSDT","type":"snapshot","ts":1,"data":[{"T":1,"s":"BTCUSDT","S":"Sell","v":"0.003","p":"16578.50","L":"ZeroMinusTick","i":"t-1","BT":false}]}`
+	assert.NoError(t, json.Unmarshal([]byte(msg), &res))
+	if assert.Len(t, res.Data, 1) {
+		data := res.Data[0]
+		assert.Equal(t, SideSell, data.Side)
+		assert.Equal(t, ZeroMinusTick, data.TickDirection)
+		assert.Equal(t, 0.003, data.Size)
+		assert.Equal(t, 16578.5, data.Price)
+		assert.Equal(t, "t-1", data.TradeID)
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`{"v":"x","p":"1"}`), &Data{}))
+}
+
+// TestTrade_Unsubscribe verifies that Unsubscribe sends the unsubscribe op and removes the
+// callback, so that a trade arriving afterwards is no longer delivered to it.
+func TestTrade_Unsubscribe(t *testing.T) {
+	ops := make(chan string, 4)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID string   `json:"req_id"`
+				Op    string   `json:"op"`
+				Args  []string `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+			if req.ReqID == "" {
+				continue
+			}
+			ops <- req.Op + " " + strings.Join(req.Args, ",")
+			ack := fmt.Sprintf(`{"success":true,"ret_msg":"","conn_id":"1","req_id":%q,"op":%q}`, req.ReqID, req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(ack)); err != nil {
+				return
+			}
+			trade := fmt.Sprintf(`{"topic":%q,"type":"snapshot","ts":1,"data":[{"T":1,"s":"BTCUSDT","S":"Buy","v":"0.001","p":"65000","L":"PlusTick","i":%q,"BT":false}]}`, req.Args[0], req.Op)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(trade)); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(client.WithURL("ws" + strings.TrimPrefix(srv.URL, "http")))
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	trades := make(chan Data, 2)
+	tr := New(cli)
+	assert.NoError(t, tr.Subscribe([]string{"BTCUSDT"}, func(data Data) { trades <- data }))
+	assert.Equal(t, "subscribe publicTrade.BTCUSDT", <-ops)
+	select {
+	case data := <-trades:
+		assert.Equal(t, "subscribe", data.TradeID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the trade")
+	}
+
+	assert.NoError(t, tr.Unsubscribe("BTCUSDT"))
+	assert.Equal(t, "unsubscribe publicTrade.BTCUSDT", <-ops)
+	assert.Empty(t, cli.Subscriptions().ListSubscriptions())
+
+	// The trade the server sends after the unsubscribe ack is no longer routed to the
+	// callback and ends up with the unrouted messages instead.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for {
+		msg, err := cli.ReceiveContext(ctx)
+		if !assert.NoError(t, err) {
+			break
+		}
+		if strings.Contains(string(msg), `"topic":"publicTrade.BTCUSDT"`) {
+			assert.Contains(t, string(msg), `"i":"unsubscribe"`)
+			break
+		}
+	}
+	assert.Empty(t, trades)
+}
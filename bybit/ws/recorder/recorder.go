@@ -0,0 +1,284 @@
+// Package recorder taps stream subscriptions and writes their messages to rotating JSONL or
+// CSV files, optionally gzip compressed, to build historical datasets.
+//
+// JSONL lines hold the raw frame together with its decoded records and can be read back
+// with replay.Load (after gzip.NewReader for compressed files). CSV files hold one row per
+// decoded record with the configured columns.
+package recorder
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Format selects the file format.
+type Format int
+
+const (
+	// JSONL writes one JSON object per frame.
+	JSONL Format = iota
+	// CSV writes one row per decoded record.
+	CSV
+)
+
+// Config configures a Recorder.
+type Config struct {
+	// Dir is the directory the files are written to. It is created if needed.
+	Dir string
+	// Prefix starts every file name; "stream" when empty.
+	Prefix string
+	Format Format
+	// Gzip compresses the files.
+	Gzip bool
+	// MaxSize rotates the file once this many uncompressed bytes were written to it. Zero
+	// disables size based rotation.
+	MaxSize int64
+	// MaxAge rotates the file once it is this old. Zero disables time based rotation.
+	MaxAge time.Duration
+	// Columns are the data fields written after the time, topic and type columns of a CSV
+	// row. Missing fields are left empty.
+	Columns []string
+}
+
+// Line is a JSONL line. Time and Message match replay.Entry.
+type Line struct {
+	Time    int64            `json:"t"`
+	Topic   string           `json:"topic"`
+	Message json.RawMessage  `json:"msg"`
+	Records []map[string]any `json:"records,omitempty"`
+}
+
+// frame is the part of a stream message the recorder decodes.
+type frame struct {
+	Topic string          `json:"topic"`
+	Type  string          `json:"type"`
+	TS    int64           `json:"ts"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Recorder writes stream messages to rotating files. It is safe for concurrent use.
+type Recorder struct {
+	cfg Config
+
+	mu      sync.Mutex
+	file    *os.File
+	gz      *gzip.Writer
+	out     io.Writer
+	csv     *csv.Writer
+	size    int64
+	opened  time.Time
+	counter int
+	err     error
+}
+
+// New creates a Recorder. The first file is opened on the first write.
+func New(cfg Config) (*Recorder, error) {
+	if cfg.Format == CSV && len(cfg.Columns) == 0 {
+		return nil, errors.New("CSV recording requires columns")
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "stream"
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %v", err)
+	}
+	return &Recorder{cfg: cfg}, nil
+}
+
+// Tap registers the recorder as an additional handler of topics on cli. The services that
+// consume the topics are not affected. The returned function removes the handlers.
+func (r *Recorder) Tap(cli *client.Client, topics ...string) (remove func()) {
+	removers := make([]func(), len(topics))
+	for i, topic := range topics {
+		removers[i] = cli.Handle(topic, r.handle)
+	}
+	return func() {
+		for _, remove := range removers {
+			remove()
+		}
+	}
+}
+
+// handle records message, keeping the first error for Err.
+func (r *Recorder) handle(message []byte) {
+	if err := r.Write(message); err != nil {
+		r.mu.Lock()
+		if r.err == nil {
+			r.err = err
+		}
+		r.mu.Unlock()
+	}
+}
+
+// Err returns the first error encountered by a tapped handler.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Write records a single stream message.
+func (r *Recorder) Write(message []byte) error {
+	var f frame
+	if err := json.Unmarshal(message, &f); err != nil {
+		return fmt.Errorf("error unmarshalling message: %v", err)
+	}
+	records, err := decodeRecords(f.Data)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if err := r.rotateIfNeeded(now); err != nil {
+		return err
+	}
+	if r.cfg.Format == CSV {
+		return r.writeCSV(now, f, records)
+	}
+	line, err := json.Marshal(Line{Time: now.UnixMilli(), Topic: f.Topic, Message: message, Records: records})
+	if err != nil {
+		return err
+	}
+	_, err = countingWriter{r}.Write(append(line, '\n'))
+	return err
+}
+
+// Close flushes and closes the current file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closeFile()
+}
+
+// decodeRecords returns the data payload as a list of objects; a single object becomes a
+// list of one.
+func decodeRecords(data json.RawMessage) ([]map[string]any, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var records []map[string]any
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &records); err != nil {
+			return nil, fmt.Errorf("error decoding records: %v", err)
+		}
+		return records, nil
+	}
+	var record map[string]any
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("error decoding record: %v", err)
+	}
+	return []map[string]any{record}, nil
+}
+
+// writeCSV writes a row per record. The caller must hold r.mu.
+func (r *Recorder) writeCSV(now time.Time, f frame, records []map[string]any) error {
+	ts := f.TS
+	if ts == 0 {
+		ts = now.UnixMilli()
+	}
+	for _, record := range records {
+		row := make([]string, 0, len(r.cfg.Columns)+3)
+		row = append(row, fmt.Sprint(ts), f.Topic, f.Type)
+		for _, column := range r.cfg.Columns {
+			value, ok := record[column]
+			if !ok || value == nil {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, fmt.Sprint(value))
+		}
+		if err := r.csv.Write(row); err != nil {
+			return err
+		}
+	}
+	r.csv.Flush()
+	return r.csv.Error()
+}
+
+// rotateIfNeeded opens a new file when none is open or the current one exceeded MaxSize or
+// MaxAge. The caller must hold r.mu.
+func (r *Recorder) rotateIfNeeded(now time.Time) error {
+	if r.file != nil {
+		full := r.cfg.MaxSize > 0 && r.size >= r.cfg.MaxSize
+		old := r.cfg.MaxAge > 0 && now.Sub(r.opened) >= r.cfg.MaxAge
+		if !full && !old {
+			return nil
+		}
+		if err := r.closeFile(); err != nil {
+			return err
+		}
+	}
+
+	r.counter++
+	name := fmt.Sprintf("%s-%s-%03d.%s", r.cfg.Prefix, now.UTC().Format("20060102-150405"), r.counter, r.extension())
+	file, err := os.Create(filepath.Join(r.cfg.Dir, name))
+	if err != nil {
+		return fmt.Errorf("failed to create recording file: %v", err)
+	}
+	r.file, r.out, r.size, r.opened = file, file, 0, now
+	if r.cfg.Gzip {
+		r.gz = gzip.NewWriter(file)
+		r.out = r.gz
+	}
+	if r.cfg.Format == CSV {
+		r.csv = csv.NewWriter(countingWriter{r})
+		header := append([]string{"ts", "topic", "type"}, r.cfg.Columns...)
+		if err := r.csv.Write(header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeFile flushes and closes the current file, if any. The caller must hold r.mu.
+func (r *Recorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	var errs []error
+	if r.csv != nil {
+		r.csv.Flush()
+		errs = append(errs, r.csv.Error())
+	}
+	if r.gz != nil {
+		errs = append(errs, r.gz.Close())
+	}
+	errs = append(errs, r.file.Close())
+	r.file, r.gz, r.out, r.csv = nil, nil, nil, nil
+	return errors.Join(errs...)
+}
+
+func (r *Recorder) extension() string {
+	ext := "jsonl"
+	if r.cfg.Format == CSV {
+		ext = "csv"
+	}
+	if r.cfg.Gzip {
+		ext += ".gz"
+	}
+	return ext
+}
+
+// countingWriter writes to the current file and counts the bytes towards MaxSize. The caller
+// must hold r.mu.
+type countingWriter struct {
+	r *Recorder
+}
+
+func (w countingWriter) Write(p []byte) (int, error) {
+	n, err := w.r.out.Write(p)
+	w.r.size += int64(n)
+	return n, err
+}
@@ -0,0 +1,68 @@
+package recorder
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/replay"
+	"github.com/stretchr/testify/assert"
+)
+
+const tickerFrame = `{"topic":"tickers.BTCUSDT","type":"snapshot","ts":1,"data":{"symbol":"BTCUSDT","lastPrice":"100"}}`
+
+// TestRecorder_JSONLRotation verifies that gzip compressed JSONL files rotate by size and
+// can be replayed.
+func TestRecorder_JSONLRotation(t *testing.T) {
+	dir := t.TempDir()
+	rec, err := New(Config{Dir: dir, Prefix: "btc", Gzip: true, MaxSize: 1})
+	assert.NoError(t, err)
+	assert.NoError(t, rec.Write([]byte(tickerFrame)))
+	assert.NoError(t, rec.Write([]byte(tickerFrame)))
+	assert.NoError(t, rec.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "btc-*.jsonl.gz"))
+	assert.NoError(t, err)
+	if !assert.Len(t, files, 2) {
+		return
+	}
+	f, err := os.Open(files[0])
+	assert.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	assert.NoError(t, err)
+	entries, err := replay.Load(gz)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.JSONEq(t, tickerFrame, string(entries[0].Message))
+	}
+}
+
+// TestRecorder_CSV verifies that decoded records are written with the configured columns.
+func TestRecorder_CSV(t *testing.T) {
+	dir := t.TempDir()
+	rec, err := New(Config{Dir: dir, Format: CSV, Columns: []string{"symbol", "lastPrice", "markPrice"}})
+	assert.NoError(t, err)
+	assert.NoError(t, rec.Write([]byte(tickerFrame)))
+	assert.NoError(t, rec.Write([]byte(`{"topic":"publicTrade.BTCUSDT","type":"snapshot","ts":2,"data":[{"symbol":"BTCUSDT"},{"symbol":"BTCUSDT","lastPrice":1.5}]}`)))
+	assert.NoError(t, rec.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "stream-*.csv"))
+	assert.NoError(t, err)
+	if !assert.Len(t, files, 1) {
+		return
+	}
+	content, err := os.ReadFile(files[0])
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"ts,topic,type,symbol,lastPrice,markPrice",
+		"1,tickers.BTCUSDT,snapshot,BTCUSDT,100,",
+		"2,publicTrade.BTCUSDT,snapshot,BTCUSDT,,",
+		"2,publicTrade.BTCUSDT,snapshot,BTCUSDT,1.5,",
+	}, strings.Split(strings.TrimSpace(string(content)), "\n"))
+
+	_, err = New(Config{Dir: dir, Format: CSV})
+	assert.Error(t, err)
+}
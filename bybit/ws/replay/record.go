@@ -0,0 +1,91 @@
+// Package replay plays recorded WebSocket messages back through the regular stream
+// services, so that strategies can be tested deterministically without a live connection.
+//
+// A Recorder writes the messages a client receives to a log. A Server loads such a log,
+// poses as the Bybit endpoint for clients created by Server.Client and, once Play is
+// called, sends every recorded message to the connections subscribed to its topic at the
+// original pace or faster. The kline, ticker, orderbook and other services run unchanged on
+// top of those clients.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Entry is a recorded message: a JSON line of the log.
+type Entry struct {
+	// Time is the unix time in milliseconds at which the message was received.
+	Time    int64           `json:"t"`
+	Message json.RawMessage `json:"msg"`
+}
+
+// Recorder writes received messages to a log, one Entry per line.
+type Recorder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	err error
+}
+
+// NewRecorder creates a Recorder writing to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// Observe records inbound topic messages and can be passed to client.WithOnRawMessage.
+// Acknowledgements, pongs and outbound messages are skipped.
+func (r *Recorder) Observe(direction client.Direction, message []byte) {
+	if direction != client.Inbound {
+		return
+	}
+	var env struct {
+		Topic string `json:"topic"`
+	}
+	if err := json.Unmarshal(message, &env); err != nil || env.Topic == "" {
+		return
+	}
+	line, err := json.Marshal(Entry{Time: time.Now().UnixMilli(), Message: message})
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		_, r.err = r.w.Write(append(line, '\n'))
+	}
+}
+
+// Err returns the first error encountered while writing the log.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Load reads a log written by a Recorder.
+func Load(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid replay entry on line %d: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading replay log: %v", err)
+	}
+	return entries, nil
+}
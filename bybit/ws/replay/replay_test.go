@@ -0,0 +1,67 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/market"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/kline"
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/public/ticker"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestServer_Play verifies that a recorded log is replayed through the stream services,
+// skipping the topics nobody subscribed to.
+func TestServer_Play(t *testing.T) {
+	var log bytes.Buffer
+	rec := NewRecorder(&log)
+	rec.Observe(client.Inbound, []byte(`{"success":true,"op":"subscribe"}`))
+	rec.Observe(client.Outbound, []byte(`{"topic":"tickers.BTCUSDT"}`))
+	rec.Observe(client.Inbound, []byte(`{"topic":"tickers.BTCUSDT","type":"snapshot","data":{"symbol":"BTCUSDT","lastPrice":"100"}}`))
+	rec.Observe(client.Inbound, []byte(`{"topic":"tickers.ETHUSDT","type":"snapshot","data":{"symbol":"ETHUSDT","lastPrice":"10"}}`))
+	rec.Observe(client.Inbound, []byte(`{"topic":"kline.5.BTCUSDT","type":"snapshot","data":[{"start":1,"interval":"5","close":"101"}]}`))
+	rec.Observe(client.Inbound, []byte(`{"topic":"tickers.BTCUSDT","type":"delta","data":{"symbol":"BTCUSDT","lastPrice":"102"}}`))
+	assert.NoError(t, rec.Err())
+
+	entries, err := Load(&log)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+
+	srv, err := NewServer(entries, 0)
+	assert.NoError(t, err)
+	defer srv.Close()
+	cli, err := srv.Client()
+	assert.NoError(t, err)
+	defer cli.Close()
+
+	tickers := make(chan ticker.Data, 4)
+	assert.NoError(t, ticker.New(cli).Subscribe([]string{"BTCUSDT"}, func(data ticker.Data) { tickers <- data }))
+	kl, err := kline.New(cli)
+	assert.NoError(t, err)
+	klines := make(chan kline.Data, 4)
+	assert.NoError(t, kl.Subscribe([]string{"BTCUSDT"}, market.Interval5m, func(data kline.Data) { klines <- data }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, srv.Play(ctx))
+
+	var prices []string
+	for len(prices) < 2 {
+		select {
+		case data := <-tickers:
+			prices = append(prices, data.LastPrice)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the replayed tickers")
+		}
+	}
+	assert.Equal(t, []string{"100", "102"}, prices)
+	select {
+	case data := <-klines:
+		assert.Equal(t, "101", data.Close)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the replayed kline")
+	}
+}
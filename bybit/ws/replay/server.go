@@ -0,0 +1,200 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+)
+
+// Server poses as a Bybit WebSocket endpoint on a local port and replays recorded messages
+// to the connected clients.
+type Server struct {
+	// Speed scales the recorded pace: 1 replays in real time, 10 ten times faster. Zero or
+	// a negative value sends the messages as fast as possible.
+	Speed float64
+
+	entries  []Entry
+	listener net.Listener
+	http     *http.Server
+	upgrader websocket.Upgrader
+
+	mu    sync.Mutex
+	conns map[*conn]struct{}
+}
+
+// conn is a client connection with the topics it is subscribed to.
+type conn struct {
+	ws     *websocket.Conn
+	mu     sync.Mutex
+	topics map[string]struct{}
+}
+
+// request is the part of a client message the server answers.
+type request struct {
+	ReqID string `json:"req_id"`
+	Op    string `json:"op"`
+	Args  []any  `json:"args"`
+}
+
+// NewServer starts a Server for entries on a local port.
+func NewServer(entries []Entry, speed float64) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		Speed:    speed,
+		entries:  entries,
+		listener: listener,
+		conns:    make(map[*conn]struct{}),
+	}
+	s.http = &http.Server{Handler: http.HandlerFunc(s.serve)}
+	go s.http.Serve(listener)
+	return s, nil
+}
+
+// URL returns the WebSocket URL of the server.
+func (s *Server) URL() string {
+	return "ws://" + s.listener.Addr().String()
+}
+
+// Client creates a client connected to the server. opts are applied after the server URL.
+func (s *Server) Client(opts ...client.Option) (*client.Client, error) {
+	return client.NewClient(append([]client.Option{client.WithURL(s.URL())}, opts...)...)
+}
+
+// Play sends every entry to the connections subscribed to its topic, waiting between
+// entries according to the recorded times and Speed. It returns once every entry has been
+// written or ctx is done. Subscribe the services before calling Play: entries of topics
+// without subscribers are skipped.
+func (s *Server) Play(ctx context.Context) error {
+	var timer *time.Timer
+	for i, entry := range s.entries {
+		if i > 0 && s.Speed > 0 {
+			recorded := time.Duration(entry.Time-s.entries[i-1].Time) * time.Millisecond
+			wait := time.Duration(float64(recorded) / s.Speed)
+			if wait > 0 {
+				if timer == nil {
+					timer = time.NewTimer(wait)
+					defer timer.Stop()
+				} else {
+					timer.Reset(wait)
+				}
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.publish(entry.Message)
+	}
+	return nil
+}
+
+// Close disconnects the clients and stops the server.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	for c := range s.conns {
+		c.ws.Close()
+	}
+	s.mu.Unlock()
+	return s.http.Close()
+}
+
+// publish writes message to every connection subscribed to its topic.
+func (s *Server) publish(message json.RawMessage) {
+	var env struct {
+		Topic string `json:"topic"`
+	}
+	if err := json.Unmarshal(message, &env); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range conns {
+		c.mu.Lock()
+		if _, subscribed := c.topics[env.Topic]; subscribed {
+			_ = c.ws.WriteMessage(websocket.TextMessage, message)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// serve upgrades a client connection and answers its requests until it disconnects.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &conn{ws: ws, topics: make(map[string]struct{})}
+	s.mu.Lock()
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		ws.Close()
+	}()
+
+	for {
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req request
+		if err := json.Unmarshal(message, &req); err != nil {
+			continue
+		}
+		if err := c.answer(req); err != nil {
+			return
+		}
+	}
+}
+
+// answer updates the subscriptions of c and acknowledges req.
+func (c *conn) answer(req request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var reply any
+	switch req.Op {
+	case client.PingOperation:
+		reply = map[string]any{"op": client.PongOperation, "req_id": req.ReqID}
+	case client.SubscribeOperation, client.UnsubscribeOperation, client.AuthOperation:
+		for _, arg := range req.Args {
+			topic, _ := arg.(string)
+			switch req.Op {
+			case client.SubscribeOperation:
+				c.topics[topic] = struct{}{}
+			case client.UnsubscribeOperation:
+				delete(c.topics, topic)
+			}
+		}
+		reply = map[string]any{"success": true, "ret_msg": "", "conn_id": "replay", "req_id": req.ReqID, "op": req.Op}
+	default:
+		return nil
+	}
+	ack, err := json.Marshal(reply)
+	if err != nil {
+		return errors.New("failed to encode replay acknowledgement")
+	}
+	return c.ws.WriteMessage(websocket.TextMessage, ack)
+}
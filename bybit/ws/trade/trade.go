@@ -0,0 +1,197 @@
+package trade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+)
+
+// Operations of the order entry API.
+const (
+	CreateOperation = "order.create"
+	AmendOperation  = "order.amend"
+	CancelOperation = "order.cancel"
+)
+
+// DefaultRecvWindow is the X-BAPI-RECV-WINDOW sent when Trade.RecvWindow is empty.
+const DefaultRecvWindow = "5000"
+
+// CreateOrderRequest holds the parameters of order.create.
+type CreateOrderRequest struct {
+	Category         string `json:"category"`
+	Symbol           string `json:"symbol"`
+	IsLeverage       int    `json:"isLeverage,omitempty"`
+	Side             string `json:"side"`
+	OrderType        string `json:"orderType"`
+	Qty              string `json:"qty"`
+	MarketUnit       string `json:"marketUnit,omitempty"`
+	Price            string `json:"price,omitempty"`
+	TriggerDirection int    `json:"triggerDirection,omitempty"`
+	OrderFilter      string `json:"orderFilter,omitempty"`
+	TriggerPrice     string `json:"triggerPrice,omitempty"`
+	TriggerBy        string `json:"triggerBy,omitempty"`
+	OrderIv          string `json:"orderIv,omitempty"`
+	TimeInForce      string `json:"timeInForce,omitempty"`
+	PositionIdx      int    `json:"positionIdx,omitempty"`
+	OrderLinkID      string `json:"orderLinkId,omitempty"`
+	TakeProfit       string `json:"takeProfit,omitempty"`
+	StopLoss         string `json:"stopLoss,omitempty"`
+	TpTriggerBy      string `json:"tpTriggerBy,omitempty"`
+	SlTriggerBy      string `json:"slTriggerBy,omitempty"`
+	ReduceOnly       bool   `json:"reduceOnly,omitempty"`
+	CloseOnTrigger   bool   `json:"closeOnTrigger,omitempty"`
+	SmpType          string `json:"smpType,omitempty"`
+	Mmp              bool   `json:"mmp,omitempty"`
+	TpslMode         string `json:"tpslMode,omitempty"`
+	TpLimitPrice     string `json:"tpLimitPrice,omitempty"`
+	SlLimitPrice     string `json:"slLimitPrice,omitempty"`
+	TpOrderType      string `json:"tpOrderType,omitempty"`
+	SlOrderType      string `json:"slOrderType,omitempty"`
+}
+
+// AmendOrderRequest holds the parameters of order.amend.
+type AmendOrderRequest struct {
+	Category     string `json:"category"`
+	Symbol       string `json:"symbol"`
+	OrderID      string `json:"orderId,omitempty"`
+	OrderLinkID  string `json:"orderLinkId,omitempty"`
+	OrderIv      string `json:"orderIv,omitempty"`
+	TriggerPrice string `json:"triggerPrice,omitempty"`
+	Qty          string `json:"qty,omitempty"`
+	Price        string `json:"price,omitempty"`
+	TpslMode     string `json:"tpslMode,omitempty"`
+	TakeProfit   string `json:"takeProfit,omitempty"`
+	StopLoss     string `json:"stopLoss,omitempty"`
+	TpTriggerBy  string `json:"tpTriggerBy,omitempty"`
+	SlTriggerBy  string `json:"slTriggerBy,omitempty"`
+	TriggerBy    string `json:"triggerBy,omitempty"`
+	TpLimitPrice string `json:"tpLimitPrice,omitempty"`
+	SlLimitPrice string `json:"slLimitPrice,omitempty"`
+}
+
+// CancelOrderRequest holds the parameters of order.cancel.
+type CancelOrderRequest struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	OrderID     string `json:"orderId,omitempty"`
+	OrderLinkID string `json:"orderLinkId,omitempty"`
+	OrderFilter string `json:"orderFilter,omitempty"`
+}
+
+// Response is the typed acknowledgement of an order entry call.
+type Response struct {
+	ReqID      string            `json:"reqId"`
+	RetCode    int               `json:"retCode"`
+	RetMsg     string            `json:"retMsg"`
+	Op         string            `json:"op"`
+	Data       OrderResult       `json:"data"`
+	RetExtInfo json.RawMessage   `json:"retExtInfo"`
+	Header     map[string]string `json:"header"`
+	ConnID     string            `json:"connId"`
+}
+
+// OrderResult identifies the order an order entry call acted on.
+type OrderResult struct {
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"`
+}
+
+// Error is returned when Bybit rejects an order entry call.
+type Error struct {
+	Op      string
+	RetCode int
+	RetMsg  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s failed with retCode %d: %s", e.Op, e.RetCode, e.RetMsg)
+}
+
+// message is the wire format of an order entry call.
+type message struct {
+	ReqID  string            `json:"reqId"`
+	Header map[string]string `json:"header"`
+	Op     string            `json:"op"`
+	Args   []any             `json:"args"`
+}
+
+// Trade places, amends and cancels orders over an authenticated trade channel connection,
+// which skips the HTTP round trip of the REST API.
+type Trade struct {
+	client *client.Client
+	// RecvWindow is the X-BAPI-RECV-WINDOW header in milliseconds; DefaultRecvWindow when
+	// empty.
+	RecvWindow string
+	// Timeout bounds every call whose context has no deadline; client.AckTimeout when zero.
+	Timeout time.Duration
+}
+
+// New creates a Trade on top of cli, a client on the trade channel such as one returned by
+// client.NewTradeClient.
+func New(cli *client.Client) *Trade {
+	return &Trade{client: cli}
+}
+
+// CreateOrder places an order.
+func (t *Trade) CreateOrder(ctx context.Context, req *CreateOrderRequest) (*Response, error) {
+	return t.call(ctx, CreateOperation, req)
+}
+
+// AmendOrder amends an open order.
+func (t *Trade) AmendOrder(ctx context.Context, req *AmendOrderRequest) (*Response, error) {
+	return t.call(ctx, AmendOperation, req)
+}
+
+// CancelOrder cancels an open order.
+func (t *Trade) CancelOrder(ctx context.Context, req *CancelOrderRequest) (*Response, error) {
+	return t.call(ctx, CancelOperation, req)
+}
+
+// call authenticates the connection if needed, sends op and decodes its response. A
+// rejected call returns the response together with *Error.
+func (t *Trade) call(ctx context.Context, op string, args any) (*Response, error) {
+	if _, ok := ctx.Deadline(); !ok && t.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.Timeout)
+		defer cancel()
+	}
+	if err := t.client.EnsureAuthenticated(ctx); err != nil {
+		return nil, fmt.Errorf("failed to authenticate trade connection: %v", err)
+	}
+
+	ack, err := t.client.Call(ctx, op, func(reqID string) any {
+		return message{ReqID: reqID, Header: t.header(), Op: op, Args: []any{args}}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var res Response
+	if err := json.Unmarshal(ack.Raw, &res); err != nil {
+		return nil, fmt.Errorf("error unmarshalling %s response: %v", op, err)
+	}
+	if res.RetCode != 0 {
+		return &res, &Error{Op: op, RetCode: res.RetCode, RetMsg: res.RetMsg}
+	}
+	return &res, nil
+}
+
+// header returns the headers sent with every call.
+func (t *Trade) header() map[string]string {
+	recvWindow := t.RecvWindow
+	if recvWindow == "" {
+		recvWindow = DefaultRecvWindow
+	}
+	header := map[string]string{
+		"X-BAPI-TIMESTAMP":   strconv.FormatInt(time.Now().UnixMilli(), 10),
+		"X-BAPI-RECV-WINDOW": recvWindow,
+	}
+	if t.client.BrokerID != "" {
+		header["Referer"] = t.client.BrokerID
+	}
+	return header
+}
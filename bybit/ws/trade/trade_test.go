@@ -0,0 +1,94 @@
+package trade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cploutarchou/crypto-sdk-suite/bybit/ws/client"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTrade_Calls verifies that calls authenticate first, carry the broker id, are
+// correlated with their responses by reqId and surface rejections as *Error.
+func TestTrade_Calls(t *testing.T) {
+	referers := make(chan string, 2)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		authenticated := false
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req struct {
+				ReqID  string            `json:"reqId"`
+				Header map[string]string `json:"header"`
+				Op     string            `json:"op"`
+				Args   []map[string]any  `json:"args"`
+			}
+			_ = json.Unmarshal(msg, &req)
+
+			if req.Op != "auth" {
+				referers <- req.Header["Referer"]
+			}
+			var res string
+			switch {
+			case req.Op == "auth":
+				authenticated = true
+				res = `{"retCode":0,"retMsg":"OK","op":"auth","connId":"c1"}`
+			case !authenticated || req.Header["X-BAPI-TIMESTAMP"] == "":
+				res = fmt.Sprintf(`{"reqId":%q,"retCode":10003,"retMsg":"not authenticated","op":%q}`, req.ReqID, req.Op)
+			case req.Op == CancelOperation:
+				res = fmt.Sprintf(`{"reqId":%q,"retCode":110001,"retMsg":"order not exists or too late to cancel","op":%q,"data":{}}`, req.ReqID, req.Op)
+			case req.Op == CreateOperation:
+				res = fmt.Sprintf(`{"reqId":%q,"retCode":0,"retMsg":"OK","op":%q,"data":{"orderId":"o-1","orderLinkId":%q},"connId":"c1"}`,
+					req.ReqID, req.Op, req.Args[0]["orderLinkId"])
+			default:
+				continue
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(res)); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	cli, err := client.NewClient(
+		client.WithURL("ws"+strings.TrimPrefix(srv.URL, "http")),
+		client.WithCredentials("key", "secret"),
+		client.WithChannel(client.Trade),
+		client.WithBrokerID("broker-1"),
+	)
+	assert.NoError(t, err)
+	defer cli.Close()
+	tr := New(cli)
+	ctx := context.Background()
+
+	res, err := tr.CreateOrder(ctx, &CreateOrderRequest{
+		Category: "linear", Symbol: "BTCUSDT", Side: "Buy", OrderType: "Market", Qty: "0.001", OrderLinkID: "link-1",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "o-1", res.Data.OrderID)
+		assert.Equal(t, "link-1", res.Data.OrderLinkID)
+	}
+
+	res, err = tr.CancelOrder(ctx, &CancelOrderRequest{Category: "linear", Symbol: "BTCUSDT", OrderID: "o-2"})
+	var tradeErr *Error
+	if assert.ErrorAs(t, err, &tradeErr) {
+		assert.Equal(t, 110001, tradeErr.RetCode)
+		assert.Equal(t, CancelOperation, res.Op)
+	}
+	assert.Equal(t, "broker-1", <-referers)
+	assert.Equal(t, "broker-1", <-referers)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -27,55 +28,55 @@ func init() {
 	acc = account.New(bybitCli)
 }
 
-func getWalletBalance() (any, error) {
+func getWalletBalance() (*account.WalletBalance, error) {
 	wallet := acc.Wallet()
 	fmt.Println("getWalletBalance")
-	return wallet.GetContractWalletBalance("BTC")
+	return wallet.GetContractWalletBalance(context.Background(), "BTC")
 }
 
-func upgradeToUnified() (any, error) {
+func upgradeToUnified() (*account.UpgradeToUnifiedResponse, error) {
 	fmt.Println("upgradeToUnified")
 	upgrade := acc.UpgradeToUnified()
-	return upgrade.Upgrade()
+	return upgrade.Upgrade(context.Background())
 }
 
-func getBorrowHistory() (any, error) {
+func getBorrowHistory() (*account.BorrowRes, error) {
 	fmt.Println("getBorrowHistory")
 	borrow := acc.Borrow()
-	return borrow.GetHistory("BTC", 0, 0, 0, "")
+	return borrow.GetHistory(context.Background(), "BTC", 0, 0, 0, "")
 }
 
-func getCollateralCoin() (any, error) {
+func getCollateralCoin() (*account.CollateralInfoResponse, error) {
 	fmt.Println("getCollateralCoin")
 	collateral := acc.Collateral()
-	return collateral.GetInfo("BTCUSDT")
+	return collateral.GetInfo(context.Background(), "BTCUSDT")
 }
 
-func setCollateralCoin() (any, error) {
+func setCollateralCoin() (*account.CollateralInfoResponse, error) {
 	fmt.Println("setCollateralCoin")
 	collateral := acc.Collateral()
-	return collateral.Set("BTC", "ON")
+	return collateral.Set(context.Background(), "BTC", "ON")
 }
 
-func getCoinGreeks() (any, error) {
+func getCoinGreeks() (*account.CoinGreekRes, error) {
 	fmt.Println("getCoinGreeks")
 	coinGreeks := acc.CoinGreek()
-	return coinGreeks.Get("BTC")
+	return coinGreeks.Get(context.Background(), "BTC")
 }
 
-func getFeeRates() (any, error) {
+func getFeeRates() (*account.FeeRatesResponse, error) {
 	fmt.Println("getFeeRates")
 	feeRates := acc.FeeRates()
-	return feeRates.GetFeeRate("taker", "BTCUSDT", "USDT")
+	return feeRates.GetFeeRate(context.Background(), "taker", "BTCUSDT", "USDT")
 }
 
-func getInfo() (any, error) {
+func getInfo() (*account.AccInfoResponse, error) {
 	fmt.Println("getInfo")
 	info := acc.Info()
-	return info.Get()
+	return info.Get(context.Background())
 }
 
-func getTransactionLog() (any, error) {
+func getTransactionLog() (*account.TransactionLogResponse, error) {
 	params := map[string]string{
 		"accountType": "UNIFIED",
 		"category":    "linear",
@@ -83,16 +84,16 @@ func getTransactionLog() (any, error) {
 	}
 	fmt.Println("getTransactionLog")
 	transactionLog := acc.TransactionLog()
-	return transactionLog.Get(params)
+	return transactionLog.Get(context.Background(), params)
 }
 
-func setMargin() (any, error) {
+func setMargin() (*account.SetMarginModeResponse, error) {
 	margin := acc.Margin()
 	fmt.Println("setMargin")
-	return margin.SetMarginMode("ISOLATED")
+	return margin.SetMarginMode(context.Background(), "ISOLATED")
 }
 
-func setMMP() (any, error) {
+func setMMP() (*account.MMPResponse, error) {
 	margin := acc.Margin()
 	params := &account.MMPParams{
 		BaseCoin:     "BTC",
@@ -102,19 +103,19 @@ func setMMP() (any, error) {
 		DeltaLimit:   100,
 	}
 	fmt.Println("setMMP")
-	return margin.SetMMP(params)
+	return margin.SetMMP(context.Background(), params)
 }
 
-func resetMMP() (any, error) {
+func resetMMP() (*account.MMPResponse, error) {
 	margin := acc.Margin()
 	fmt.Println("resetMMP")
-	return margin.ResetMMP("BTC")
+	return margin.ResetMMP(context.Background(), "BTC")
 }
 
-func getMMPState() (any, error) {
+func getMMPState() (*account.MMPStateResponse, error) {
 	margin := acc.Margin()
 	fmt.Println("getMMPState")
-	return margin.GetMMPState("BTC")
+	return margin.GetMMPState(context.Background(), "BTC")
 }
 
 func wsConnectTicker() {
@@ -135,7 +136,7 @@ func wsConnectTicker() {
 
 	ticker := publicWS.Ticker("linear")
 
-	err = ticker.Subscribe("BTCUSDT", func(data ticker2.Data) {
+	err = ticker.Subscribe([]string{"BTCUSDT"}, func(data ticker2.Data) {
 		if data.LastPrice != "" {
 			lastPrice, parseErr := strconv.ParseFloat(data.LastPrice, 64)
 			if parseErr != nil {
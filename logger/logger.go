@@ -109,6 +109,11 @@ func (l *Logger) Warning(format string, v ...any) {
 	l.log(WARNING, format, v...)
 }
 
+// Warn logs a message at WARNING level. It is an alias of Warning.
+func (l *Logger) Warn(format string, v ...any) {
+	l.log(WARNING, format, v...)
+}
+
 // Error logs a message at ERROR level
 func (l *Logger) Error(format string, v ...any) {
 	l.log(ERROR, format, v...)